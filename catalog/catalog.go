@@ -3,8 +3,12 @@ package catalog
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
+	"regexp"
+	"regexp/syntax"
+	"slices"
 	"sort"
 	"strings"
 	"time"
@@ -12,15 +16,38 @@ import (
 	goversion "github.com/netapp/ontap-mcp/third_party/go-version"
 )
 
+// FilterInfo describes a query filter of an endpoint. Since is the first ONTAP
+// version with a filter, field or endpoint, and Until the first version
+// without it. An empty Since means it is as old as its endpoint, and an empty
+// Until that no cataloged version removed it.
+//
+// Type is the JSON type of a field or filter, with Items the type of the
+// elements of an array. An empty Type of a filter means string. Format refines
+// the type, e.g. date-time, Unit is what a number counts, e.g. bytes, and Enum
+// lists the values a string may have.
 type FilterInfo struct {
-	Type  string `json:"type,omitempty"`
-	Since string `json:"since,omitempty"`
-	Desc  string `json:"desc,omitempty"`
+	Type   string   `json:"type,omitempty"`
+	Items  string   `json:"items,omitempty"`
+	Format string   `json:"format,omitempty"`
+	Unit   string   `json:"unit,omitempty"`
+	Enum   []string `json:"enum,omitempty"`
+	Since  string   `json:"since,omitempty"`
+	Until  string   `json:"until,omitempty"`
+	Desc   string   `json:"desc,omitempty"`
 }
 
+// FieldInfo describes a field of the records of an endpoint. ReadOnly fields
+// are set by ONTAP and cannot be changed by a PATCH or POST.
 type FieldInfo struct {
-	Since string `json:"since,omitempty"`
-	Desc  string `json:"desc"`
+	Type     string   `json:"type,omitempty"`
+	Items    string   `json:"items,omitempty"`
+	Format   string   `json:"format,omitempty"`
+	Unit     string   `json:"unit,omitempty"`
+	Enum     []string `json:"enum,omitempty"`
+	ReadOnly bool     `json:"read_only,omitempty"`
+	Since    string   `json:"since,omitempty"`
+	Until    string   `json:"until,omitempty"`
+	Desc     string   `json:"desc"`
 }
 
 // PathParamInfo describes a path parameter (e.g. {uuid}) in an endpoint template.
@@ -32,6 +59,7 @@ type APIEndpoint struct {
 	Summary    string                   `json:"summary"`
 	Tags       []string                 `json:"tags"`
 	Introduced string                   `json:"api_introduced,omitempty"`
+	Until      string                   `json:"until,omitempty"`
 	PathParams map[string]PathParamInfo `json:"path_params,omitempty"`
 	Filters    map[string]FilterInfo    `json:"filters,omitempty"`
 	Fields     map[string]FieldInfo     `json:"fields,omitempty"`
@@ -46,9 +74,10 @@ var ExcludedPathPrefixes = []string{
 type APICatalog map[string]APIEndpoint
 
 type File struct {
-	ONTAPVersion string     `json:"ontap_version"`
-	GeneratedAt  string     `json:"generated_at"`
-	Endpoints    APICatalog `json:"endpoints"`
+	ONTAPVersion  string     `json:"ontap_version"`
+	ONTAPVersions []string   `json:"ontap_versions,omitempty"`
+	GeneratedAt   string     `json:"generated_at"`
+	Endpoints     APICatalog `json:"endpoints"`
 }
 
 func Load(path string) (APICatalog, error) {
@@ -56,22 +85,42 @@ func Load(path string) (APICatalog, error) {
 	if err != nil {
 		return nil, fmt.Errorf("read catalog %s: %w", path, err)
 	}
+	cat, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("parse catalog %s: %w", path, err)
+	}
+	return cat, nil
+}
+
+// Parse returns the endpoints of a catalog file. A catalog without endpoints
+// is an error.
+func Parse(data []byte) (APICatalog, error) {
 	var cf File
 	if err := json.Unmarshal(data, &cf); err != nil {
-		return nil, fmt.Errorf("parse catalog %s: %w", path, err)
+		return nil, err
+	}
+	if len(cf.Endpoints) == 0 {
+		return nil, errors.New("catalog has no endpoints")
 	}
 	return cf.Endpoints, nil
 }
 
-func Save(cat APICatalog, ontapVersion string, path string) error {
+// Save writes cat, generated from the swaggers of ontapVersions, to path.
+func Save(cat APICatalog, ontapVersions []string, path string) error {
+	if len(ontapVersions) == 0 {
+		return errors.New("at least one ONTAP version is required")
+	}
 	if err := os.MkdirAll(dirOf(path), 0750); err != nil {
 		return err
 	}
 	cf := File{
-		ONTAPVersion: ontapVersion,
+		ONTAPVersion: ontapVersions[len(ontapVersions)-1],
 		GeneratedAt:  time.Now().UTC().Format(time.RFC3339),
 		Endpoints:    cat,
 	}
+	if len(ontapVersions) > 1 {
+		cf.ONTAPVersions = ontapVersions
+	}
 	var buf bytes.Buffer
 	enc := json.NewEncoder(&buf)
 	enc.SetEscapeHTML(false)
@@ -85,32 +134,53 @@ func Save(cat APICatalog, ontapVersion string, path string) error {
 type SearchResult struct {
 	Path     string      `json:"path"`
 	Endpoint APIEndpoint `json:"endpoint"`
+	Score    float64     `json:"score,omitempty"`
+	Reasons  []string    `json:"reasons,omitempty"`
 }
 
-func (c APICatalog) Search(query string) []SearchResult {
-	lower := strings.ToLower(query)
-	seen := make(map[string]bool)
+// Limits of the patterns Filter accepts. Go regular expressions run in
+// linear time, so these only bound the work a single pattern can cause.
+const (
+	maxPatternLen  = 256
+	maxPatternInst = 5000
+)
+
+// CompilePattern compiles a case-insensitive regular expression for Filter.
+// Patterns longer than maxPatternLen, or that compile to more than
+// maxPatternInst instructions, e.g. through nested repetition counts, are
+// rejected.
+func CompilePattern(pattern string) (*regexp.Regexp, error) {
+	if len(pattern) > maxPatternLen {
+		return nil, fmt.Errorf("pattern is longer than %d characters", maxPatternLen)
+	}
+	re, err := syntax.Parse(pattern, syntax.Perl|syntax.FoldCase)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+	}
+	prog, err := syntax.Compile(re.Simplify())
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+	}
+	if len(prog.Inst) > maxPatternInst {
+		return nil, fmt.Errorf("pattern %q is too complex", pattern)
+	}
+	return regexp.Compile("(?i)" + pattern)
+}
+
+// Filter returns the endpoints, sorted by path, whose path, summary or a tag
+// matches re, and that have tag. A nil re or an empty tag matches every
+// endpoint.
+func (c APICatalog) Filter(re *regexp.Regexp, tag string) []SearchResult {
 	var results []SearchResult
-	for path, ep := range c {
-		if seen[path] {
+	for _, r := range c.ListAll() {
+		if tag != "" && !slices.ContainsFunc(r.Endpoint.Tags, func(t string) bool { return strings.EqualFold(t, tag) }) {
 			continue
 		}
-		matched := strings.Contains(strings.ToLower(path), lower) ||
-			strings.Contains(strings.ToLower(ep.Summary), lower)
-		if !matched {
-			for _, tag := range ep.Tags {
-				if strings.Contains(strings.ToLower(tag), lower) {
-					matched = true
-					break
-				}
-			}
-		}
-		if matched {
-			seen[path] = true
-			results = append(results, SearchResult{Path: path, Endpoint: ep})
+		if re != nil && !re.MatchString(r.Path) && !re.MatchString(r.Endpoint.Summary) && !slices.ContainsFunc(r.Endpoint.Tags, re.MatchString) {
+			continue
 		}
+		results = append(results, r)
 	}
-	sort.Slice(results, func(i, j int) bool { return results[i].Path < results[j].Path })
 	return results
 }
 
@@ -123,36 +193,46 @@ func (c APICatalog) ListAll() []SearchResult {
 	return results
 }
 
+// AvailableIn reports whether ONTAP ontapVersion has the endpoint.
+func (ep APIEndpoint) AvailableIn(ontapVersion string) bool {
+	return InRange(ep.Introduced, ep.Until, ontapVersion)
+}
+
 func (ep APIEndpoint) FilterByVersion(ontapVersion string) APIEndpoint {
 	out := APIEndpoint{
 		Summary:    ep.Summary,
 		Tags:       ep.Tags,
 		Introduced: ep.Introduced,
+		Until:      ep.Until,
 		PathParams: ep.PathParams,
 		Filters:    make(map[string]FilterInfo, len(ep.Filters)),
 		Fields:     make(map[string]FieldInfo, len(ep.Fields)),
 	}
 	for k, v := range ep.Filters {
-		since := v.Since
-		if since == "" {
-			since = "9.6"
-		}
-		if compareVersions(since, ontapVersion) <= 0 {
+		if InRange(v.Since, v.Until, ontapVersion) {
 			out.Filters[k] = v
 		}
 	}
 	for k, v := range ep.Fields {
-		since := v.Since
-		if since == "" {
-			since = "9.6"
-		}
-		if compareVersions(since, ontapVersion) <= 0 {
+		if InRange(v.Since, v.Until, ontapVersion) {
 			out.Fields[k] = v
 		}
 	}
 	return out
 }
 
+// InRange reports whether ontapVersion is at least since, 9.6 when empty, and
+// older than until, when set.
+func InRange(since, until, ontapVersion string) bool {
+	if since == "" {
+		since = "9.6"
+	}
+	if compareVersions(since, ontapVersion) > 0 {
+		return false
+	}
+	return until == "" || compareVersions(ontapVersion, until) < 0
+}
+
 func CompareVersions(a, b string) int {
 	return compareVersions(a, b)
 }
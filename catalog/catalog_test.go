@@ -0,0 +1,159 @@
+package catalog
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestMerge(t *testing.T) {
+	byVersion := map[string]APICatalog{
+		"9.10": {
+			"/storage/volumes": {
+				Summary: "old summary", Introduced: "9.6",
+				Fields:  map[string]FieldInfo{"name": {Desc: "old name"}, "legacy": {Desc: "removed in 9.14"}},
+				Filters: map[string]FilterInfo{"name": {}},
+			},
+			"/storage/disks-old": {Introduced: "9.6"},
+		},
+		"9.14": {
+			"/storage/volumes": {
+				Summary: "new summary", Introduced: "9.6",
+				Fields:  map[string]FieldInfo{"name": {Desc: "name"}, "anti_ransomware": {Since: "9.12", Desc: "arw"}},
+				Filters: map[string]FilterInfo{"name": {}, "anti_ransomware.state": {Since: "9.12"}},
+			},
+		},
+		"9.16": {
+			"/storage/volumes": {
+				Summary: "newest summary", Introduced: "9.6",
+				Fields:  map[string]FieldInfo{"name": {Desc: "name"}, "anti_ransomware": {Since: "9.12", Desc: "arw"}, "tiering": {Desc: "tiering"}},
+				Filters: map[string]FilterInfo{"name": {}, "anti_ransomware.state": {Since: "9.12"}},
+			},
+			"/storage/pools": {Introduced: "9.11"},
+		},
+	}
+
+	cat, versions := Merge(byVersion)
+	if want := []string{"9.10", "9.14", "9.16"}; !reflect.DeepEqual(versions, want) {
+		t.Fatalf("versions = %v, want %v", versions, want)
+	}
+
+	want := APICatalog{
+		"/storage/volumes": {
+			Summary: "newest summary", Introduced: "9.6",
+			Fields: map[string]FieldInfo{
+				"name":            {Desc: "name"},
+				"legacy":          {Until: "9.14", Desc: "removed in 9.14"},
+				"anti_ransomware": {Since: "9.14", Desc: "arw"},
+				"tiering":         {Since: "9.16", Desc: "tiering"},
+			},
+			Filters: map[string]FilterInfo{"name": {}, "anti_ransomware.state": {Since: "9.14"}},
+		},
+		"/storage/disks-old": {Introduced: "9.6", Until: "9.14"},
+		"/storage/pools":     {Introduced: "9.16"},
+	}
+	if !reflect.DeepEqual(cat, want) {
+		t.Fatalf("Merge() =\n%+v\nwant\n%+v", cat, want)
+	}
+}
+
+// TestMergeEndpointWithGap covers an endpoint missing from a version in the
+// middle: a field dropped before the gap ends at the version after its last
+// appearance, not at the next version that has the endpoint.
+func TestMergeEndpointWithGap(t *testing.T) {
+	byVersion := map[string]APICatalog{
+		"9.10": {"/cluster/quirks": {Introduced: "9.8", Fields: map[string]FieldInfo{"name": {}, "dropped": {}}}},
+		"9.12": {"/storage/volumes": {}},
+		"9.14": {"/cluster/quirks": {Introduced: "9.8", Fields: map[string]FieldInfo{"name": {}, "added": {}}}},
+	}
+
+	cat, _ := Merge(byVersion)
+	want := map[string]FieldInfo{
+		"name":    {},
+		"dropped": {Until: "9.12"},
+		"added":   {Since: "9.14"},
+	}
+	if got := cat["/cluster/quirks"].Fields; !reflect.DeepEqual(got, want) {
+		t.Fatalf("fields = %+v, want %+v", got, want)
+	}
+}
+
+func TestFilterByVersion(t *testing.T) {
+	ep := APIEndpoint{
+		Introduced: "9.8",
+		Fields:     map[string]FieldInfo{"name": {}, "new": {Since: "9.12"}, "old": {Until: "9.12"}},
+		Filters:    map[string]FilterInfo{"name": {}, "new": {Since: "9.12"}, "old": {Since: "9.8", Until: "9.12"}},
+	}
+	tests := []struct {
+		version   string
+		available bool
+		want      []string
+	}{
+		{version: "9.7", available: false, want: []string{"name", "old"}},
+		{version: "9.11", available: true, want: []string{"name", "old"}},
+		{version: "9.12", available: true, want: []string{"name", "new"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			if got := ep.AvailableIn(tt.version); got != tt.available {
+				t.Errorf("AvailableIn(%s) = %v, want %v", tt.version, got, tt.available)
+			}
+			got := ep.FilterByVersion(tt.version)
+			for _, name := range tt.want {
+				if _, ok := got.Fields[name]; !ok {
+					t.Errorf("expected field %s in ONTAP %s", name, tt.version)
+				}
+			}
+			if len(got.Fields) != len(tt.want) {
+				t.Errorf("fields = %v, want %v", got.Fields, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilter(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern string
+		tag     string
+		want    []string
+		wantErr string
+	}{
+		{name: "all", want: []string{"/network/ip/interfaces", "/protocols/nfs/export-policies", "/snapmirror/relationships", "/storage/volumes", "/storage/volumes/{volume.uuid}/snapshots"}},
+		{name: "regex on path", pattern: "^/storage/.*s$", want: []string{"/storage/volumes", "/storage/volumes/{volume.uuid}/snapshots"}},
+		{name: "case-insensitive summary", pattern: "IP INTERFACES", want: []string{"/network/ip/interfaces"}},
+		{name: "placeholders are literal", pattern: "{volume.uuid}", want: []string{"/storage/volumes/{volume.uuid}/snapshots"}},
+		{name: "tag", tag: "storage", want: []string{"/storage/volumes", "/storage/volumes/{volume.uuid}/snapshots"}},
+		{name: "regex and tag", pattern: "snapshots", tag: "Storage", want: []string{"/storage/volumes/{volume.uuid}/snapshots"}},
+		{name: "regex on tag", pattern: "^nas$", want: []string{"/protocols/nfs/export-policies"}},
+		{name: "invalid", pattern: "(snap", wantErr: "invalid pattern"},
+		{name: "too long", pattern: strings.Repeat("a", 300), wantErr: "longer than"},
+		{name: "too complex", pattern: "(volume){1000}", wantErr: "too complex"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var re *regexp.Regexp
+			if tt.pattern != "" {
+				var err error
+				re, err = CompilePattern(tt.pattern)
+				if tt.wantErr != "" {
+					if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+						t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+					}
+					return
+				}
+				if err != nil {
+					t.Fatalf("CompilePattern: %v", err)
+				}
+			}
+			var got []string
+			for _, r := range searchCatalog.Filter(re, tt.tag) {
+				got = append(got, r.Path)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("Filter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
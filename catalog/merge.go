@@ -0,0 +1,113 @@
+package catalog
+
+import (
+	"maps"
+	"slices"
+)
+
+// ranged is a FilterInfo or FieldInfo, whose version range Merge sets.
+type ranged[T any] interface {
+	since() string
+	withRange(since, until string) T
+}
+
+func (f FilterInfo) since() string { return f.Since }
+
+func (f FilterInfo) withRange(since, until string) FilterInfo {
+	f.Since, f.Until = since, until
+	return f
+}
+
+func (f FieldInfo) since() string { return f.Since }
+
+func (f FieldInfo) withRange(since, until string) FieldInfo {
+	f.Since, f.Until = since, until
+	return f
+}
+
+// Merge combines the catalogs generated from the swaggers of several ONTAP
+// versions into one, and returns it with the versions sorted oldest first.
+// Endpoints, fields and filters keep the descriptions of the newest version
+// that has them. Those missing from the oldest version get a Since of the
+// first version that has them, and those missing from the newest version an
+// Until of the first version after the last that has them.
+func Merge(byVersion map[string]APICatalog) (APICatalog, []string) {
+	versions := slices.SortedFunc(maps.Keys(byVersion), compareVersions)
+
+	paths := make(map[string]bool)
+	for _, cat := range byVersion {
+		for path := range cat {
+			paths[path] = true
+		}
+	}
+
+	merged := make(APICatalog, len(paths))
+	for path := range paths {
+		var (
+			present []string
+			fields  []map[string]FieldInfo
+			filters []map[string]FilterInfo
+			ep      APIEndpoint
+		)
+		for _, v := range versions {
+			// A version without the endpoint adds nil maps, so fields and
+			// filters stay aligned with versions.
+			e, ok := byVersion[v][path]
+			fields = append(fields, e.Fields)
+			filters = append(filters, e.Filters)
+			if !ok {
+				continue
+			}
+			present = append(present, v)
+			ep = e
+		}
+
+		first, last := present[0], present[len(present)-1]
+		ep.Introduced, ep.Until = versionRange(versions, ep.Introduced, first, last)
+		ep.Fields = mergeItems(versions, fields)
+		ep.Filters = mergeItems(versions, filters)
+		merged[path] = ep
+	}
+	return merged, versions
+}
+
+// mergeItems merges the fields or filters of an endpoint across versions.
+// items[i] holds those of versions[i], and is nil when that version does not
+// have the endpoint.
+func mergeItems[T ranged[T]](versions []string, items []map[string]T) map[string]T {
+	var out map[string]T
+	for i, m := range items {
+		for name := range m {
+			if _, done := out[name]; done {
+				continue
+			}
+			last := i
+			for j := i + 1; j < len(items); j++ {
+				if _, ok := items[j][name]; ok {
+					last = j
+				}
+			}
+			item := items[last][name]
+			since, until := versionRange(versions, item.since(), versions[i], versions[last])
+			if out == nil {
+				out = make(map[string]T)
+			}
+			out[name] = item.withRange(since, until)
+		}
+	}
+	return out
+}
+
+// versionRange returns the since and until of something first seen in the
+// swagger of version first and last seen in that of version last. since is
+// the version the swagger reports, unless an older swagger lacks it.
+func versionRange(versions []string, since, first, last string) (string, string) {
+	if first != versions[0] && (since == "" || compareVersions(first, since) > 0) {
+		since = first
+	}
+	var until string
+	if i := slices.Index(versions, last); i < len(versions)-1 {
+		until = versions[i+1]
+	}
+	return since, until
+}
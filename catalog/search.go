@@ -0,0 +1,315 @@
+package catalog
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Weights of the places a query term can match. A match in the last segment
+// of a path, e.g. "volumes" in /storage/volumes, ranks above one in a parent
+// segment, so collections come before their sub-resources.
+const (
+	weightLastSegment = 12
+	weightPathSegment = 10
+	weightTag         = 8
+	weightSummary     = 4
+	weightName        = 3
+	weightDesc        = 1
+)
+
+// Prefix and typo matches, e.g. "snap" for "snapshots" or "volme" for
+// "volume", score this share of an exact match.
+const (
+	prefixFactor = 0.8
+	fuzzyFactor  = 0.6
+)
+
+// synonyms are groups of terms ONTAP users treat as the same thing. A term
+// may have several words, which must all match.
+var synonyms = [][]string{
+	{"lif", "interface", "network interface"},
+	{"snap", "snapshot"},
+	{"export", "export policy", "nfs policy"},
+	{"svm", "vserver"},
+	{"aggr", "aggregate"},
+	{"vol", "volume"},
+	{"cifs", "smb"},
+	{"igroup", "initiator group"},
+	{"mirror", "snapmirror"},
+	{"peer", "peering"},
+}
+
+// concept is one word or phrase of a search query and the terms that match it.
+type concept struct {
+	query string
+	terms [][]string
+}
+
+// Search ranks the endpoints matching query, best first, and returns at most
+// limit of them, or all when limit is 0. The query is split into words; each
+// word, or synonym phrase such as "nfs policy", matches path segments, tags,
+// the summary, and field and filter names and descriptions, by exact word,
+// prefix, a one-letter typo or a synonym. Endpoints matching more of the query
+// rank higher, and every result says why it matched.
+func (c APICatalog) Search(query string, limit int) []SearchResult {
+	concepts := parseQuery(query)
+	if len(concepts) == 0 {
+		return nil
+	}
+
+	var results []SearchResult
+	for path, ep := range c {
+		doc := newSearchDoc(path, ep)
+		var (
+			score   float64
+			matched int
+			reasons []string
+		)
+		for _, con := range concepts {
+			s, reason := doc.match(con)
+			if s == 0 {
+				continue
+			}
+			score += s
+			matched++
+			reasons = append(reasons, reason)
+		}
+		if matched == 0 {
+			continue
+		}
+		// Endpoints matching only part of the query rank below those matching all of it.
+		score *= float64(matched) / float64(len(concepts))
+		results = append(results, SearchResult{Path: path, Endpoint: ep, Score: score, Reasons: reasons})
+	}
+
+	slices.SortFunc(results, func(a, b SearchResult) int {
+		return cmp.Or(
+			cmp.Compare(b.Score, a.Score),
+			cmp.Compare(len(a.Path), len(b.Path)),
+			strings.Compare(a.Path, b.Path),
+		)
+	})
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+	return results
+}
+
+// parseQuery splits query into concepts. Words that form a synonym phrase,
+// e.g. "nfs policy", are one concept.
+func parseQuery(query string) []concept {
+	words := tokenize(query)
+	var concepts []concept
+	for i := 0; i < len(words); {
+		con, n := phraseAt(words[i:])
+		concepts = append(concepts, con)
+		i += n
+	}
+	return concepts
+}
+
+// phraseAt returns the concept starting at words[0] and how many words it
+// takes. The longest synonym wins.
+func phraseAt(words []string) (concept, int) {
+	var (
+		best  []string
+		group []string
+	)
+	for _, g := range synonyms {
+		for _, term := range g {
+			t := strings.Fields(term)
+			if len(t) > len(best) && len(t) <= len(words) && slices.Equal(t, words[:len(t)]) {
+				best, group = t, g
+			}
+		}
+	}
+	if best == nil {
+		return concept{query: words[0], terms: [][]string{{words[0]}}}, 1
+	}
+	con := concept{query: strings.Join(best, " ")}
+	for _, term := range group {
+		con.terms = append(con.terms, strings.Fields(term))
+	}
+	return con, len(best)
+}
+
+// searchField is a piece of an endpoint a query can match.
+type searchField struct {
+	label  string
+	weight float64
+	words  []string
+}
+
+type searchDoc struct {
+	fields []searchField
+}
+
+func newSearchDoc(path string, ep APIEndpoint) searchDoc {
+	var doc searchDoc
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	last := len(segments) - 1
+	for last > 0 && strings.HasPrefix(segments[last], "{") {
+		last--
+	}
+	for i, seg := range segments {
+		if strings.HasPrefix(seg, "{") {
+			continue
+		}
+		weight := float64(weightPathSegment)
+		if i == last {
+			weight = weightLastSegment
+		}
+		doc.add("path segment "+seg, weight, seg)
+	}
+	for _, tag := range ep.Tags {
+		doc.add("tag "+tag, weightTag, tag)
+	}
+	doc.add("summary", weightSummary, ep.Summary)
+	for _, name := range sortedKeys(ep.Fields) {
+		doc.add("field "+name, weightName, name)
+	}
+	for _, name := range sortedKeys(ep.Filters) {
+		doc.add("filter "+name, weightName, name)
+	}
+	for _, name := range sortedKeys(ep.Fields) {
+		doc.add("description of field "+name, weightDesc, ep.Fields[name].Desc)
+	}
+	for _, name := range sortedKeys(ep.Filters) {
+		doc.add("description of filter "+name, weightDesc, ep.Filters[name].Desc)
+	}
+	return doc
+}
+
+func (d *searchDoc) add(label string, weight float64, text string) {
+	if words := tokenize(text); len(words) > 0 {
+		d.fields = append(d.fields, searchField{label: label, weight: weight, words: words})
+	}
+}
+
+// match returns the best score of con in d and why it matched.
+func (d searchDoc) match(con concept) (float64, string) {
+	var (
+		best   float64
+		reason string
+	)
+	for _, term := range con.terms {
+		for _, f := range d.fields {
+			s, how := f.match(term)
+			alt := strings.Join(term, " ")
+			// Synonyms match whole words only, not by prefix or typo. The
+			// query term itself still matches by prefix, so "snap" finds
+			// snapmirror, ranked below snapshots.
+			if s == 0 || (alt != con.query && s < 1) {
+				continue
+			}
+			score := f.weight * s
+			if alt != con.query {
+				how = "synonym " + strconv.Quote(alt)
+			}
+			if score > best {
+				best = score
+				reason = fmt.Sprintf("%q matched %s (%s)", con.query, f.label, how)
+			}
+		}
+	}
+	return best, reason
+}
+
+// match returns how well the words of term, all of which must match, match
+// f, and how.
+func (f searchField) match(term []string) (float64, string) {
+	factor := 1.0
+	how := "exact"
+	for _, want := range term {
+		best := 0.0
+		for _, w := range f.words {
+			best = max(best, wordMatch(want, w))
+			if best == 1 {
+				break
+			}
+		}
+		if best == 0 {
+			return 0, ""
+		}
+		if best < factor {
+			factor = best
+			how = "prefix"
+			if best == fuzzyFactor {
+				how = "typo"
+			}
+		}
+	}
+	return factor, how
+}
+
+// wordMatch scores the query word want against the word w: 1 when equal or
+// equal but for a plural, prefixFactor when one starts with the other, and
+// fuzzyFactor when they differ by one letter.
+func wordMatch(want, w string) float64 {
+	switch {
+	case want == w || singular(want) == singular(w):
+		return 1
+	case len(want) >= 3 && strings.HasPrefix(w, want), len(w) >= 3 && strings.HasPrefix(want, w):
+		return prefixFactor
+	case len(want) >= 5 && editDistanceOne(want, w):
+		return fuzzyFactor
+	}
+	return 0
+}
+
+// singular strips the plural ending of an English word, e.g. "policies" to
+// "policy" and "volumes" to "volume".
+func singular(w string) string {
+	switch {
+	case strings.HasSuffix(w, "ies") && len(w) > 4:
+		return strings.TrimSuffix(w, "ies") + "y"
+	case strings.HasSuffix(w, "sses"), strings.HasSuffix(w, "shes"), strings.HasSuffix(w, "ches"), strings.HasSuffix(w, "xes"):
+		return strings.TrimSuffix(w, "es")
+	case strings.HasSuffix(w, "s") && !strings.HasSuffix(w, "ss") && len(w) > 3:
+		return strings.TrimSuffix(w, "s")
+	}
+	return w
+}
+
+// editDistanceOne reports whether a and b differ by one inserted, removed,
+// replaced or swapped letter.
+func editDistanceOne(a, b string) bool {
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	if len(b)-len(a) > 1 {
+		return false
+	}
+	i := 0
+	for i < len(a) && a[i] == b[i] {
+		i++
+	}
+	if len(a) == len(b) {
+		if i+1 < len(a) && a[i] == b[i+1] && a[i+1] == b[i] && a[i+2:] == b[i+2:] {
+			return true
+		}
+		return i < len(a) && a[i+1:] == b[i+1:]
+	}
+	return a[i:] == b[i+1:]
+}
+
+// tokenize returns the lower-case words of s, split at anything that is not a
+// letter or digit, so "export-policies" and "svm.name" are two words each.
+func tokenize(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
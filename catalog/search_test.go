@@ -0,0 +1,97 @@
+package catalog
+
+import (
+	"strings"
+	"testing"
+)
+
+var searchCatalog = APICatalog{
+	"/network/ip/interfaces": {
+		Summary: "Retrieves the details of all IP interfaces", Tags: []string{"Networking"},
+		Fields: map[string]FieldInfo{"ip": {Desc: "IP information"}},
+	},
+	"/storage/volumes": {
+		Summary: "Retrieves volumes", Tags: []string{"Storage"},
+		Fields: map[string]FieldInfo{"snapshot_policy": {Desc: "The snapshot policy"}},
+	},
+	"/storage/volumes/{volume.uuid}/snapshots": {
+		Summary: "Retrieves a collection of volume snapshots", Tags: []string{"Storage"},
+		Fields: map[string]FieldInfo{"restore_size": {Desc: "Size to restore the snapshot"}},
+	},
+	"/snapmirror/relationships": {
+		Summary: "Retrieves information for SnapMirror relationships", Tags: []string{"SnapMirror"},
+	},
+	"/protocols/nfs/export-policies": {
+		Summary: "Retrieves export policies", Tags: []string{"NAS"},
+		Filters: map[string]FilterInfo{"rules.clients.match": {Desc: "Filter by client"}},
+	},
+}
+
+func TestSearch(t *testing.T) {
+	tests := []struct {
+		query      string
+		limit      int
+		want       []string
+		wantReason string
+	}{
+		{query: "lif", want: []string{"/network/ip/interfaces"}, wantReason: `"lif" matched path segment interfaces (synonym "interface")`},
+		{query: "snapshot restore", want: []string{"/storage/volumes/{volume.uuid}/snapshots", "/storage/volumes"}, wantReason: `"restore" matched field restore_size (exact)`},
+		{query: "snap", want: []string{"/storage/volumes/{volume.uuid}/snapshots", "/snapmirror/relationships", "/storage/volumes"}, wantReason: `"snap" matched path segment snapshots (synonym "snapshot")`},
+		{query: "nfs policy", want: []string{"/protocols/nfs/export-policies"}, wantReason: `"nfs policy" matched path segment export-policies (synonym`},
+		{query: "volume", limit: 1, want: []string{"/storage/volumes"}},
+		{query: "snapmirorr", want: []string{"/snapmirror/relationships"}, wantReason: "(typo)"},
+		{query: "  ", want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			results := searchCatalog.Search(tt.query, tt.limit)
+			var got []string
+			for _, r := range results {
+				got = append(got, r.Path)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Fatalf("Search(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+			if tt.wantReason != "" && !strings.Contains(strings.Join(results[0].Reasons, "; "), tt.wantReason) {
+				t.Fatalf("expected reasons %v to contain %q", results[0].Reasons, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestSearchSnapMatchesSnapmirrorByPrefix(t *testing.T) {
+	reasons := make(map[string]string)
+	for _, r := range searchCatalog.Search("snap", 0) {
+		reasons[r.Path] = strings.Join(r.Reasons, "; ")
+	}
+	want := map[string]string{
+		"/storage/volumes/{volume.uuid}/snapshots": `"snap" matched path segment snapshots (synonym "snapshot")`,
+		"/snapmirror/relationships":                `"snap" matched path segment snapmirror (prefix)`,
+		"/storage/volumes":                         `"snap" matched field snapshot_policy (synonym "snapshot")`,
+	}
+	for path, reason := range want {
+		if reasons[path] != reason {
+			t.Errorf("Search(%q) reasons for %s = %q, want %q", "snap", path, reasons[path], reason)
+		}
+	}
+}
+
+func TestEditDistanceOne(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"volume", "volme", true},
+		{"volume", "volumex", true},
+		{"volume", "volune", true},
+		{"volume", "vloume", true},
+		{"volume", "volume", false},
+		{"volume", "vlome", false},
+		{"volume", "volumexy", false},
+	}
+	for _, tt := range tests {
+		if got := editDistanceOne(tt.a, tt.b); got != tt.want {
+			t.Errorf("editDistanceOne(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
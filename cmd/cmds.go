@@ -1,15 +1,20 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"github.com/alecthomas/kong"
 	"github.com/netapp/ontap-mcp/config"
 	"github.com/netapp/ontap-mcp/server"
+	"github.com/netapp/ontap-mcp/tracing"
 	"github.com/netapp/ontap-mcp/version"
 	"log/slog"
 	"os"
+	"os/signal"
 	"path/filepath"
 	"strings"
+	"syscall"
+	"time"
 )
 
 var logger = setupLogger()
@@ -28,12 +33,19 @@ type CLI struct {
 }
 
 type StartCmd struct {
-	Host           string `default:"localhost" help:"Listening address"`
-	Port           int    `default:"8080" help:"Listening port" env:"ONTAP_MCP_PORT"`
-	InspectTraffic bool   `default:"false" help:"Inspect MCP HTTP traffic"`
-	ReadOnly       bool   `default:"false" help:"Run MCP in read-only mode. This disables all tool calls that modify ONTAP state."`
-	Stateless      bool   `default:"false" help:"Run in stateless mode (no mcp-session-id header validation). Required when deploying behind proxies or gateways that don't preserve session headers, e.g. on-premises data gateways."`
-	JSONResponse   bool   `default:"false" help:"Respond with application/json instead of text/event-stream. Required when deploying behind proxies or gateways that do not relay SSE/chunked responses, e.g. on-premises data gateways."`
+	Transport            string        `enum:"http,stdio" default:"http" env:"ONTAP_MCP_TRANSPORT" help:"MCP transport, one of: ${enum}. Use stdio when the MCP client launches the server as a subprocess, e.g. Claude Desktop or IDE integrations."`
+	Host                 string        `default:"localhost" help:"Listening address"`
+	Port                 int           `default:"8080" help:"Listening port" env:"ONTAP_MCP_PORT"`
+	InspectTraffic       bool          `default:"false" help:"Inspect MCP HTTP traffic"`
+	ReadOnly             bool          `default:"false" help:"Run MCP in read-only mode. This disables all tool calls that modify ONTAP state."`
+	Plan                 bool          `default:"false" env:"ONTAP_MCP_PLAN" help:"Run every mutating tool as a dry run. Tools resolve names and validate inputs, then return the ONTAP REST calls they would make instead of making them."`
+	ConfirmDestructive   string        `enum:"off,deny,allow" default:"off" env:"ONTAP_MCP_CONFIRM_DESTRUCTIVE" help:"Ask the user to confirm delete operations via MCP elicitation, one of: ${enum}. With deny, deletes from clients that do not support elicitation are rejected; with allow, they run and are logged."`
+	LockTimeout          time.Duration `default:"2m" env:"ONTAP_MCP_LOCK_TIMEOUT" help:"How long a tool call waits for other operations on the same cluster, SVM or object to finish before giving up."`
+	ResourcePollInterval time.Duration `default:"30s" env:"ONTAP_MCP_RESOURCE_POLL_INTERVAL" help:"How often resources that clients subscribed to are fetched again to detect changes."`
+	Stateless            bool          `default:"false" help:"Run in stateless mode (no mcp-session-id header validation). Required when deploying behind proxies or gateways that don't preserve session headers, e.g. on-premises data gateways."`
+	JSONResponse         bool          `default:"false" help:"Respond with application/json instead of text/event-stream. Required when deploying behind proxies or gateways that do not relay SSE/chunked responses, e.g. on-premises data gateways."`
+	APICatalog           string        `name:"api-catalog" env:"ONTAP_MCP_API_CATALOG" help:"Path of an API catalog generated by 'generate api-catalog' to use instead of the embedded one. Overrides ApiCatalog in the config."`
+	OtlpEndpoint         string        `name:"otlp-endpoint" env:"OTEL_EXPORTER_OTLP_ENDPOINT" help:"Export OpenTelemetry traces over OTLP/HTTP to this URL, e.g. http://localhost:4318. Tracing is disabled when empty."`
 }
 
 func (a *StartCmd) Run(cli *CLI) error {
@@ -49,21 +61,63 @@ func (a *StartCmd) Run(cli *CLI) error {
 	logger.Debug("tool mode", slog.String("tool_mode", cli.ToolMode))
 
 	opts := server.Options{
-		Host:           cli.Start.Host,
-		Port:           cli.Start.Port,
-		InspectTraffic: cli.Start.InspectTraffic,
-		ReadOnly:       cli.Start.ReadOnly,
-		Stateless:      cli.Start.Stateless,
-		JSONResponse:   cli.Start.JSONResponse,
-		ToolMode:       cli.ToolMode,
+		Host:                 cli.Start.Host,
+		Port:                 cli.Start.Port,
+		InspectTraffic:       cli.Start.InspectTraffic,
+		ReadOnly:             cli.Start.ReadOnly,
+		Plan:                 cli.Start.Plan,
+		ConfirmDestructive:   cli.Start.ConfirmDestructive,
+		LockTimeout:          cli.Start.LockTimeout,
+		ResourcePollInterval: cli.Start.ResourcePollInterval,
+		Stateless:            cli.Start.Stateless,
+		JSONResponse:         cli.Start.JSONResponse,
+		ToolMode:             cli.ToolMode,
+		Transport:            cli.Start.Transport,
+		APICatalog:           cli.Start.APICatalog,
+	}
+
+	if cli.Start.OtlpEndpoint != "" {
+		shutdown, err := tracing.Start(context.Background(), cli.Start.OtlpEndpoint)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := shutdown(ctx); err != nil {
+				logger.Error("failed to flush traces", slog.Any("error", err))
+			}
+		}()
+		logger.Info("exporting traces", slog.String("endpoint", cli.Start.OtlpEndpoint))
 	}
 
 	app, err := server.NewApp(cfg, opts, logger)
 	if err != nil {
 		return err
 	}
-	app.StartServer()
-	return nil
+	go reloadOnSIGHUP(app, cli.ConfigPath)
+
+	// Stop on SIGINT or SIGTERM so the deferred trace flush runs.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	return app.StartServer(ctx)
+}
+
+// reloadOnSIGHUP re-reads the config whenever the process receives SIGHUP so
+// cluster and credential changes take effect without restarting the server.
+func reloadOnSIGHUP(app *server.App, path string) {
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGHUP)
+	for range sigs {
+		cfg, err := config.ReadConfig(path)
+		if err != nil {
+			logger.Error("failed to reload config", slog.String("path", path), slog.Any("error", err))
+			continue
+		}
+		if err := app.ReloadConfig(cfg); err != nil {
+			logger.Error("failed to apply reloaded config", slog.String("path", path), slog.Any("error", err))
+		}
+	}
 }
 
 func Parse() {
@@ -101,8 +155,7 @@ func setupLogger() *slog.Logger {
 		Level:     level,
 		AddSource: true,
 		ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
-			if a.Key == slog.SourceKey {
-				source := a.Value.Any().(*slog.Source)
+			if source, ok := a.Value.Any().(*slog.Source); ok && a.Key == slog.SourceKey {
 				source.File = filepath.Base(source.File)
 			}
 			return a
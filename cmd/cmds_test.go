@@ -0,0 +1,11 @@
+package cmd
+
+import (
+	"log/slog"
+	"testing"
+)
+
+func TestLoggerAcceptsSourceAttribute(t *testing.T) {
+	// An attribute named like the source location must not be mistaken for it.
+	setupLogger().Info("loaded", slog.String("source", "embedded"))
+}
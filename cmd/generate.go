@@ -1,8 +1,12 @@
 package cmd
 
 import (
+	"cmp"
 	"context"
+	"errors"
 	"fmt"
+	"os"
+	"regexp"
 	"sort"
 	"strings"
 
@@ -13,37 +17,91 @@ import (
 )
 
 type GenerateCmd struct {
-	APICatalog APICatalogCmd `cmd:"api-catalog" help:"Generate API catalog JSON by downloading swagger from an ONTAP cluster"`
+	APICatalog APICatalogCmd `cmd:"api-catalog" help:"Generate API catalog JSON by downloading swagger from ONTAP clusters"`
 }
 
 type APICatalogCmd struct {
-	Poller string `required:"" name:"poller" help:"Poller name from config to download swagger from (e.g. dc1)"`
-	Output string `name:"output" default:"conf/ontap_api_catalog.json" help:"Destination path for the generated catalog JSON"`
+	Pollers []string `name:"poller" help:"Poller name from config to download swagger from (e.g. dc1). Repeat, or separate with commas, to merge the swaggers of clusters running different ONTAP versions"`
+	Swagger []string `name:"swagger" help:"Swagger YAML file of an ONTAP version, as VERSION=PATH (e.g. 9.10=swagger-9.10.yaml). Repeatable, and merged with the swaggers of --poller"`
+	Output  string   `name:"output" default:"conf/ontap_api_catalog.json" help:"Destination path for the generated catalog JSON"`
 }
 
 func (c *APICatalogCmd) Run(cli *CLI) error {
-	cfg, err := config.ReadConfig(cli.ConfigPath)
-	if err != nil {
-		return fmt.Errorf("read config %s: %w", cli.ConfigPath, err)
+	if len(c.Pollers) == 0 && len(c.Swagger) == 0 {
+		return errors.New("at least one --poller or --swagger is required")
 	}
-	poller, ok := cfg.Pollers[c.Poller]
-	if !ok {
-		return fmt.Errorf("poller %q not found in %s", c.Poller, cli.ConfigPath)
+	byVersion := make(map[string]catalog.APICatalog)
+	add := func(ontapVersion string, data []byte, source string) error {
+		if _, ok := byVersion[ontapVersion]; ok {
+			return fmt.Errorf("%s: a swagger of ONTAP %s was already given", source, ontapVersion)
+		}
+		cat, err := buildAPICatalog(data, ontapVersion)
+		if err != nil {
+			return fmt.Errorf("%s: %w", source, err)
+		}
+		byVersion[ontapVersion] = cat
+		return nil
+	}
+
+	if len(c.Pollers) > 0 {
+		cfg, err := config.ReadConfig(cli.ConfigPath)
+		if err != nil {
+			return fmt.Errorf("read config %s: %w", cli.ConfigPath, err)
+		}
+		for _, name := range c.Pollers {
+			poller, ok := cfg.Pollers[name]
+			if !ok {
+				return fmt.Errorf("poller %q not found in %s", name, cli.ConfigPath)
+			}
+			ontapVersion, data, err := downloadSwagger(poller)
+			if err != nil {
+				return err
+			}
+			if err := add(ontapVersion, data, poller.Addr); err != nil {
+				return err
+			}
+		}
+	}
+	for _, s := range c.Swagger {
+		ontapVersion, path, ok := strings.Cut(s, "=")
+		if !ok || ontapVersion == "" || path == "" {
+			return fmt.Errorf("invalid --swagger %q: use VERSION=PATH, e.g. 9.10=swagger-9.10.yaml", s)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("read swagger: %w", err)
+		}
+		fmt.Printf("Read swagger from %s (ONTAP %s, %d bytes)\n", path, ontapVersion, len(data))
+		if err := add(ontapVersion, data, path); err != nil {
+			return err
+		}
 	}
+
+	cat, versions := catalog.Merge(byVersion)
+	if err := catalog.Save(cat, versions, c.Output); err != nil {
+		return fmt.Errorf("save catalog: %w", err)
+	}
+	fmt.Printf("Generated catalog: %d endpoints from ONTAP %s written to %s\n",
+		len(cat), strings.Join(versions, ", "), c.Output)
+	return nil
+}
+
+// downloadSwagger returns the ONTAP version and swagger of poller's cluster.
+func downloadSwagger(poller *config.Poller) (string, []byte, error) {
 	client := rest.New(poller)
 
 	remote, err := client.GetClusterInfo(context.Background())
 	if err != nil {
-		return fmt.Errorf("get cluster info from %s: %w", poller.Addr, err)
+		return "", nil, fmt.Errorf("get cluster info from %s: %w", poller.Addr, err)
 	}
 	ontapVersion := fmt.Sprintf("%d.%d", remote.Version.Generation, remote.Version.Major)
 
 	data, err := client.FetchSwagger()
 	if err != nil {
-		return fmt.Errorf("download swagger from %s: %w", poller.Addr, err)
+		return "", nil, fmt.Errorf("download swagger from %s: %w", poller.Addr, err)
 	}
 	fmt.Printf("Downloaded swagger from %s (ONTAP %s, %d bytes)\n", poller.Addr, ontapVersion, len(data))
-	return generateAPICatalog(data, ontapVersion, c.Output)
+	return ontapVersion, data, nil
 }
 
 type swaggerDoc struct {
@@ -60,6 +118,9 @@ type swaggerParameter struct {
 	In          string            `yaml:"in"`
 	Description string            `yaml:"description"`
 	Type        string            `yaml:"type"`
+	Format      string            `yaml:"format"`
+	Enum        []any             `yaml:"enum"`
+	Items       *swaggerProp      `yaml:"items"`
 	Introduced  string            `yaml:"x-ntap-introduced"`
 	Visibility  swaggerVisibility `yaml:"x-ntap-visibility"`
 }
@@ -87,15 +148,21 @@ type swaggerSchema struct {
 }
 
 type swaggerDef struct {
+	Type       string                 `yaml:"type"`
 	Properties map[string]swaggerProp `yaml:"properties"`
 }
 
 type swaggerProp struct {
-	Description string            `yaml:"description"`
-	Ref         string            `yaml:"$ref"`
-	Items       *swaggerProp      `yaml:"items"`
-	Introduced  string            `yaml:"x-ntap-introduced"`
-	Visibility  swaggerVisibility `yaml:"x-ntap-visibility"`
+	Description string                 `yaml:"description"`
+	Ref         string                 `yaml:"$ref"`
+	Type        string                 `yaml:"type"`
+	Format      string                 `yaml:"format"`
+	Enum        []any                  `yaml:"enum"`
+	ReadOnly    bool                   `yaml:"readOnly"`
+	Items       *swaggerProp           `yaml:"items"`
+	Properties  map[string]swaggerProp `yaml:"properties"`
+	Introduced  string                 `yaml:"x-ntap-introduced"`
+	Visibility  swaggerVisibility      `yaml:"x-ntap-visibility"`
 }
 
 // skipParams are query params present on every collection GET.
@@ -182,35 +249,31 @@ func isGenericFilterDesc(desc string) bool {
 	return !strings.Contains(d[len("filter by "):], " ")
 }
 
-func extractFieldDescs(op *swaggerOperation, defs map[string]swaggerDef, apiVersion string) map[string]catalog.FieldInfo {
+// recordDef returns the definition of the records a collection GET returns.
+func recordDef(op *swaggerOperation, defs map[string]swaggerDef) (swaggerDef, bool) {
 	respSchema, ok := op.Responses["200"]
 	if !ok {
-		return nil
+		return swaggerDef{}, false
 	}
 	respRef := respSchema.Schema.Ref
 	if respRef == "" {
-		return nil
+		return swaggerDef{}, false
 	}
-	respDefName := strings.TrimPrefix(respRef, "#/definitions/")
-	respDef, ok := defs[respDefName]
+	respDef, ok := defs[strings.TrimPrefix(respRef, "#/definitions/")]
 	if !ok {
-		return nil
+		return swaggerDef{}, false
 	}
 	recordsProp, ok := respDef.Properties["records"]
-	if !ok || recordsProp.Items == nil {
-		return nil
-	}
-	itemRef := recordsProp.Items.Ref
-	if itemRef == "" {
-		return nil
-	}
-	itemDefName := strings.TrimPrefix(itemRef, "#/definitions/")
-	itemDef, ok := defs[itemDefName]
-	if !ok {
-		return nil
+	if !ok || recordsProp.Items == nil || recordsProp.Items.Ref == "" {
+		return swaggerDef{}, false
 	}
-	result := make(map[string]catalog.FieldInfo, len(itemDef.Properties))
-	for name, prop := range itemDef.Properties {
+	itemDef, ok := defs[strings.TrimPrefix(recordsProp.Items.Ref, "#/definitions/")]
+	return itemDef, ok
+}
+
+func extractFieldDescs(props map[string]swaggerProp, types map[string]fieldType, apiVersion string) map[string]catalog.FieldInfo {
+	result := make(map[string]catalog.FieldInfo, len(props))
+	for name, prop := range props {
 		if strings.HasPrefix(name, "_") {
 			continue // skip _links, _tags etc.
 		}
@@ -221,7 +284,16 @@ func extractFieldDescs(op *swaggerOperation, defs map[string]swaggerDef, apiVers
 		if desc == "" {
 			continue
 		}
-		fi := catalog.FieldInfo{Desc: stripHTML(desc)}
+		t := types[name]
+		fi := catalog.FieldInfo{
+			Type:     t.Type,
+			Items:    t.Items,
+			Format:   t.Format,
+			Unit:     t.Unit,
+			Enum:     t.Enum,
+			ReadOnly: t.ReadOnly,
+			Desc:     stripHTML(desc),
+		}
 		if v := prop.Introduced; v != "" && v != "DO_NOT_DISPLAY" && v != apiVersion {
 			fi.Since = v
 		}
@@ -233,6 +305,113 @@ func extractFieldDescs(op *swaggerOperation, defs map[string]swaggerDef, apiVers
 	return result
 }
 
+// fieldType is the type information of a record field.
+type fieldType struct {
+	Type     string
+	Items    string
+	Format   string
+	Unit     string
+	Enum     []string
+	ReadOnly bool
+}
+
+// maxFieldDepth bounds how deep fieldTypes follows nested objects, which also
+// stops definitions that refer to themselves.
+const maxFieldDepth = 6
+
+// fieldTypes returns the type of every field of props, nested ones by their
+// dot-notation name, e.g. "space.size". Fields of array elements are named
+// like ONTAP filters them, e.g. "aggregates.name".
+func fieldTypes(props map[string]swaggerProp, defs map[string]swaggerDef) map[string]fieldType {
+	types := make(map[string]fieldType)
+	var walk func(props map[string]swaggerProp, prefix string, depth int)
+	walk = func(props map[string]swaggerProp, prefix string, depth int) {
+		for name, prop := range props {
+			if strings.HasPrefix(name, "_") || prop.Visibility.Type == "private" {
+				continue
+			}
+			full := prefix + name
+			t, nested := propType(prop, defs)
+			types[full] = t
+			if depth < maxFieldDepth && len(nested) > 0 {
+				walk(nested, full+".", depth+1)
+			}
+		}
+	}
+	walk(props, "", 0)
+	return types
+}
+
+// propType returns the type of prop and, for an object or an array of objects,
+// the properties of the object.
+func propType(prop swaggerProp, defs map[string]swaggerDef) (fieldType, map[string]swaggerProp) {
+	props := prop.Properties
+	t := fieldType{
+		Type:     prop.Type,
+		Format:   typeFormat(prop.Format),
+		Unit:     unitOf(prop.Description),
+		Enum:     enumValues(prop.Enum),
+		ReadOnly: prop.ReadOnly,
+	}
+	if prop.Ref != "" {
+		def := defs[strings.TrimPrefix(prop.Ref, "#/definitions/")]
+		props = def.Properties
+		t.Type = cmp.Or(def.Type, "object")
+	}
+	if prop.Type == "array" && prop.Items != nil {
+		items, nested := propType(*prop.Items, defs)
+		t.Items = items.Type
+		t.Enum = items.Enum
+		t.Unit = cmp.Or(t.Unit, items.Unit)
+		props = nested
+	}
+	if t.Type == "" && len(props) > 0 {
+		t.Type = "object"
+	}
+	return t, props
+}
+
+// typeFormat drops the formats that only give the size of a number.
+func typeFormat(format string) string {
+	switch format {
+	case "int32", "int64", "float", "double":
+		return ""
+	}
+	return format
+}
+
+func enumValues(values []any) []string {
+	if len(values) == 0 {
+		return nil
+	}
+	out := make([]string, 0, len(values))
+	for _, v := range values {
+		out = append(out, fmt.Sprint(v))
+	}
+	return out
+}
+
+// unitPattern finds the unit ONTAP descriptions give for numbers, e.g. "The
+// size of the volume, in bytes".
+var unitPattern = regexp.MustCompile(`(?i)\b(?:in|of) (bytes|kilobytes|megabytes|seconds|milliseconds|microseconds|nanoseconds|minutes|hours|days|percentage|percent|KB/s|MB/s|KB|MB|GB|IOPS)\b`)
+
+func unitOf(desc string) string {
+	m := unitPattern.FindStringSubmatch(desc)
+	if m == nil {
+		return ""
+	}
+	unit := strings.ToLower(m[1])
+	switch unit {
+	case "percentage":
+		return "percent"
+	case "kb", "mb", "gb", "kb/s", "mb/s":
+		return strings.ToUpper(unit[:2]) + unit[2:]
+	case "iops":
+		return "IOPS"
+	}
+	return unit
+}
+
 func extractSummary(description string) string {
 	lines := strings.SplitN(strings.TrimSpace(description), "\n", 2)
 	s := strings.TrimSpace(lines[0])
@@ -243,10 +422,39 @@ func extractSummary(description string) string {
 	return s
 }
 
-func generateAPICatalog(data []byte, ontapVersion string, outputPath string) error {
+// filterInfo returns the type of the query parameter p, filled in from the
+// type of the record field it filters, t, where the parameter leaves it out.
+func filterInfo(p swaggerParameter, t fieldType) catalog.FilterInfo {
+	fi := catalog.FilterInfo{
+		Type:   cmp.Or(p.Type, t.Type),
+		Format: cmp.Or(typeFormat(p.Format), t.Format),
+		Unit:   cmp.Or(unitOf(p.Description), t.Unit),
+		Enum:   enumValues(p.Enum),
+	}
+	if fi.Type == "array" {
+		fi.Items = t.Items
+		if p.Items != nil {
+			fi.Items = cmp.Or(p.Items.Type, fi.Items)
+		}
+	}
+	if fi.Enum == nil && p.Items != nil {
+		fi.Enum = enumValues(p.Items.Enum)
+	}
+	if fi.Enum == nil {
+		fi.Enum = t.Enum
+	}
+	// Most filters are strings, so string is left out to keep the catalog small.
+	if fi.Type == "string" {
+		fi.Type = ""
+	}
+	return fi
+}
+
+// buildAPICatalog returns the catalog of the swagger of one ONTAP version.
+func buildAPICatalog(data []byte, ontapVersion string) (catalog.APICatalog, error) {
 	var doc swaggerDoc
 	if err := yaml.Unmarshal(data, &doc); err != nil {
-		return fmt.Errorf("parse swagger YAML: %w", err)
+		return nil, fmt.Errorf("parse swagger YAML: %w", err)
 	}
 
 	cat := make(catalog.APICatalog, 256)
@@ -286,6 +494,8 @@ func generateAPICatalog(data []byte, ontapVersion string, outputPath string) err
 		summary := extractSummary(stripHTML(op.Description))
 
 		apiVersion := op.Introduced
+		record, _ := recordDef(op, doc.Definitions)
+		types := fieldTypes(record.Properties, doc.Definitions)
 
 		// Collect path parameters (e.g. {uuid}, {name}) for endpoints that have them.
 		pathParams := make(map[string]catalog.PathParamInfo)
@@ -305,11 +515,7 @@ func generateAPICatalog(data []byte, ontapVersion string, outputPath string) err
 				if p.Visibility.Type == "private" {
 					continue
 				}
-				t := p.Type
-				if t == "string" {
-					t = ""
-				}
-				fi := catalog.FilterInfo{Type: t}
+				fi := filterInfo(p, types[p.Name])
 				if v := p.Introduced; v != "" && v != "DO_NOT_DISPLAY" && v != apiVersion {
 					fi.Since = v
 				}
@@ -324,7 +530,7 @@ func generateAPICatalog(data []byte, ontapVersion string, outputPath string) err
 			Summary:    summary,
 			Tags:       op.Tags,
 			Introduced: apiVersion,
-			Fields:     extractFieldDescs(op, doc.Definitions, apiVersion),
+			Fields:     extractFieldDescs(record.Properties, types, apiVersion),
 		}
 		if len(pathParams) > 0 {
 			ep.PathParams = pathParams
@@ -336,11 +542,6 @@ func generateAPICatalog(data []byte, ontapVersion string, outputPath string) err
 		cat[path] = ep
 	}
 
-	if err := catalog.Save(cat, ontapVersion, outputPath); err != nil {
-		return fmt.Errorf("save catalog: %w", err)
-	}
-
-	fmt.Printf("Generated catalog: %d endpoints written to %s (%d paths skipped)\n",
-		len(cat), outputPath, skipped)
-	return nil
+	fmt.Printf("ONTAP %s: %d endpoints (%d paths skipped)\n", ontapVersion, len(cat), skipped)
+	return cat, nil
 }
@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/netapp/ontap-mcp/catalog"
+)
+
+const testSwagger = `
+paths:
+  /storage/volumes:
+    get:
+      description: Retrieves volumes.
+      tags: [volume]
+      x-ntap-introduced: "9.6"
+      parameters:
+        - {name: fields, in: query, type: array}
+        - {name: state, in: query, type: string, description: Filter by state}
+        - {name: space.size, in: query, type: integer, format: int64, description: Filter by space.size}
+        - {name: aggregates.name, in: query, type: string}
+        - {name: create_time, in: query, type: string, format: date-time}
+      responses:
+        "200":
+          schema: {$ref: "#/definitions/volume_response"}
+definitions:
+  volume_response:
+    properties:
+      records:
+        type: array
+        items: {$ref: "#/definitions/volume"}
+  volume:
+    properties:
+      _links: {$ref: "#/definitions/self_link"}
+      uuid: {type: string, format: uuid, readOnly: true, description: Unique identifier}
+      state:
+        type: string
+        description: Volume state.
+        enum: [online, offline, error]
+      space:
+        type: object
+        description: Space information.
+        properties:
+          size: {type: integer, format: int64, description: "Total provisioned size, in bytes."}
+      aggregates:
+        type: array
+        description: Aggregates hosting the volume.
+        items: {$ref: "#/definitions/aggregate_ref"}
+  aggregate_ref:
+    properties:
+      name: {type: string, description: Aggregate name}
+`
+
+func TestBuildAPICatalogTypes(t *testing.T) {
+	cat, err := buildAPICatalog([]byte(testSwagger), "9.16")
+	if err != nil {
+		t.Fatalf("buildAPICatalog: %v", err)
+	}
+	ep := cat["/storage/volumes"]
+
+	wantFields := map[string]catalog.FieldInfo{
+		"uuid":       {Type: "string", Format: "uuid", ReadOnly: true, Desc: "Unique identifier"},
+		"state":      {Type: "string", Enum: []string{"online", "offline", "error"}, Desc: "Volume state."},
+		"space":      {Type: "object", Desc: "Space information."},
+		"aggregates": {Type: "array", Items: "object", Desc: "Aggregates hosting the volume."},
+	}
+	if !reflect.DeepEqual(ep.Fields, wantFields) {
+		t.Errorf("fields =\n%+v\nwant\n%+v", ep.Fields, wantFields)
+	}
+
+	wantFilters := map[string]catalog.FilterInfo{
+		"state":           {Enum: []string{"online", "offline", "error"}},
+		"space.size":      {Type: "integer", Unit: "bytes"},
+		"aggregates.name": {},
+		"create_time":     {Format: "date-time"},
+	}
+	if !reflect.DeepEqual(ep.Filters, wantFilters) {
+		t.Errorf("filters =\n%+v\nwant\n%+v", ep.Filters, wantFilters)
+	}
+}
+
+func TestUnitOf(t *testing.T) {
+	tests := map[string]string{
+		"Total provisioned size, in bytes.":            "bytes",
+		"Latency in microseconds.":                     "microseconds",
+		"Used space as a percentage of the total size": "",
+		"Throughput in MB/s":                           "MB/s",
+		"The name of the volume":                       "",
+		"Percentage in percent":                        "percent",
+	}
+	for desc, want := range tests {
+		if got := unitOf(desc); got != want {
+			t.Errorf("unitOf(%q) = %q, want %q", desc, got, want)
+		}
+	}
+}
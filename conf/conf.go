@@ -0,0 +1,11 @@
+// Package conf embeds the configuration files shipped with ONTAP MCP.
+package conf
+
+import _ "embed"
+
+// APICatalog is the ONTAP REST API catalog generated by
+// `ontap-mcp generate api-catalog`. It is used unless another catalog is
+// configured.
+//
+//go:embed ontap_api_catalog.json
+var APICatalog []byte
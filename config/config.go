@@ -3,6 +3,8 @@ package config
 import (
 	"fmt"
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/goccy/go-yaml"
 	"github.com/goccy/go-yaml/ast"
@@ -13,6 +15,22 @@ const (
 	AppName = "ontap-mcp"
 )
 
+const (
+	// DefaultClientTimeout bounds a single ONTAP REST request when client_timeout is not set.
+	DefaultClientTimeout = 2 * time.Minute
+	// DefaultJobTimeout bounds how long an async ONTAP job is waited on when job_timeout is not set.
+	DefaultJobTimeout = 3 * time.Minute
+	// DefaultRetryAttempts is how often an idempotent ONTAP request is tried
+	// when retry.max_attempts is not set.
+	DefaultRetryAttempts = 3
+	// DefaultRetryInitialBackoff is the wait before the first retry when
+	// retry.initial_backoff is not set. Each further retry waits twice as long.
+	DefaultRetryInitialBackoff = 500 * time.Millisecond
+	// DefaultRetryMaxBackoff caps the wait between retries when
+	// retry.max_backoff is not set.
+	DefaultRetryMaxBackoff = 10 * time.Second
+)
+
 func ReadConfig(path string) (*ONTAP, error) {
 	contents, err := os.ReadFile(path)
 	if err != nil {
@@ -40,6 +58,15 @@ func ReadConfig(path string) (*ONTAP, error) {
 		if err := poller.applyDefaults(cfg.Defaults); err != nil {
 			return nil, fmt.Errorf("error applying defaults to poller %q: %w", name, err)
 		}
+		if _, err := poller.ClientTimeoutDuration(); err != nil {
+			return nil, fmt.Errorf("poller %q: %w", name, err)
+		}
+		if _, err := poller.JobTimeoutDuration(); err != nil {
+			return nil, fmt.Errorf("poller %q: %w", name, err)
+		}
+		if _, err := poller.RetryPolicy(); err != nil {
+			return nil, fmt.Errorf("poller %q: %w", name, err)
+		}
 	}
 
 	return &cfg, nil
@@ -68,12 +95,82 @@ func (p *Poller) InsecureTLS() bool {
 	return p.UseInsecureTLS != nil && *p.UseInsecureTLS
 }
 
+// ClientTimeoutDuration returns the effective client_timeout, the maximum time
+// a single ONTAP REST request may take.
+func (p *Poller) ClientTimeoutDuration() (time.Duration, error) {
+	return parseTimeout("client_timeout", p.ClientTimeout, DefaultClientTimeout)
+}
+
+// JobTimeoutDuration returns the effective job_timeout, the maximum time to
+// wait for an async ONTAP job such as a volume move or SVM delete to finish.
+func (p *Poller) JobTimeoutDuration() (time.Duration, error) {
+	return parseTimeout("job_timeout", p.JobTimeout, DefaultJobTimeout)
+}
+
+// RetryPolicy is the effective retry section: how idempotent ONTAP requests
+// are retried after a transient failure.
+type RetryPolicy struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	ErrorCodes     []string
+}
+
+// RetryPolicy returns the effective retry settings. A max_attempts of 1
+// disables retries.
+func (p *Poller) RetryPolicy() (RetryPolicy, error) {
+	policy := RetryPolicy{
+		MaxAttempts: DefaultRetryAttempts,
+		ErrorCodes:  p.Retry.ErrorCodes,
+	}
+	if p.Retry.MaxAttempts < 0 {
+		return policy, fmt.Errorf("invalid retry.max_attempts %d: must be at least 1", p.Retry.MaxAttempts)
+	}
+	if p.Retry.MaxAttempts > 0 {
+		policy.MaxAttempts = p.Retry.MaxAttempts
+	}
+	var err error
+	if policy.InitialBackoff, err = parseTimeout("retry.initial_backoff", p.Retry.InitialBackoff, DefaultRetryInitialBackoff); err != nil {
+		return policy, err
+	}
+	if policy.MaxBackoff, err = parseTimeout("retry.max_backoff", p.Retry.MaxBackoff, DefaultRetryMaxBackoff); err != nil {
+		return policy, err
+	}
+	if policy.MaxBackoff < policy.InitialBackoff {
+		return policy, fmt.Errorf("invalid retry.max_backoff %s: must not be less than retry.initial_backoff %s", policy.MaxBackoff, policy.InitialBackoff)
+	}
+	return policy, nil
+}
+
+// parseTimeout accepts a Go duration such as "90s" or, like Harvest, a plain
+// number of seconds. An empty value returns def.
+func parseTimeout(name, value string, def time.Duration) (time.Duration, error) {
+	if value == "" {
+		return def, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		secs, convErr := strconv.Atoi(value)
+		if convErr != nil {
+			return 0, fmt.Errorf("invalid %s %q: must be a duration like 90s or a number of seconds", name, value)
+		}
+		d = time.Duration(secs) * time.Second
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("invalid %s %q: must be greater than zero", name, value)
+	}
+	return d, nil
+}
+
 type ONTAP struct {
 	Pollers        map[string]*Poller `yaml:"Pollers,omitempty"`
 	Defaults       *Poller            `yaml:"Defaults,omitempty"`
 	McpAuth        *OAuth             `yaml:"McpAuth,omitempty"`
 	TLS            *TLS               `yaml:"Tls,omitempty"`
-	PollersOrdered []string           `yaml:"-"` // poller names in same order as yaml config
+	Audit          *Audit             `yaml:"Audit,omitempty"`
+	Prompts        *Prompts           `yaml:"Prompts,omitempty"`
+	APICatalog     string             `yaml:"ApiCatalog,omitempty"` // path of an API catalog to use instead of the embedded one
+	PollersOrdered []string           `yaml:"-"`                    // poller names in same order as yaml config
 }
 
 type OAuth struct {
@@ -81,6 +178,17 @@ type OAuth struct {
 	Audience string      `yaml:"audience"`
 	Alg      StringSlice `yaml:"alg,omitempty"`
 	Scope    string      `yaml:"scope,omitempty"`
+	Roles    []Role      `yaml:"roles,omitempty"`
+}
+
+// Role grants callers whose token carries the claim value access to tools of
+// the listed kinds (read, create, update, delete) on the listed clusters. An
+// empty cluster list, or "*", allows every cluster.
+type Role struct {
+	Claim    string      `yaml:"claim"`
+	Value    string      `yaml:"value"`
+	Allow    StringSlice `yaml:"allow"`
+	Clusters StringSlice `yaml:"clusters,omitempty"`
 }
 
 // StringSlice accepts either a single YAML scalar (alg: RS256) or a YAML
@@ -97,7 +205,7 @@ func (s *StringSlice) UnmarshalYAML(b []byte) error {
 	}
 	var single string
 	if err := yaml.Unmarshal(b, &single); err != nil {
-		return fmt.Errorf("alg must be a string or a list of strings: %w", err)
+		return fmt.Errorf("value must be a string or a list of strings: %w", err)
 	}
 	*s = StringSlice{single}
 	return nil
@@ -125,6 +233,30 @@ type TLS struct {
 	KeyFile string `yaml:"key_file,omitempty"`
 }
 
+// Audit configures where a JSON line is written for every tool call. Both
+// outputs may be enabled at once.
+type Audit struct {
+	// File is the path of the audit log. Entries are appended; rotate it with
+	// an external tool such as logrotate using copytruncate.
+	File   string       `yaml:"file,omitempty"`
+	Syslog *AuditSyslog `yaml:"syslog,omitempty"`
+}
+
+// Prompts adds operator-defined MCP prompts to the built-in runbooks.
+type Prompts struct {
+	// Dir is a directory of YAML prompt templates, one prompt per .yaml or
+	// .yml file. Path may be absolute or relative to the working directory.
+	Dir string `yaml:"dir,omitempty"`
+}
+
+// AuditSyslog sends audit entries to syslog. Leave Network and Address empty
+// to use the local syslog daemon.
+type AuditSyslog struct {
+	Network string `yaml:"network,omitempty"` // udp, tcp or unix
+	Address string `yaml:"address,omitempty"` // e.g. syslog.example.com:514
+	Tag     string `yaml:"tag,omitempty"`     // defaults to ontap-mcp
+}
+
 type Poller struct {
 	Addr string `yaml:"addr,omitempty"`
 
@@ -136,8 +268,10 @@ type Poller struct {
 	CredentialsScript CredentialsScript `yaml:"credentials_script,omitempty"`
 	Datacenter        string            `yaml:"datacenter,omitempty"`
 	IsDisabled        bool              `yaml:"disabled,omitempty"`
+	JobTimeout        string            `yaml:"job_timeout,omitempty"`
 	Password          string            `yaml:"password,omitempty"`
 	Recorder          Recorder          `yaml:"recorder,omitempty"`
+	Retry             Retry             `yaml:"retry,omitempty"`
 	SslCert           string            `yaml:"ssl_cert,omitempty"`
 	SslKey            string            `yaml:"ssl_key,omitempty"`
 	UseInsecureTLS    *bool             `yaml:"use_insecure_tls,omitempty"`
@@ -156,6 +290,13 @@ type CertificateScript struct {
 	Timeout string `yaml:"timeout,omitempty"`
 }
 
+type Retry struct {
+	MaxAttempts    int      `yaml:"max_attempts,omitempty"`
+	InitialBackoff string   `yaml:"initial_backoff,omitempty"`
+	MaxBackoff     string   `yaml:"max_backoff,omitempty"`
+	ErrorCodes     []string `yaml:"error_codes,omitempty"` // ONTAP error codes that are always retried
+}
+
 type Recorder struct {
 	Path     string `yaml:"path,omitempty"`
 	Mode     string `yaml:"mode,omitempty"`      // record or replay
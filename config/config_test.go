@@ -3,7 +3,9 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/netapp/ontap-mcp/assert"
 )
@@ -245,3 +247,147 @@ McpAuth:
 	assert.NotNil(t, cfg.McpAuth)
 	assert.Equal(t, len(cfg.McpAuth.Alg), 0)
 }
+
+func TestTimeoutDurations(t *testing.T) {
+	tests := []struct {
+		name       string
+		value      string
+		want       time.Duration
+		wantErr    bool
+		useDefault bool
+	}{
+		{name: "unset uses default", value: "", useDefault: true},
+		{name: "go duration", value: "90s", want: 90 * time.Second},
+		{name: "plain seconds", value: "45", want: 45 * time.Second},
+		{name: "minutes", value: "30m", want: 30 * time.Minute},
+		{name: "garbage", value: "soon", wantErr: true},
+		{name: "zero", value: "0", wantErr: true},
+		{name: "negative", value: "-5s", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &Poller{ClientTimeout: tt.value, JobTimeout: tt.value}
+
+			clientTimeout, err := p.ClientTimeoutDuration()
+			assert.Equal(t, err != nil, tt.wantErr)
+			jobTimeout, err := p.JobTimeoutDuration()
+			assert.Equal(t, err != nil, tt.wantErr)
+
+			if tt.wantErr {
+				return
+			}
+			if tt.useDefault {
+				assert.Equal(t, clientTimeout, DefaultClientTimeout)
+				assert.Equal(t, jobTimeout, DefaultJobTimeout)
+				return
+			}
+			assert.Equal(t, clientTimeout, tt.want)
+			assert.Equal(t, jobTimeout, tt.want)
+		})
+	}
+}
+
+func TestReadConfig_RejectsInvalidJobTimeout(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ontap.yaml")
+	contents := `
+Defaults:
+  job_timeout: 20m
+Pollers:
+  good:
+    addr: 10.0.0.1
+  bad:
+    addr: 10.0.0.2
+    job_timeout: forever
+`
+	assert.Nil(t, os.WriteFile(path, []byte(contents), 0600))
+
+	_, err := ReadConfig(path)
+	assert.NotNil(t, err)
+	assert.True(t, strings.Contains(err.Error(), "job_timeout"))
+}
+
+func TestReadConfig_JobTimeoutInheritsDefault(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ontap.yaml")
+	contents := `
+Defaults:
+  job_timeout: 20m
+Pollers:
+  dc1:
+    addr: 10.0.0.1
+  dc2:
+    addr: 10.0.0.2
+    job_timeout: 1h
+`
+	assert.Nil(t, os.WriteFile(path, []byte(contents), 0600))
+
+	cfg, err := ReadConfig(path)
+	assert.Nil(t, err)
+
+	d, err := cfg.Pollers["dc1"].JobTimeoutDuration()
+	assert.Nil(t, err)
+	assert.Equal(t, d, 20*time.Minute)
+
+	d, err = cfg.Pollers["dc2"].JobTimeoutDuration()
+	assert.Nil(t, err)
+	assert.Equal(t, d, time.Hour)
+}
+
+func TestRetryPolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		retry   Retry
+		want    RetryPolicy
+		wantErr bool
+	}{
+		{
+			name: "unset uses defaults",
+			want: RetryPolicy{MaxAttempts: DefaultRetryAttempts, InitialBackoff: DefaultRetryInitialBackoff, MaxBackoff: DefaultRetryMaxBackoff},
+		},
+		{
+			name:  "configured",
+			retry: Retry{MaxAttempts: 5, InitialBackoff: "1s", MaxBackoff: "30"},
+			want:  RetryPolicy{MaxAttempts: 5, InitialBackoff: time.Second, MaxBackoff: 30 * time.Second},
+		},
+		{name: "negative attempts", retry: Retry{MaxAttempts: -1}, wantErr: true},
+		{name: "invalid backoff", retry: Retry{InitialBackoff: "soon"}, wantErr: true},
+		{name: "max below initial", retry: Retry{InitialBackoff: "20s", MaxBackoff: "10s"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &Poller{Retry: tt.retry}
+			got, err := p.RetryPolicy()
+			assert.Equal(t, err != nil, tt.wantErr)
+			if tt.wantErr {
+				return
+			}
+			assert.Equal(t, got.MaxAttempts, tt.want.MaxAttempts)
+			assert.Equal(t, got.InitialBackoff, tt.want.InitialBackoff)
+			assert.Equal(t, got.MaxBackoff, tt.want.MaxBackoff)
+		})
+	}
+}
+
+func TestReadConfig_RetryInheritsDefault(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ontap.yaml")
+	contents := `
+Defaults:
+  retry:
+    max_attempts: 5
+    error_codes: ["2"]
+Pollers:
+  dc1:
+    addr: 10.0.0.1
+    retry:
+      max_backoff: 1m
+`
+	assert.Nil(t, os.WriteFile(path, []byte(contents), 0600))
+
+	cfg, err := ReadConfig(path)
+	assert.Nil(t, err)
+
+	policy, err := cfg.Pollers["dc1"].RetryPolicy()
+	assert.Nil(t, err)
+	assert.Equal(t, policy.MaxAttempts, 5)
+	assert.Equal(t, policy.MaxBackoff, time.Minute)
+	assert.Equal(t, len(policy.ErrorCodes), 1)
+}
@@ -15,6 +15,7 @@ Use the swagger-catalog tools + ontap_get for any listing or read request:
   search_ontap_endpoints → find paths by keyword (e.g. "snapshot", "lun", "nfs")
   describe_ontap_endpoint→ get filterable fields for one path
   ontap_get              → execute the GET and return raw JSON results
+  ontap_get_multi        → run the same GET on several clusters, a datacenter or all clusters at once
 
 ### ONTAP REST query syntax (for ontap_get filters)
 - Exact match:     "svm.name": "vs1"
@@ -26,7 +27,10 @@ Use the swagger-catalog tools + ontap_get for any listing or read request:
 - Comma list for fields param: "name,svm.name,space.size,state"
 
 ## Write operations
-Create/update/delete operations remain as dedicated typed tools
+Create/update/delete operations remain as dedicated typed tools.
+By default they wait for any ONTAP job they start to finish. For long operations, such as
+deleting an SVM or initializing SnapMirror, pass async: true to get the job UUID back at once,
+then follow it with get_ontap_job or stop it with cancel_ontap_job.
 `
 
 const ListClusters = `List all ONTAP clusters registered in the server configuration.
@@ -168,14 +172,18 @@ const UpdateSnapMirrorTransfer = `Trigger a SnapMirror transfer initialize or up
 const BreakSnapMirror = `Break a SnapMirror relationship on a cluster by cluster name. Sets the relationship state to broken_off, making the destination volume read-write. Identifies the relationship by destination SVM and volume names.`
 const ResyncSnapMirror = `Resync a SnapMirror relationship on a cluster by cluster name. Re-establishes replication by setting the state back to snapmirrored. Identifies the relationship by destination SVM and volume names.`
 
-const ListOntapEndpoints = `List ONTAP REST collection endpoints in the catalog.
+const ListOntapEndpoints = `List ONTAP REST collection endpoints in the catalog, sorted by path, 50 at a time.
 The catalog contains all endpoints — can be large. Prefer search_ontap_endpoints for targeted discovery.
-Use the optional 'match' parameter to filter by substring or regex pattern (e.g. "snapshot", "lun", ".*nfs.*export.*").
-Omit 'match' only when you want a full overview of all available endpoints.`
+Use the optional 'match' parameter to filter by a case-insensitive regular expression on path, summary and tags (e.g. "snapshot", "lun", "nfs.*export"),
+and 'tag' to keep one tag (e.g. "storage"). Set compact to get only paths. Use offset and limit to page through the results.
+Omit 'match' and 'tag' only when you want a full overview of all available endpoints.`
 
-const SearchOntapEndpoints = `Search the catalog by keyword across endpoint paths, summaries, and tags (e.g. "snapshot", "lun", "nfs").`
+const SearchOntapEndpoints = `Search the catalog for endpoints, best match first. Matches each word of the query against endpoint paths, tags, summaries, and field and filter names and descriptions, allowing prefixes, small typos and synonyms (lif/interface, snap/snapshot, export/nfs policy, svm/vserver).
+Each result says why it matched. Use several words to narrow the search (e.g. "snapshot restore", "lif", "lun map"), and limit to return more or fewer than 10 endpoints.`
 
 const DescribeOntapEndpoint = `Get filterable query params for an endpoint. Call before ontap_get to learn valid filter names and which sub-objects need explicit fields (e.g. "space.*", "efficiency.*").
+Fields and filters are annotated with their type when it is not a string, e.g. (integer,in bytes), (array of object), (date-time) or (read-only), and list their allowed values when they are an enum.
+ontap_get rejects filter values that do not match these types or values.
 Pass cluster_name to automatically filter out fields and filters not available in that cluster's ONTAP version.`
 
 const CreateSVM = `Create an SVM on a cluster by cluster name.`
@@ -202,10 +210,98 @@ Parameters:
 - filters:     key-value object using ONTAP query syntax (see system instructions for syntax reference)
 - path_params: values for {param} placeholders in templated paths, e.g. {"volume.uuid":"abc-123"}
 - max_records: cap result count; omit to return all
+- select:      comma-separated fields to keep in each returned record
+- sort_by:     comma-separated fields, each optionally followed by asc or desc, e.g. "space.used desc"
+- group_by:    comma-separated fields to group by; each group reports its count
+- aggregate:   sum, avg, min or max of numeric fields, e.g. "sum(space.used),max(space.used)"
+- top_n:       keep the first N records after sort_by; with group_by, the first N of each group
+select, sort_by, group_by, aggregate and top_n are applied to the fetched records before they are returned, so use them
+to keep large results small. Do not combine top_n with max_records: max_records limits what ONTAP returns before sorting.
 
 Example — collection:
 {"cluster_name":"dc1","path":"/storage/volumes","fields":"name,uuid,svm.name,state","filters":{"svm.name":"vs1"}}
 
 Example — snapshots for a volume (2 calls):
 Call 1: {"cluster_name":"dc1","path":"/storage/volumes","fields":"uuid","filters":{"name":"vol1","svm.name":"vs1"}}
-Call 2: {"cluster_name":"dc1","path":"/storage/volumes/{volume.uuid}/snapshots","path_params":{"volume.uuid":"<uuid-from-call-1>"},"fields":"name,create_time,comment"}`
+Call 2: {"cluster_name":"dc1","path":"/storage/volumes/{volume.uuid}/snapshots","path_params":{"volume.uuid":"<uuid-from-call-1>"},"fields":"name,create_time,comment"}
+
+Example — top 10 volumes by used space per aggregate:
+{"cluster_name":"dc1","path":"/storage/volumes","fields":"name,svm.name,aggregates.name,space.used","group_by":"aggregates.name","sort_by":"space.used desc","top_n":10,"aggregate":"sum(space.used)"}`
+
+const OntapGetMulti = `Execute the same read-only GET against several ONTAP clusters at once, e.g. to find volumes over 90% full everywhere.
+Select clusters with "clusters" (a list of names, or ["all"]) or with "datacenter". Takes the same path, fields, filters and path_params as ontap_get.
+Records from every cluster are merged and each is tagged with cluster_name. Clusters that fail are listed under errors and do not fail the call.
+Follow the ontap_get rules: always set fields and narrow with filters.
+Example: {"clusters":["all"],"path":"/storage/volumes","fields":"name,svm.name,space.percent_used","filters":{"space.percent_used":">90"}}`
+
+const GetOntapJob = `Get the state, progress message and result of an ONTAP job on a cluster by cluster name and job UUID.
+Use this to follow a change made by a mutating tool called with async: true.`
+const ListOntapJobs = `List ONTAP jobs on a cluster by cluster name, most recently started first. Optionally filter by state.`
+const CancelOntapJob = `Cancel a running ONTAP job on a cluster by cluster name and job UUID. Not every job can be cancelled; ONTAP reports an error for those that cannot.`
+
+// Runbook prompts. Each is a text/template rendered with the prompt arguments,
+// keyed by argument name, e.g. {{.cluster_name}}. Missing optional arguments
+// render as empty strings.
+
+const PromptProvisionNFSShare = `Provision an NFS share for volume {{.volume_name}} on SVM {{.svm_name}} of cluster {{.cluster_name}}{{if .size}}, size {{.size}}{{end}}{{if .clients}}, exported to {{.clients}}{{end}}.
+
+Follow these steps in order and stop to ask me if any check fails:
+1. Call list_registered_clusters and confirm {{.cluster_name}} is registered.
+2. Use ontap_get on /svm/svms with filter name={{.svm_name}} and fields=state,nfs.enabled to check the SVM is running.
+   If NFS is not enabled, call create_nfs_service for the SVM with v3_enabled and v41_enabled set to true.
+3. Use ontap_get on /storage/volumes with filters svm.name={{.svm_name}} and name={{.volume_name}} to make sure the volume does not exist yet.
+4. Pick an export policy name such as {{.volume_name}}_export. Call create_nfs_export_policies for it, then
+   create_nfs_export_policies_rules with client_match {{if .clients}}{{.clients}}{{else}}set to the clients I name (ask me){{end}}, ro_rule and rw_rule sys.
+5. Call create_volume with svm_name {{.svm_name}}, volume_name {{.volume_name}}, size {{if .size}}{{.size}}{{else}}I confirm (ask me){{end}},
+   nas.path /{{.volume_name}} and nas.export_policy.name set to the policy from step 4. Let ONTAP pick the aggregate unless I name one.
+6. Use ontap_get on /storage/volumes with the same filters and fields=state,nas.path,nas.export_policy.name to verify the volume is online.
+   Use ontap_get on /network/ip/interfaces with filter svm.name={{.svm_name}} and fields=ip.address,services to find a data LIF that serves NFS.
+7. Reply with the mount command, e.g. mount -t nfs <lif address>:/{{.volume_name}} /mnt/{{.volume_name}}.`
+
+const PromptMapLUNToESXiHost = `Map LUN {{.lun_name}} on SVM {{.svm_name}} of cluster {{.cluster_name}} to a new ESXi host with initiator {{.initiator_name}}{{if .igroup_name}}, using igroup {{.igroup_name}}{{end}}.
+
+Follow these steps in order and stop to ask me if any check fails:
+1. Call list_registered_clusters and confirm {{.cluster_name}} is registered.
+2. Use ontap_get on /storage/luns with filters svm.name={{.svm_name}} and name={{.lun_name}} and fields=status.state,os_type,space.size,lun_maps
+   to check the LUN exists, is online and has os_type vmware.
+3. An initiator that starts with iqn. is iSCSI, otherwise it is an FC WWPN. Check the matching protocol service is enabled with ontap_get on
+   /protocols/san/iscsi/services or /protocols/san/fcp/services filtered by svm.name={{.svm_name}}.
+4. Use ontap_get on /protocols/san/igroups with filter svm.name={{.svm_name}} and fields=name,os_type,protocol,initiators.name.
+   If an igroup already contains {{.initiator_name}}, use it and skip to step 6.
+5. {{if .igroup_name}}If igroup {{.igroup_name}} exists, call add_igroup_initiator to add {{.initiator_name}} to it. Otherwise call create_igroup named {{.igroup_name}}
+   with os_type vmware, then add_igroup_initiator.{{else}}Ask me whether to add the host to an existing ESXi cluster igroup or to create a new one.
+   Create a new igroup with create_igroup and os_type vmware, then call add_igroup_initiator for {{.initiator_name}}.{{end}}
+6. Call create_lun_map to map {{.lun_name}} to the igroup, unless step 2 shows it is already mapped to it.
+7. Use ontap_get on /protocols/san/lun-maps with filter lun.name={{.lun_name}} and fields=igroup.name,logical_unit_number to report the LUN ID,
+   and remind me to rescan the storage adapters on the ESXi host.`
+
+const PromptSetupSnapMirrorDR = `Set up SnapMirror disaster recovery for volume {{.volume_name}} on SVM {{.svm_name}} of cluster {{.cluster_name}}{{if .destination_cluster_name}} to cluster {{.destination_cluster_name}}{{end}}{{if .destination_svm_name}}, SVM {{.destination_svm_name}}{{end}}.
+
+Follow these steps in order and stop to ask me if any check fails:
+1. Call list_registered_clusters and confirm {{.cluster_name}}{{if .destination_cluster_name}} and {{.destination_cluster_name}} are{{else}} is{{end}} registered.{{if not .destination_cluster_name}}
+   Ask me which registered cluster is the destination.{{end}}
+2. Use ontap_get on /storage/volumes on {{.cluster_name}} with filters svm.name={{.svm_name}} and name={{.volume_name}} and fields=size,type,state
+   to get the source volume size.
+3. On the destination cluster, use ontap_get on /cluster/peers and /svm/peers to check the clusters and SVMs are peered with the snapmirror application.
+   If they are not, stop and tell me; peering needs a passphrase that must be entered on both clusters.
+4. On the destination SVM{{if .destination_svm_name}} {{.destination_svm_name}}{{end}}, call create_volume named {{.volume_name}}_dr with type dp and a size
+   at least as large as the source volume.
+5. On the destination cluster, call create_snapmirror with source.path {{.svm_name}}:{{.volume_name}}, destination.path
+   <destination svm>:{{.volume_name}}_dr and policy_name {{if .policy_name}}{{.policy_name}}{{else}}MirrorAllSnapshots unless I ask for another policy{{end}}.
+6. Call initialize_snapmirror for the destination path with async true, then follow the returned job with get_ontap_job.
+7. Use ontap_get on /snapmirror/relationships with filter destination.path=<destination svm>:{{.volume_name}}_dr and fields=state,healthy,lag_time
+   and report the state. A healthy relationship is snapmirrored.
+8. Suggest a transfer schedule with modify_snapmirror if the relationship has none.`
+
+const PromptRecoverFileFromSnapshot = `Recover {{if .file_path}}the file {{.file_path}}{{else}}a file{{end}} in volume {{.volume_name}} on SVM {{.svm_name}} of cluster {{.cluster_name}} from a snapshot{{if .before}}, taken before {{.before}}{{end}}.
+
+Follow these steps in order and stop to ask me if any check fails:
+1. Call list_registered_clusters and confirm {{.cluster_name}} is registered.
+2. Use ontap_get on /storage/volumes with filters svm.name={{.svm_name}} and name={{.volume_name}} and fields=uuid,nas.path,snapshot_directory_access_enabled.
+3. Use ontap_get on /storage/volumes/{volume.uuid}/snapshots with fields=name,create_time and list the snapshots, newest first.
+   {{if .before}}Suggest the newest snapshot taken before {{.before}}.{{else}}Ask me when the file was last known to be good.{{end}}
+4. Recover the file by copying it from the read-only snapshot directory on a client, e.g.
+   cp <mount point>/.snapshot/<snapshot name>/{{if .file_path}}{{.file_path}}{{else}}<file path>{{end}} <mount point>/{{if .file_path}}{{.file_path}}{{else}}<file path>{{end}}
+   If snapshot_directory_access_enabled is false, tell me it must be enabled for clients to see .snapshot.
+5. Do NOT call restore_snapshot. It reverts the whole volume and discards every change made after the snapshot.
+   Only mention it if I ask to restore the entire volume, and explain that consequence first.`
@@ -0,0 +1,169 @@
+// Package metrics defines the Prometheus metrics ONTAP-MCP exposes on /metrics.
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "ontap_mcp"
+
+// Outcomes of an async ONTAP job, used as the state label of JobWait.
+const (
+	JobSuccess = "success"
+	JobFailure = "failure"
+	JobTimeout = "timeout"
+)
+
+var (
+	// ToolCalls counts MCP tool calls by tool.
+	ToolCalls = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "tool_calls_total",
+		Help:      "Number of MCP tool calls.",
+	}, []string{"tool"})
+
+	// ToolErrors counts MCP tool calls that returned an error, including calls
+	// denied by McpAuth roles or not confirmed by the user.
+	ToolErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "tool_errors_total",
+		Help:      "Number of MCP tool calls that returned an error.",
+	}, []string{"tool"})
+
+	// ToolDuration observes how long MCP tool calls take.
+	ToolDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "tool_duration_seconds",
+		Help:      "Duration of MCP tool calls.",
+		Buckets:   []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 180},
+	}, []string{"tool"})
+
+	// ONTAPRequests counts ONTAP REST requests by cluster, method and HTTP
+	// status code. Requests that fail without a response use code "error".
+	ONTAPRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "ontap_requests_total",
+		Help:      "Number of ONTAP REST requests.",
+	}, []string{"cluster", "method", "code"})
+
+	// ONTAPRequestDuration observes ONTAP REST request latency.
+	ONTAPRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "ontap_request_duration_seconds",
+		Help:      "Duration of ONTAP REST requests.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"cluster", "method"})
+
+	// ONTAPRetries counts ONTAP REST requests that were sent again after a
+	// transient failure.
+	ONTAPRetries = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "ontap_request_retries_total",
+		Help:      "Number of ONTAP REST requests retried after a transient failure.",
+	}, []string{"cluster"})
+
+	// JobWait observes how long tools wait for async ONTAP jobs to finish.
+	JobWait = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "ontap_job_wait_seconds",
+		Help:      "Time spent waiting for async ONTAP jobs.",
+		Buckets:   []float64{1, 2, 5, 10, 30, 60, 120, 180, 300, 600},
+	}, []string{"cluster", "state"})
+
+	// JWKSRefreshFailures counts failed fetches of the OAuth issuer's JWKS.
+	JWKSRefreshFailures = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "jwks_refresh_failures_total",
+		Help:      "Number of failed JWKS refreshes.",
+	})
+
+	// LockContention counts tool calls that had to queue behind another
+	// operation on the same cluster, SVM or object.
+	LockContention = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "lock_contention_total",
+		Help:      "Number of tool calls that waited for another operation to finish.",
+	}, []string{"cluster"})
+
+	// LockTimeouts counts tool calls rejected because they waited longer than
+	// the lock timeout.
+	LockTimeouts = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "lock_timeouts_total",
+		Help:      "Number of tool calls rejected after waiting too long for another operation to finish.",
+	}, []string{"cluster"})
+)
+
+var registry = prometheus.NewRegistry()
+
+func init() {
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		ToolCalls,
+		ToolErrors,
+		ToolDuration,
+		ONTAPRequests,
+		ONTAPRequestDuration,
+		ONTAPRetries,
+		JobWait,
+		JWKSRefreshFailures,
+		LockContention,
+		LockTimeouts,
+	)
+}
+
+// Handler serves the metrics in the Prometheus text format.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
+
+// ObserveToolCall records a finished tool call.
+func ObserveToolCall(tool string, failed bool, elapsed time.Duration) {
+	ToolCalls.WithLabelValues(tool).Inc()
+	if failed {
+		ToolErrors.WithLabelValues(tool).Inc()
+	}
+	ToolDuration.WithLabelValues(tool).Observe(elapsed.Seconds())
+}
+
+// Transport returns a RoundTripper that records every request sent through
+// next for cluster.
+func Transport(cluster string, next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return roundTripper{cluster: cluster, next: next}
+}
+
+type roundTripper struct {
+	cluster string
+	next    http.RoundTripper
+}
+
+func (rt roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := rt.next.RoundTrip(req)
+	ONTAPRequestDuration.WithLabelValues(rt.cluster, req.Method).Observe(time.Since(start).Seconds())
+
+	code := "error"
+	if err == nil {
+		code = strconv.Itoa(resp.StatusCode)
+	}
+	ONTAPRequests.WithLabelValues(rt.cluster, req.Method, code).Inc()
+	return resp, err
+}
+
+// CloseIdleConnections lets http.Client.CloseIdleConnections reach the
+// wrapped transport.
+func (rt roundTripper) CloseIdleConnections() {
+	if c, ok := rt.next.(interface{ CloseIdleConnections() }); ok {
+		c.CloseIdleConnections()
+	}
+}
@@ -0,0 +1,75 @@
+package metrics
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+type stubTransport struct {
+	status int
+	err    error
+}
+
+func (s stubTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &http.Response{StatusCode: s.status, Body: http.NoBody, Request: req}, nil
+}
+
+func TestTransport(t *testing.T) {
+	tests := []struct {
+		name    string
+		cluster string
+		next    stubTransport
+		code    string
+	}{
+		{name: "ok", cluster: "transport-ok", next: stubTransport{status: http.StatusOK}, code: "200"},
+		{name: "ontap error", cluster: "transport-404", next: stubTransport{status: http.StatusNotFound}, code: "404"},
+		{name: "no response", cluster: "transport-err", next: stubTransport{err: errors.New("connection refused")}, code: "error"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "https://cluster/api/cluster", http.NoBody)
+			resp, _ := Transport(tt.cluster, tt.next).RoundTrip(req)
+			if resp != nil {
+				_ = resp.Body.Close()
+			}
+			if got := testutil.ToFloat64(ONTAPRequests.WithLabelValues(tt.cluster, http.MethodGet, tt.code)); got != 1 {
+				t.Errorf("ontap_requests_total{code=%q} = %v, want 1", tt.code, got)
+			}
+		})
+	}
+}
+
+func TestHandler(t *testing.T) {
+	ObserveToolCall("handler_tool", false, time.Second)
+	ObserveToolCall("handler_tool", true, time.Second)
+
+	if got := testutil.ToFloat64(ToolCalls.WithLabelValues("handler_tool")); got != 2 {
+		t.Errorf("tool_calls_total = %v, want 2", got)
+	}
+	if got := testutil.ToFloat64(ToolErrors.WithLabelValues("handler_tool")); got != 1 {
+		t.Errorf("tool_errors_total = %v, want 1", got)
+	}
+
+	rec := httptest.NewRecorder()
+	Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", http.NoBody))
+	body := rec.Body.String()
+	for _, want := range []string{
+		`ontap_mcp_tool_calls_total{tool="handler_tool"} 2`,
+		`ontap_mcp_tool_duration_seconds_count{tool="handler_tool"} 2`,
+		"ontap_mcp_jwks_refresh_failures_total 0",
+		"go_goroutines",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("metrics output is missing %q", want)
+		}
+	}
+}
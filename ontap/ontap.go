@@ -14,6 +14,7 @@ type OErr struct {
 type ClusterError struct {
 	Err        OErr `json:"error"`
 	StatusCode int
+	RetryAfter time.Duration `json:"-"` // from the Retry-After response header, if any
 }
 
 func (o ClusterError) Error() string {
@@ -26,8 +27,8 @@ type JobResponse struct {
 	State       string    `json:"state"` // enum: queued, running, paused, success, failure
 	Message     string    `json:"message"`
 	Code        int       `json:"code"`
-	StartTime   time.Time `json:"start_time"`
-	EndTime     time.Time `json:"end_time"`
+	StartTime   time.Time `json:"start_time,omitzero"`
+	EndTime     time.Time `json:"end_time,omitzero"`
 	Svm         struct {
 		Name string `json:"name"`
 		UUID string `json:"uuid"`
@@ -18,7 +18,7 @@ func (c *Client) CreateNFSExportPolicy(ctx context.Context, exportPolicy ontap.E
 	)
 	responseHeaders := http.Header{}
 
-	builder := c.baseRequestBuilder(`/api/protocols/nfs/export-policies`, &statusCode, responseHeaders).
+	builder := c.createRequestBuilder(ctx, `/api/protocols/nfs/export-policies`, &statusCode, responseHeaders).
 		BodyJSON(exportPolicy).
 		ToBytesBuffer(&buf)
 
@@ -55,7 +55,7 @@ func (c *Client) CreateNFSExportPolicyRules(ctx context.Context, exportPolicyNam
 		return fmt.Errorf("failed to get detail of export policy %s because it does not exist", exportPolicyName)
 	}
 
-	builder2 := c.baseRequestBuilder(`/api/protocols/nfs/export-policies/`+strconv.Itoa(exportPolicy.Records[0].ID)+`/rules`, &statusCode, responseHeaders).
+	builder2 := c.createRequestBuilder(ctx, `/api/protocols/nfs/export-policies/`+strconv.Itoa(exportPolicy.Records[0].ID)+`/rules`, &statusCode, responseHeaders).
 		BodyJSON(rule).
 		ToBytesBuffer(&buf)
 
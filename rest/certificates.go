@@ -0,0 +1,208 @@
+package rest
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"sync"
+	"time"
+
+	"github.com/netapp/ontap-mcp/config"
+)
+
+const (
+	authStyleBasic       = "basic_auth"
+	authStyleCertificate = "certificate_auth"
+)
+
+// certRefreshMargin is how long before a client certificate expires that it is
+// reloaded from its files or certificate_script.
+const certRefreshMargin = 5 * time.Minute
+
+// usesCertificateAuth reports whether the poller authenticates to ONTAP with a
+// client certificate instead of basic auth or a bearer token.
+func usesCertificateAuth(p *config.Poller) bool {
+	return p.AuthStyle == authStyleCertificate
+}
+
+// newTLSConfig builds the TLS settings for connecting to ONTAP: server
+// verification against the optional ca_cert bundle and, with
+// auth_style: certificate_auth, a client certificate from ssl_cert/ssl_key or
+// certificate_script.
+func newTLSConfig(p *config.Poller) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: p.InsecureTLS(), //nolint:gosec
+		ClientSessionCache: tls.NewLRUClientSessionCache(0),
+	}
+
+	switch p.AuthStyle {
+	case "", authStyleBasic, authStyleCertificate:
+	default:
+		return nil, fmt.Errorf("invalid auth_style %q; supported values: %s, %s", p.AuthStyle, authStyleBasic, authStyleCertificate)
+	}
+
+	if p.CaCertPath != "" {
+		pool, err := loadCAPool(p.CaCertPath)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if usesCertificateAuth(p) {
+		source := &clientCertSource{poller: p}
+		// Load once up front so a bad path or script fails on the first
+		// request with a clear error instead of as a TLS handshake failure.
+		if _, err := source.get(context.Background()); err != nil {
+			return nil, err
+		}
+		tlsConfig.GetClientCertificate = func(info *tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			return source.get(info.Context())
+		}
+	}
+
+	return tlsConfig, nil
+}
+
+func loadCAPool(path string) (*x509.CertPool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ca_cert %s: %w", path, err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("ca_cert %s does not contain any PEM encoded certificates", path)
+	}
+	return pool, nil
+}
+
+// clientCertSource loads the client certificate lazily and caches it until it
+// is close to expiring, so a certificate_script can hand out short-lived
+// certificates. Replacing ssl_cert or ssl_key changes the poller's fingerprint,
+// which replaces the whole client.
+type clientCertSource struct {
+	poller *config.Poller
+	mu     sync.Mutex
+	cert   *tls.Certificate
+}
+
+func (s *clientCertSource) get(ctx context.Context) (*tls.Certificate, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.cert != nil && time.Until(s.cert.Leaf.NotAfter) > certRefreshMargin {
+		return s.cert, nil
+	}
+
+	cert, err := loadClientCertificate(ctx, s.poller)
+	if err != nil {
+		return nil, err
+	}
+	s.cert = cert
+	return cert, nil
+}
+
+// loadClientCertificate returns the poller's client certificate.
+// Priority: certificate_script > ssl_cert/ssl_key
+func loadClientCertificate(ctx context.Context, p *config.Poller) (*tls.Certificate, error) {
+	var (
+		certPEM, keyPEM []byte
+		err             error
+	)
+
+	switch {
+	case p.CertificateScript.Path != "":
+		certPEM, keyPEM, err = executeCertificateScript(ctx, p)
+		if err != nil {
+			return nil, err
+		}
+	case p.SslCert != "" && p.SslKey != "":
+		certPEM, err = os.ReadFile(p.SslCert)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read ssl_cert %s: %w", p.SslCert, err)
+		}
+		keyPEM, err = os.ReadFile(p.SslKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read ssl_key %s: %w", p.SslKey, err)
+		}
+	default:
+		return nil, errors.New("auth_style certificate_auth requires either certificate_script or both ssl_cert and ssl_key")
+	}
+
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("invalid client certificate for poller %s: %w", p.Name, err)
+	}
+	return &cert, nil
+}
+
+// executeCertificateScript runs the certificate_script and splits the PEM
+// blocks it prints on stdout into the certificate chain and the private key.
+func executeCertificateScript(ctx context.Context, poller *config.Poller) ([]byte, []byte, error) {
+	// Parse timeout (default 10s)
+	timeout := 10 * time.Second
+	if poller.CertificateScript.Timeout != "" {
+		var err error
+		timeout, err = time.ParseDuration(poller.CertificateScript.Timeout)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid certificate_script timeout '%s': %w", poller.CertificateScript.Timeout, err)
+		}
+	}
+
+	execCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// Build command arguments: script $addr [$username]
+	args := []string{poller.Addr}
+	if poller.Username != "" {
+		args = append(args, poller.Username)
+	}
+
+	//nolint:gosec // G204: Script path and args are from trusted config file
+	cmd := exec.CommandContext(execCtx, poller.CertificateScript.Path, args...)
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if errors.Is(execCtx.Err(), context.DeadlineExceeded) {
+			return nil, nil, fmt.Errorf("certificate_script timed out after %v", timeout)
+		}
+		return nil, nil, fmt.Errorf("certificate_script execution failed: %w (stderr: %s)", err, stderr.String())
+	}
+
+	return splitPEM(stdout.Bytes())
+}
+
+// splitPEM separates CERTIFICATE blocks from the private key block.
+func splitPEM(data []byte) ([]byte, []byte, error) {
+	var certPEM, keyPEM []byte
+	rest := data
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type == "CERTIFICATE" {
+			certPEM = append(certPEM, pem.EncodeToMemory(block)...)
+		} else if keyPEM == nil {
+			keyPEM = pem.EncodeToMemory(block)
+		}
+	}
+
+	if len(certPEM) == 0 {
+		return nil, nil, errors.New("certificate_script output does not contain a PEM encoded CERTIFICATE")
+	}
+	if len(keyPEM) == 0 {
+		return nil, nil, errors.New("certificate_script output does not contain a PEM encoded private key")
+	}
+	return certPEM, keyPEM, nil
+}
@@ -0,0 +1,289 @@
+package rest
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/netapp/ontap-mcp/config"
+)
+
+type testPKI struct {
+	caPEM      []byte
+	serverCert tls.Certificate
+	clientCert []byte
+	clientKey  []byte
+	caPool     *x509.CertPool
+}
+
+func newTestPKI(t *testing.T) testPKI {
+	t.Helper()
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caTmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTmpl, caTmpl, &caKey.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caCert, err := x509.ParseCertificate(caDER)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	issue := func(serial int64, cn string, usage x509.ExtKeyUsage, ips []net.IP) ([]byte, []byte) {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tmpl := &x509.Certificate{
+			SerialNumber: big.NewInt(serial),
+			Subject:      pkix.Name{CommonName: cn},
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     time.Now().Add(time.Hour),
+			KeyUsage:     x509.KeyUsageDigitalSignature,
+			ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+			IPAddresses:  ips,
+		}
+		der, err := x509.CreateCertificate(rand.Reader, tmpl, caCert, &key.PublicKey, caKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		keyDER, err := x509.MarshalECPrivateKey(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+			pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	}
+
+	serverCertPEM, serverKeyPEM := issue(2, "ontap", x509.ExtKeyUsageServerAuth, []net.IP{net.ParseIP("127.0.0.1")})
+	serverCert, err := tls.X509KeyPair(serverCertPEM, serverKeyPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	clientCert, clientKey := issue(3, "admin", x509.ExtKeyUsageClientAuth, nil)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(caCert)
+
+	return testPKI{
+		caPEM:      pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER}),
+		serverCert: serverCert,
+		clientCert: clientCert,
+		clientKey:  clientKey,
+		caPool:     pool,
+	}
+}
+
+// newMTLSServer starts an ONTAP stand-in that requires a client certificate
+// signed by the test CA and records the Authorization header it receives.
+func newMTLSServer(t *testing.T, pki testPKI, clientAuth tls.ClientAuthType, gotAuth *string) *httptest.Server {
+	t.Helper()
+	ts := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*gotAuth = r.Header.Get("Authorization")
+		_, _ = w.Write([]byte(clusterJSON))
+	}))
+	ts.TLS = &tls.Config{
+		Certificates: []tls.Certificate{pki.serverCert},
+		ClientAuth:   clientAuth,
+		ClientCAs:    pki.caPool,
+		MinVersion:   tls.VersionTLS12,
+	}
+	ts.StartTLS()
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func writeFile(t *testing.T, dir, name string, data []byte, mode os.FileMode) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, data, mode); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestClient_CACertVerifiesServer(t *testing.T) {
+	pki := newTestPKI(t)
+	var gotAuth string
+	ts := newMTLSServer(t, pki, tls.NoClientCert, &gotAuth)
+	dir := t.TempDir()
+
+	poller := &config.Poller{
+		Name:     "c1",
+		Addr:     strings.TrimPrefix(ts.URL, "https://"),
+		Username: "admin",
+		Password: "secret",
+	}
+
+	if _, err := New(poller).GetClusterInfo(t.Context()); err == nil {
+		t.Fatal("expected verification failure without ca_cert")
+	}
+
+	poller.CaCertPath = writeFile(t, dir, "ca.pem", pki.caPEM, 0600)
+	remote, err := New(poller).GetClusterInfo(t.Context())
+	if err != nil {
+		t.Fatalf("GetClusterInfo with ca_cert: %v", err)
+	}
+	if remote.Name != "cluster1" {
+		t.Fatalf("unexpected remote %+v", remote)
+	}
+	if !strings.HasPrefix(gotAuth, "Basic ") {
+		t.Fatalf("expected basic auth header, got %q", gotAuth)
+	}
+}
+
+func TestClient_CertificateAuthFromFiles(t *testing.T) {
+	pki := newTestPKI(t)
+	var gotAuth string
+	ts := newMTLSServer(t, pki, tls.RequireAndVerifyClientCert, &gotAuth)
+	dir := t.TempDir()
+
+	poller := &config.Poller{
+		Name:       "c1",
+		Addr:       strings.TrimPrefix(ts.URL, "https://"),
+		AuthStyle:  "certificate_auth",
+		CaCertPath: writeFile(t, dir, "ca.pem", pki.caPEM, 0600),
+		SslCert:    writeFile(t, dir, "client.pem", pki.clientCert, 0600),
+		SslKey:     writeFile(t, dir, "client.key", pki.clientKey, 0600),
+		Username:   "admin",
+		Password:   "must-not-be-sent",
+	}
+
+	if _, err := New(poller).GetClusterInfo(t.Context()); err != nil {
+		t.Fatalf("GetClusterInfo: %v", err)
+	}
+	if gotAuth != "" {
+		t.Fatalf("expected no Authorization header with certificate_auth, got %q", gotAuth)
+	}
+}
+
+func TestClient_CertificateAuthFromScript(t *testing.T) {
+	if !hasBash(t) {
+		return
+	}
+	pki := newTestPKI(t)
+	var gotAuth string
+	ts := newMTLSServer(t, pki, tls.RequireAndVerifyClientCert, &gotAuth)
+	dir := t.TempDir()
+
+	certPath := writeFile(t, dir, "client.pem", pki.clientCert, 0600)
+	keyPath := writeFile(t, dir, "client.key", pki.clientKey, 0600)
+	//nolint:gosec // G306: Script needs to be executable
+	script := writeFile(t, dir, "cert.sh", []byte("#!/bin/bash\ncat "+certPath+" "+keyPath+"\n"), 0700)
+
+	poller := &config.Poller{
+		Name:              "c1",
+		Addr:              strings.TrimPrefix(ts.URL, "https://"),
+		AuthStyle:         "certificate_auth",
+		CaCertPath:        writeFile(t, dir, "ca.pem", pki.caPEM, 0600),
+		CertificateScript: config.CertificateScript{Path: script, Timeout: "5s"},
+	}
+
+	if _, err := New(poller).GetClusterInfo(t.Context()); err != nil {
+		t.Fatalf("GetClusterInfo: %v", err)
+	}
+	if gotAuth != "" {
+		t.Fatalf("expected no Authorization header with certificate_auth, got %q", gotAuth)
+	}
+}
+
+func TestNewTLSConfig_Errors(t *testing.T) {
+	dir := t.TempDir()
+	notPEM := writeFile(t, dir, "ca.pem", []byte("not a certificate"), 0600)
+
+	tests := []struct {
+		name        string
+		poller      *config.Poller
+		errContains string
+	}{
+		{
+			name:        "unknown auth_style",
+			poller:      &config.Poller{AuthStyle: "kerberos"},
+			errContains: "invalid auth_style",
+		},
+		{
+			name:        "missing ca_cert file",
+			poller:      &config.Poller{CaCertPath: filepath.Join(dir, "missing.pem")},
+			errContains: "failed to read ca_cert",
+		},
+		{
+			name:        "ca_cert without certificates",
+			poller:      &config.Poller{CaCertPath: notPEM},
+			errContains: "does not contain any PEM",
+		},
+		{
+			name:        "certificate_auth without cert source",
+			poller:      &config.Poller{AuthStyle: "certificate_auth"},
+			errContains: "requires either certificate_script or both ssl_cert and ssl_key",
+		},
+		{
+			name:        "certificate_auth with missing key file",
+			poller:      &config.Poller{AuthStyle: "certificate_auth", SslCert: notPEM, SslKey: filepath.Join(dir, "missing.key")},
+			errContains: "failed to read ssl_key",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newTLSConfig(tt.poller)
+			if err == nil {
+				t.Fatal("expected error, got nil")
+			}
+			if !strings.Contains(err.Error(), tt.errContains) {
+				t.Fatalf("error %q does not contain %q", err.Error(), tt.errContains)
+			}
+		})
+	}
+}
+
+func TestClient_InvalidTLSSettingsFailRequests(t *testing.T) {
+	poller := &config.Poller{Name: "c1", Addr: "127.0.0.1:1", AuthStyle: "certificate_auth"}
+	_, err := New(poller).GetClusterInfo(t.Context())
+	if err == nil || !strings.Contains(err.Error(), "invalid TLS settings for poller c1") {
+		t.Fatalf("expected TLS settings error, got %v", err)
+	}
+}
+
+func TestSplitPEM(t *testing.T) {
+	pki := newTestPKI(t)
+
+	certPEM, keyPEM, err := splitPEM(append(append([]byte("noise\n"), pki.clientKey...), pki.clientCert...))
+	if err != nil {
+		t.Fatalf("splitPEM: %v", err)
+	}
+	if string(certPEM) != string(pki.clientCert) || string(keyPEM) != string(pki.clientKey) {
+		t.Fatal("splitPEM did not separate certificate and key")
+	}
+
+	if _, _, err := splitPEM(pki.clientCert); err == nil {
+		t.Fatal("expected error when private key is missing")
+	}
+	if _, _, err := splitPEM(pki.clientKey); err == nil {
+		t.Fatal("expected error when certificate is missing")
+	}
+}
@@ -16,7 +16,7 @@ func (c *Client) CreateCIFSShare(ctx context.Context, cifsShare ontap.CIFSShare)
 	)
 	responseHeaders := http.Header{}
 
-	builder := c.baseRequestBuilder(`/api/protocols/cifs/shares`, &statusCode, responseHeaders).
+	builder := c.createRequestBuilder(ctx, `/api/protocols/cifs/shares`, &statusCode, responseHeaders).
 		ToBytesBuffer(&buf).
 		BodyJSON(cifsShare)
 
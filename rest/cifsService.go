@@ -15,7 +15,7 @@ func (c *Client) CreateCIFSService(ctx context.Context, cifsService ontap.CIFSSe
 	)
 	responseHeaders := http.Header{}
 
-	builder := c.baseRequestBuilder(`/api/protocols/cifs/services`, &statusCode, responseHeaders).
+	builder := c.createRequestBuilder(ctx, `/api/protocols/cifs/services`, &statusCode, responseHeaders).
 		BodyJSON(cifsService).
 		ToBytesBuffer(&buf)
 
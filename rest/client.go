@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"context"
 	"crypto/sha1" //nolint:gosec // using sha1 for a hash, not a security risk
-	"crypto/tls"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
@@ -17,24 +16,38 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/netapp/ontap-mcp/version"
 
 	"github.com/carlmjohnson/requests"
 	"github.com/netapp/ontap-mcp/config"
+	"github.com/netapp/ontap-mcp/metrics"
 	"github.com/netapp/ontap-mcp/ontap"
+	"github.com/netapp/ontap-mcp/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type Client struct {
 	poller          *config.Poller
-	httpClient      *http.Client
+	httpClient      atomic.Pointer[http.Client] // read by CloseIdleConnections while getHTTPClient may set it
 	credCache       credentialsCache
-	remote          ontap.Remote
 	initOnce        sync.Once
+	initErr         error
 	jobPollInterval time.Duration
+
+	remoteMu      sync.RWMutex
+	remote        ontap.Remote
+	remoteFetched time.Time
 }
 
+// remoteTTL bounds how long the cached cluster Remote is trusted before it is
+// fetched again, so an ONTAP upgrade is eventually noticed by a long-lived client.
+const remoteTTL = 24 * time.Hour
+
 // credentials holds authentication information
 type credentials struct {
 	Username  string
@@ -57,13 +70,48 @@ func (c *Client) handleJob(ctx context.Context, statusCode int, buf *bytes.Buffe
 	if strings.TrimSpace(pj.Job.UUID) == "" {
 		return errors.New("async job response is missing job UUID")
 	}
+	jobs := jobsFromContext(ctx)
+	jobs.record(pj.Job.UUID)
+	if jobs != nil && jobs.noWait {
+		return nil
+	}
+
+	timeout, err := c.poller.JobTimeoutDuration()
+	if err != nil {
+		return err
+	}
 
-	return c.waitForJob(ctx, `/api/cluster/jobs/`+pj.Job.UUID, 3*time.Minute)
+	start := time.Now()
+	err = c.waitForJob(ctx, `/api/cluster/jobs/`+pj.Job.UUID, timeout)
+	metrics.JobWait.WithLabelValues(c.poller.Name, jobState(err)).Observe(time.Since(start).Seconds())
+	return err
+}
+
+func jobState(err error) string {
+	switch {
+	case err == nil:
+		return metrics.JobSuccess
+	case errors.Is(err, context.DeadlineExceeded):
+		return metrics.JobTimeout
+	default:
+		return metrics.JobFailure
+	}
 }
 
-//nolint:unparam
 func (c *Client) waitForJob(ctx context.Context, jobLocation string, duration time.Duration) error {
+	ctx, span := tracer.Start(ctx, "waitForJob", trace.WithAttributes(
+		attribute.String("ontap.cluster", c.poller.Name),
+		attribute.String("ontap.job.location", jobLocation)))
+	err := c.pollJob(ctx, span, jobLocation, duration)
+	tracing.End(span, err)
+	return err
+}
+
+// pollJob polls jobLocation until the job finishes. Each poll is a child span
+// of span and its job state is added to span as an event.
+func (c *Client) pollJob(ctx context.Context, span trace.Span, jobLocation string, duration time.Duration) error {
 	var jr ontap.JobResponse
+	jobs := jobsFromContext(ctx)
 
 	pollInterval := c.jobPollInterval
 	if pollInterval <= 0 {
@@ -88,6 +136,8 @@ func (c *Client) waitForJob(ctx context.Context, jobLocation string, duration ti
 	// otherwise keep trying
 	// queued, running, paused
 	handleJob := func(jobResponse ontap.JobResponse) (bool, error) {
+		span.AddEvent("poll", trace.WithAttributes(attribute.String("ontap.job.state", jobResponse.State)))
+		jobs.poll(jobResponse)
 		switch jobResponse.State {
 		case "success":
 			return true, nil
@@ -141,41 +191,77 @@ func ontapValidator(response *http.Response) error {
 		var ontapErr ontap.ClusterError
 		err := requests.ToJSON(&ontapErr)(response)
 		if err != nil {
-			return err
+			// Proxies and nodes in takeover may answer with a non-JSON body;
+			// keep the status so the error can still be classified.
+			if response.StatusCode < http.StatusInternalServerError && response.StatusCode != http.StatusTooManyRequests {
+				return err
+			}
+			ontapErr.Err.Message = http.StatusText(response.StatusCode)
 		}
 		ontapErr.StatusCode = response.StatusCode
+		ontapErr.RetryAfter = parseRetryAfter(response.Header.Get("Retry-After"), time.Now())
 		return ontapErr
 	}
 	return nil
 }
 
-func (c *Client) newClient() *http.Client {
-	transport := &http.Transport{
-		TLSClientConfig: &tls.Config{
-			InsecureSkipVerify: c.poller.InsecureTLS(), //nolint:gosec
-		},
+func (c *Client) newClient() (*http.Client, error) {
+	var transport http.RoundTripper
+
+	timeout, err := c.poller.ClientTimeoutDuration()
+	if err != nil {
+		return nil, err
 	}
+
+	// Replay mode serves recorded responses, so it needs neither a network
+	// transport nor TLS material.
+	if !isReplay(c.poller) {
+		tlsConfig, err := newTLSConfig(c.poller)
+		if err != nil {
+			return nil, fmt.Errorf("invalid TLS settings for poller %s: %w", c.poller.Name, err)
+		}
+
+		// The client is shared by every tool call against this cluster, so keep
+		// connections alive and reuse TLS sessions instead of handshaking per call.
+		transport = &http.Transport{
+			TLSClientConfig:     tlsConfig,
+			ForceAttemptHTTP2:   true,
+			MaxIdleConns:        32,
+			MaxIdleConnsPerHost: 8,
+			IdleConnTimeout:     90 * time.Second,
+			TLSHandshakeTimeout: 10 * time.Second,
+		}
+	}
+
+	transport, err = newRecorder(c.poller, transport)
+	if err != nil {
+		return nil, fmt.Errorf("invalid recorder settings for poller %s: %w", c.poller.Name, err)
+	}
+
 	aClient := &http.Client{
-		Transport: transport,
-		Timeout:   2 * time.Minute,
+		Transport: instrument(c.poller, transport),
+		Timeout:   timeout,
 	}
 
-	return aClient
+	return aClient, nil
 }
 
 func New(p *config.Poller) *Client {
 	return &Client{
-		poller:     p,
-		httpClient: nil,
+		poller: p,
 	}
 }
 
 // NewWithClient creates a new Client with a custom HTTP client for testing
 func NewWithClient(p *config.Poller, aClient *http.Client) *Client {
-	return &Client{
-		poller:     p,
-		httpClient: aClient,
-	}
+	if aClient != nil {
+		instrumented := *aClient
+		instrumented.Transport = instrument(p, aClient.Transport)
+		aClient = &instrumented
+	}
+	c := &Client{poller: p}
+	c.httpClient.Store(aClient)
+	return c
 }
 
 // getHTTPClient returns the custom client if set, otherwise creates a new default client
@@ -184,17 +270,18 @@ func (c *Client) getHTTPClient() *http.Client {
 	var wasInitialized bool
 
 	c.initOnce.Do(func() {
-		if c.httpClient == nil {
-			c.httpClient = c.newClient()
-			wasInitialized = true
+		if c.httpClient.Load() == nil {
+			var client *http.Client
+			client, c.initErr = c.newClient()
+			c.httpClient.Store(client)
+			wasInitialized = c.initErr == nil
 		}
 	})
 
 	// If we just initialized the client, fetch cluster info and send MCP version
 	if wasInitialized {
-		remote, err := c.GetClusterInfo(context.Background())
+		_, err := c.GetClusterInfo(context.Background())
 		if err == nil {
-			c.remote = remote
 			err = c.sendMcpVersion()
 			if err != nil {
 				slog.Error("failed to send mcp version", slog.Any("error", err))
@@ -202,9 +289,45 @@ func (c *Client) getHTTPClient() *http.Client {
 		}
 	}
 
-	return c.httpClient
+	return c.httpClient.Load()
+}
+
+// CloseIdleConnections releases the pooled connections held by this client.
+func (c *Client) CloseIdleConnections() {
+	if client := c.httpClient.Load(); client != nil {
+		client.CloseIdleConnections()
+	}
+}
+
+// Remote returns the cluster identity and version, fetching it from ONTAP only
+// when it has not been cached yet or the cached copy is older than remoteTTL.
+func (c *Client) Remote(ctx context.Context) (ontap.Remote, error) {
+	// The first use of the HTTP client already fetches the cluster info.
+	c.getHTTPClient()
+
+	if r, ok := c.cachedRemote(); ok {
+		return r, nil
+	}
+	return c.GetClusterInfo(ctx)
 }
 
+func (c *Client) cachedRemote() (ontap.Remote, bool) {
+	c.remoteMu.RLock()
+	defer c.remoteMu.RUnlock()
+	if c.remoteFetched.IsZero() || time.Since(c.remoteFetched) >= remoteTTL {
+		return ontap.Remote{}, false
+	}
+	return c.remote, true
+}
+
+func (c *Client) setRemote(r ontap.Remote) {
+	c.remoteMu.Lock()
+	defer c.remoteMu.Unlock()
+	c.remote = r
+	c.remoteFetched = time.Now()
+}
+
+// GetClusterInfo always queries ONTAP and refreshes the cached Remote.
 func (c *Client) GetClusterInfo(ctx context.Context) (ontap.Remote, error) {
 	var cluster ontap.Cluster
 
@@ -231,6 +354,8 @@ func (c *Client) GetClusterInfo(ctx context.Context) (ontap.Remote, error) {
 		r.Model = ontap.ASAr2
 	}
 
+	c.setRemote(r)
+
 	return r, nil
 }
 
@@ -264,17 +389,49 @@ func (c *Client) baseRequestBuilder(endpoint string, statusCode *int, responseHe
 // buildAndExecuteRequest is a helper method that handles the common pattern of:
 // 1. Getting authentication credentials
 // 2. Building a request with authentication
-// 3. Executing the request
+// 3. Executing the request, or capturing it when ctx carries a Plan
+//
+// Each call is traced as a span; see tracingTransport.
 func (c *Client) buildAndExecuteRequest(ctx context.Context, builder *requests.Builder) error {
-	creds, err := c.getAuth(ctx)
-	if err != nil {
-		return err
+	ctx, span := tracer.Start(ctx, "ontap request",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String("ontap.cluster", c.poller.Name)))
+	err := c.executeWithRetry(ctx, span, builder)
+	tracing.End(span, err)
+	return err
+}
+
+func (c *Client) executeRequest(ctx context.Context, builder *requests.Builder) error {
+	if c.initErr != nil {
+		return c.initErr
 	}
 
-	if creds.AuthToken != "" {
-		builder = builder.Bearer(creds.AuthToken)
-	} else {
-		builder = builder.BasicAuth(creds.Username, creds.Password)
+	// In plan mode, mutating requests are captured instead of sent. They
+	// skip authentication too, so no credentials script runs for them.
+	if plan := planFromContext(ctx); plan != nil {
+		req, err := builder.Request(ctx)
+		if err != nil {
+			return err
+		}
+		if isMutating(req.Method) {
+			return builder.Transport(plan).Fetch(ctx)
+		}
+	}
+
+	// With certificate_auth the client certificate presented during the TLS
+	// handshake authenticates the request, so no credentials are sent. Replay
+	// mode never reaches a cluster and must not run credential scripts.
+	if !usesCertificateAuth(c.poller) && !isReplay(c.poller) {
+		creds, err := c.getAuth(ctx)
+		if err != nil {
+			return err
+		}
+
+		if creds.AuthToken != "" {
+			builder = builder.Bearer(creds.AuthToken)
+		} else {
+			builder = builder.BasicAuth(creds.Username, creds.Password)
+		}
 	}
 
 	return builder.Fetch(ctx)
@@ -322,16 +479,22 @@ func (c *Client) getFileCredentials() (credentials, error) {
 
 // getScriptCredentials fetches credentials from the configured script
 func (c *Client) getScriptCredentials(ctx context.Context) (credentials, error) {
+	ctx, span := tracer.Start(ctx, "getScriptCredentials", trace.WithAttributes(attribute.String("ontap.cluster", c.poller.Name)))
+	defer span.End()
+
 	schedule := parseSchedule(c.poller.CredentialsScript.Schedule)
 
 	// Check if we need to refresh credentials
 	if !c.credCache.shouldRefreshCredentials(schedule) {
+		span.SetAttributes(attribute.Bool("ontap.credentials.cached", true))
 		return c.credCache.getCredentials(), nil
 	}
 
 	// Execute the script to get new credentials
 	response, err := executeCredentialsScript(ctx, c.poller)
 	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
 		return credentials{}, err
 	}
 
@@ -352,7 +515,8 @@ func (c *Client) getScriptCredentials(ctx context.Context) (credentials, error)
 }
 
 func (c *Client) sendMcpVersion() error {
-	if !c.remote.HasREST {
+	remote, ok := c.cachedRemote()
+	if !ok || !remote.HasREST {
 		return nil
 	}
 
@@ -360,7 +524,7 @@ func (c *Client) sendMcpVersion() error {
 	// send an ontapmcpTag to the cluster to indicate that the ONTAP MCP is running.
 	// Otherwise, do nothing
 
-	if c.remote.Version.Generation < 9 || c.remote.Version.Major < 11 || c.remote.Version.Minor < 1 {
+	if remote.Version.Generation < 9 || remote.Version.Major < 11 || remote.Version.Minor < 1 {
 		return nil
 	}
 
@@ -0,0 +1,156 @@
+package rest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/netapp/ontap-mcp/config"
+	"github.com/netapp/ontap-mcp/metrics"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+const clusterJSON = `{"name":"cluster1","uuid":"c-uuid","version":{"full":"NetApp Release 9.16.1","generation":9,"major":16,"minor":1}}`
+
+func newTestServer(t *testing.T, handler http.HandlerFunc) (*httptest.Server, *config.Poller) {
+	t.Helper()
+	ts := httptest.NewTLSServer(handler)
+	t.Cleanup(ts.Close)
+	poller := &config.Poller{
+		Name:     "cluster1",
+		Addr:     strings.TrimPrefix(ts.URL, "https://"),
+		Username: "admin",
+		Password: "secret",
+	}
+	return ts, poller
+}
+
+func TestClient_RemoteIsCached(t *testing.T) {
+	var clusterCalls atomic.Int32
+	ts, poller := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/cluster" {
+			clusterCalls.Add(1)
+			_, _ = w.Write([]byte(clusterJSON))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	c := NewWithClient(poller, ts.Client())
+	for range 3 {
+		remote, err := c.Remote(t.Context())
+		if err != nil {
+			t.Fatalf("Remote: %v", err)
+		}
+		if remote.Name != "cluster1" || remote.Version.Major != 16 {
+			t.Fatalf("unexpected remote %+v", remote)
+		}
+	}
+
+	if got := clusterCalls.Load(); got != 1 {
+		t.Fatalf("expected 1 call to /api/cluster, got %d", got)
+	}
+
+	// GetClusterInfo always refreshes.
+	if _, err := c.GetClusterInfo(t.Context()); err != nil {
+		t.Fatalf("GetClusterInfo: %v", err)
+	}
+	if got := clusterCalls.Load(); got != 2 {
+		t.Fatalf("expected 2 calls to /api/cluster, got %d", got)
+	}
+}
+
+func TestClient_HandleJobHonorsJobTimeout(t *testing.T) {
+	ts, poller := newTestServer(t, func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"uuid":"j1","state":"running"}`))
+	})
+	poller.JobTimeout = "100ms"
+
+	c := NewWithClient(poller, ts.Client())
+	c.jobPollInterval = 10 * time.Millisecond
+
+	start := time.Now()
+	err := c.handleJob(t.Context(), http.StatusAccepted, bytes.NewBufferString(`{"job":{"uuid":"j1"}}`))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected job wait to time out, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Fatalf("job_timeout was not honored, waited %v", elapsed)
+	}
+	rec := httptest.NewRecorder()
+	metrics.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", http.NoBody))
+	if want := `ontap_mcp_ontap_job_wait_seconds_count{cluster="cluster1",state="timeout"}`; !strings.Contains(rec.Body.String(), want) {
+		t.Fatalf("expected the timed out job wait to be observed as %s", want)
+	}
+	if got := testutil.ToFloat64(metrics.ONTAPRequests.WithLabelValues("cluster1", http.MethodGet, "200")); got == 0 {
+		t.Fatal("expected job polls to be counted as ONTAP requests")
+	}
+}
+
+func TestClient_HonorsClientTimeout(t *testing.T) {
+	_, poller := newTestServer(t, func(w http.ResponseWriter, _ *http.Request) {
+		time.Sleep(300 * time.Millisecond)
+		_, _ = w.Write([]byte(clusterJSON))
+	})
+	poller.ClientTimeout = "50ms"
+	poller.UseInsecureTLS = new(true)
+
+	c := New(poller)
+	_, err := c.GetClusterInfo(t.Context())
+	if err == nil || !strings.Contains(err.Error(), "Client.Timeout") {
+		t.Fatalf("expected client timeout error, got %v", err)
+	}
+}
+
+func TestClient_TracesJobPolls(t *testing.T) {
+	// The global tracer provider can only be replaced once, so it is not
+	// restored after the test.
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	var polls atomic.Int32
+	ts, poller := newTestServer(t, func(w http.ResponseWriter, _ *http.Request) {
+		if polls.Add(1) < 3 {
+			_, _ = w.Write([]byte(`{"uuid":"j1","state":"running"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"uuid":"j1","state":"success"}`))
+	})
+	c := NewWithClient(poller, ts.Client())
+	c.jobPollInterval = 10 * time.Millisecond
+
+	if err := c.waitForJob(t.Context(), "/api/cluster/jobs/j1", time.Second); err != nil {
+		t.Fatalf("waitForJob: %v", err)
+	}
+
+	var wait sdktrace.ReadOnlySpan
+	for _, s := range recorder.Ended() {
+		if s.Name() == "waitForJob" {
+			wait = s
+		}
+	}
+	if wait == nil {
+		t.Fatal("expected a waitForJob span")
+	}
+	if got := len(wait.Events()); got != 3 {
+		t.Fatalf("expected 3 poll events, got %d", got)
+	}
+	var children int
+	for _, s := range recorder.Ended() {
+		if s.Parent().SpanID() == wait.SpanContext().SpanID() && s.Name() == "GET /api/cluster/jobs/j1" {
+			children++
+		}
+	}
+	if children != 3 {
+		t.Fatalf("expected 3 poll spans under waitForJob, got %d", children)
+	}
+}
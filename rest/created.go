@@ -0,0 +1,84 @@
+package rest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"slices"
+	"sync"
+
+	"github.com/carlmjohnson/requests"
+)
+
+// CreatedObject is an object ONTAP reported as created by a POST request.
+type CreatedObject struct {
+	UUID string `json:"uuid,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+// Created collects the objects created by the create calls rest.Client makes
+// with a context from WithCreated. Those requests ask ONTAP to return the new
+// records, so their UUIDs are known without a second lookup.
+type Created struct {
+	mu      sync.Mutex
+	objects []CreatedObject
+}
+
+type createdKey struct{}
+
+// WithCreated returns a context that records the objects created by
+// rest.Client calls made with it, and the Created they are added to.
+func WithCreated(ctx context.Context) (context.Context, *Created) {
+	c := &Created{}
+	return context.WithValue(ctx, createdKey{}, c), c
+}
+
+func createdFromContext(ctx context.Context) *Created {
+	c, _ := ctx.Value(createdKey{}).(*Created)
+	return c
+}
+
+// Objects returns the created objects in the order ONTAP reported them.
+func (c *Created) Objects() []CreatedObject {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return slices.Clone(c.objects)
+}
+
+// createRequestBuilder is baseRequestBuilder for a POST that creates an
+// object. When ctx comes from WithCreated, the request asks ONTAP to return
+// the new record and adds it to the Created. Action POSTs, such as a
+// SnapMirror transfer, use baseRequestBuilder.
+func (c *Client) createRequestBuilder(ctx context.Context, endpoint string, statusCode *int, responseHeaders http.Header) *requests.Builder {
+	builder := c.baseRequestBuilder(endpoint, statusCode, responseHeaders)
+	if created := createdFromContext(ctx); created != nil {
+		builder = builder.Param("return_records", "true").AddValidator(created.record)
+	}
+	return builder
+}
+
+// record reads the records of a successful response, and puts the body back
+// for the builder's own handler.
+func (c *Created) record(response *http.Response) error {
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return err
+	}
+	response.Body = io.NopCloser(bytes.NewReader(body))
+
+	// A body without records, such as an empty one, adds nothing.
+	var resp struct {
+		Records []CreatedObject `json:"records"`
+	}
+	_ = json.Unmarshal(body, &resp)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, r := range resp.Records {
+		if r.UUID != "" || r.Name != "" {
+			c.objects = append(c.objects, r)
+		}
+	}
+	return nil
+}
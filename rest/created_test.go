@@ -0,0 +1,57 @@
+package rest
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/netapp/ontap-mcp/ontap"
+)
+
+func TestClient_CreatedObjects(t *testing.T) {
+	ts, poller := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			if r.URL.Query().Has("return_records") {
+				t.Errorf("expected GET %s not to ask for return_records", r.URL.Path)
+			}
+			_, _ = w.Write([]byte(`{"num_records":1,"records":[{"uuid":"v-uuid"}]}`))
+			return
+		}
+		if r.URL.Query().Get("return_records") != "true" {
+			t.Errorf("expected POST %s to ask for return_records", r.URL.Path)
+		}
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"num_records":1,"records":[{"uuid":"s-uuid","name":"snap1"}]}`))
+	})
+	c := NewWithClient(poller, ts.Client())
+
+	ctx, created := WithCreated(t.Context())
+	if err := c.CreateSnapshot(ctx, ontap.Snapshot{Name: "snap1"}, "vol1", "vs1"); err != nil {
+		t.Fatalf("CreateSnapshot: %v", err)
+	}
+	objects := created.Objects()
+	if len(objects) != 1 || objects[0] != (CreatedObject{UUID: "s-uuid", Name: "snap1"}) {
+		t.Fatalf("expected snapshot snap1 to be recorded, got %+v", objects)
+	}
+}
+
+func TestClient_ActionPostsDoNotReturnRecords(t *testing.T) {
+	ts, poller := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Has("return_records") && r.Method == http.MethodPost {
+			t.Errorf("expected POST %s not to ask for return_records", r.URL.Path)
+		}
+		if r.Method == http.MethodGet {
+			_, _ = w.Write([]byte(`{"num_records":1,"records":[{"uuid":"ig-uuid"}]}`))
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	})
+	c := NewWithClient(poller, ts.Client())
+
+	ctx, created := WithCreated(t.Context())
+	if err := c.AddIGroupInitiator(ctx, "ig1", "vs1", ontap.IGroupInitiator{Name: "iqn.1998-01.com.vmware:host1"}); err != nil {
+		t.Fatalf("AddIGroupInitiator: %v", err)
+	}
+	if objects := created.Objects(); len(objects) != 0 {
+		t.Fatalf("expected no created objects, got %+v", objects)
+	}
+}
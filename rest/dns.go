@@ -13,7 +13,7 @@ func (c *Client) CreateDNS(ctx context.Context, dns ontap.DNSConfig) error {
 	)
 	responseHeaders := http.Header{}
 
-	builder := c.baseRequestBuilder(`/api/name-services/dns`, &statusCode, responseHeaders).
+	builder := c.createRequestBuilder(ctx, `/api/name-services/dns`, &statusCode, responseHeaders).
 		BodyJSON(dns)
 
 	if err := c.buildAndExecuteRequest(ctx, builder); err != nil {
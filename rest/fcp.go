@@ -14,7 +14,7 @@ func (c *Client) CreateFCPService(ctx context.Context, fcpService ontap.FCPServi
 
 	responseHeaders := http.Header{}
 
-	builder := c.baseRequestBuilder(`/api/protocols/san/fcp/services`, &statusCode, responseHeaders).
+	builder := c.createRequestBuilder(ctx, `/api/protocols/san/fcp/services`, &statusCode, responseHeaders).
 		BodyJSON(fcpService)
 
 	if err := c.buildAndExecuteRequest(ctx, builder); err != nil {
@@ -106,7 +106,7 @@ func (c *Client) CreateFCInterface(ctx context.Context, fcInterface ontap.FCInte
 
 	responseHeaders := http.Header{}
 
-	builder := c.baseRequestBuilder(`/api/network/fc/interfaces`, &statusCode, responseHeaders).
+	builder := c.createRequestBuilder(ctx, `/api/network/fc/interfaces`, &statusCode, responseHeaders).
 		BodyJSON(fcInterface)
 
 	if err := c.buildAndExecuteRequest(ctx, builder); err != nil {
@@ -18,7 +18,7 @@ func (c *Client) CreateIGroup(ctx context.Context, igroup ontap.IGroup) error {
 	)
 	responseHeaders := http.Header{}
 
-	builder := c.baseRequestBuilder(`/api/protocols/san/igroups`, &statusCode, responseHeaders).
+	builder := c.createRequestBuilder(ctx, `/api/protocols/san/igroups`, &statusCode, responseHeaders).
 		BodyJSON(igroup).
 		ToBytesBuffer(&buf)
 
@@ -14,7 +14,7 @@ func (c *Client) CreateIscsiService(ctx context.Context, iscsiService ontap.Iscs
 	)
 	responseHeaders := http.Header{}
 
-	builder := c.baseRequestBuilder(`/api/protocols/san/iscsi/services`, &statusCode, responseHeaders).
+	builder := c.createRequestBuilder(ctx, `/api/protocols/san/iscsi/services`, &statusCode, responseHeaders).
 		BodyJSON(iscsiService)
 
 	if err := c.buildAndExecuteRequest(ctx, builder); err != nil {
@@ -101,7 +101,7 @@ func (c *Client) CreateNetworkIPInterface(ctx context.Context, nwInterface ontap
 	)
 	responseHeaders := http.Header{}
 
-	builder := c.baseRequestBuilder(`/api/network/ip/interfaces`, &statusCode, responseHeaders).
+	builder := c.createRequestBuilder(ctx, `/api/network/ip/interfaces`, &statusCode, responseHeaders).
 		BodyJSON(nwInterface)
 
 	if err := c.buildAndExecuteRequest(ctx, builder); err != nil {
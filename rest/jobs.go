@@ -0,0 +1,149 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"slices"
+	"strconv"
+	"sync"
+
+	"github.com/netapp/ontap-mcp/ontap"
+)
+
+// Jobs controls how a rest.Client handles the async jobs ONTAP starts for
+// calls made with a context from WithJobs.
+type Jobs struct {
+	noWait bool
+	onPoll func(ontap.JobResponse)
+	mu     sync.Mutex
+	uuids  []string
+}
+
+type jobsKey struct{}
+
+// WithJobs returns a context whose rest.Client calls report async jobs to the
+// returned Jobs. With noWait, a call returns as soon as ONTAP accepts it and
+// the job's UUID is recorded instead of waiting for the job to finish.
+// Otherwise onPoll, if not nil, is called with the job's state on every poll.
+func WithJobs(ctx context.Context, noWait bool, onPoll func(ontap.JobResponse)) (context.Context, *Jobs) {
+	j := &Jobs{noWait: noWait, onPoll: onPoll}
+	return context.WithValue(ctx, jobsKey{}, j), j
+}
+
+func jobsFromContext(ctx context.Context) *Jobs {
+	j, _ := ctx.Value(jobsKey{}).(*Jobs)
+	return j
+}
+
+// UUIDs returns the UUIDs of every job ONTAP started, in the order they were
+// started.
+func (j *Jobs) UUIDs() []string {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return slices.Clone(j.uuids)
+}
+
+// Started returns the UUIDs of the jobs left running, in the order they were
+// started.
+func (j *Jobs) Started() []string {
+	if !j.noWait {
+		return nil
+	}
+	return j.UUIDs()
+}
+
+func (j *Jobs) record(uuid string) {
+	if j == nil {
+		return
+	}
+	j.mu.Lock()
+	j.uuids = append(j.uuids, uuid)
+	j.mu.Unlock()
+}
+
+func (j *Jobs) poll(jr ontap.JobResponse) {
+	if j != nil && j.onPoll != nil {
+		j.onPoll(jr)
+	}
+}
+
+const jobFields = "uuid,description,state,message,code,start_time,end_time,svm,error"
+
+func (c *Client) GetJob(ctx context.Context, uuid string) (ontap.JobResponse, error) {
+	var (
+		statusCode int
+		jr         ontap.JobResponse
+	)
+
+	if uuid == "" {
+		return jr, errors.New("job uuid is required")
+	}
+
+	params := url.Values{}
+	params.Set("fields", jobFields)
+
+	builder := c.baseRequestBuilder(`/api/cluster/jobs/`+url.PathEscape(uuid), &statusCode, nil).
+		Params(params).
+		ToJSON(&jr)
+
+	if err := c.buildAndExecuteRequest(ctx, builder); err != nil {
+		return jr, err
+	}
+
+	return jr, c.checkStatus(statusCode)
+}
+
+// ListJobs returns up to maxRecords jobs, most recently started first. An
+// empty state returns jobs in every state.
+func (c *Client) ListJobs(ctx context.Context, state string, maxRecords int) ([]ontap.JobResponse, error) {
+	var (
+		statusCode int
+		resp       struct {
+			Records []ontap.JobResponse `json:"records"`
+		}
+	)
+
+	params := url.Values{}
+	params.Set("fields", jobFields)
+	params.Set("order_by", "start_time desc")
+	params.Set("max_records", strconv.Itoa(maxRecords))
+	if state != "" {
+		params.Set("state", state)
+	}
+
+	builder := c.baseRequestBuilder(`/api/cluster/jobs`, &statusCode, nil).
+		Params(params).
+		ToJSON(&resp)
+
+	if err := c.buildAndExecuteRequest(ctx, builder); err != nil {
+		return nil, err
+	}
+
+	if err := c.checkStatus(statusCode); err != nil {
+		return nil, err
+	}
+	return resp.Records, nil
+}
+
+func (c *Client) CancelJob(ctx context.Context, uuid string) error {
+	var statusCode int
+
+	if uuid == "" {
+		return errors.New("job uuid is required")
+	}
+
+	params := url.Values{}
+	params.Set("action", "cancel")
+
+	builder := c.baseRequestBuilder(`/api/cluster/jobs/`+url.PathEscape(uuid), &statusCode, nil).
+		Params(params).
+		BodyJSON(struct{}{}).
+		Patch()
+
+	if err := c.buildAndExecuteRequest(ctx, builder); err != nil {
+		return err
+	}
+
+	return c.checkStatus(statusCode)
+}
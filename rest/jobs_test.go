@@ -0,0 +1,102 @@
+package rest
+
+import (
+	"bytes"
+	"net/http"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/netapp/ontap-mcp/ontap"
+)
+
+func TestClient_HandleJobNoWait(t *testing.T) {
+	var calls atomic.Int32
+	ts, poller := newTestServer(t, func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		_, _ = w.Write([]byte(`{"uuid":"j1","state":"running"}`))
+	})
+	c := NewWithClient(poller, ts.Client())
+
+	ctx, jobs := WithJobs(t.Context(), true, nil)
+	if err := c.handleJob(ctx, http.StatusAccepted, bytes.NewBufferString(`{"job":{"uuid":"j1"}}`)); err != nil {
+		t.Fatalf("handleJob: %v", err)
+	}
+	if got := jobs.Started(); !slices.Equal(got, []string{"j1"}) {
+		t.Fatalf("expected job j1 to be recorded, got %v", got)
+	}
+	if got := calls.Load(); got != 0 {
+		t.Fatalf("expected no job polls, got %d", got)
+	}
+}
+
+func TestClient_WaitForJobReportsPolls(t *testing.T) {
+	var polls atomic.Int32
+	ts, poller := newTestServer(t, func(w http.ResponseWriter, _ *http.Request) {
+		if polls.Add(1) < 2 {
+			_, _ = w.Write([]byte(`{"uuid":"j1","state":"running","message":"Deleting volume"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"uuid":"j1","state":"success","message":"Complete"}`))
+	})
+	c := NewWithClient(poller, ts.Client())
+	c.jobPollInterval = 10 * time.Millisecond
+
+	var messages []string
+	ctx, jobs := WithJobs(t.Context(), false, func(jr ontap.JobResponse) {
+		messages = append(messages, jr.State+": "+jr.Message)
+	})
+	if err := c.handleJob(ctx, http.StatusAccepted, bytes.NewBufferString(`{"job":{"uuid":"j1"}}`)); err != nil {
+		t.Fatalf("handleJob: %v", err)
+	}
+	if want := []string{"running: Deleting volume", "success: Complete"}; !slices.Equal(messages, want) {
+		t.Fatalf("poll callbacks = %v, want %v", messages, want)
+	}
+	if got := jobs.Started(); len(got) != 0 {
+		t.Fatalf("expected no jobs left running, got %v", got)
+	}
+	if got := jobs.UUIDs(); !slices.Equal(got, []string{"j1"}) {
+		t.Fatalf("expected job j1 to be recorded, got %v", got)
+	}
+}
+
+func TestClient_Jobs(t *testing.T) {
+	var cancelled string
+	ts, poller := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPatch && r.URL.Path == "/api/cluster/jobs/j1":
+			cancelled = r.URL.Query().Get("action")
+			_, _ = w.Write([]byte(`{}`))
+		case r.URL.Path == "/api/cluster/jobs/j1":
+			_, _ = w.Write([]byte(`{"uuid":"j1","state":"running","description":"DELETE /api/svm/svms/s1"}`))
+		case r.URL.Path == "/api/cluster/jobs":
+			if r.URL.Query().Get("state") != "running" || r.URL.Query().Get("order_by") != "start_time desc" {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"error":{"message":"unexpected query","code":"1"}}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"num_records":2,"records":[{"uuid":"j2","state":"running"},{"uuid":"j1","state":"running"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	c := NewWithClient(poller, ts.Client())
+
+	job, err := c.GetJob(t.Context(), "j1")
+	if err != nil || job.UUID != "j1" || job.State != "running" {
+		t.Fatalf("GetJob = %+v, %v", job, err)
+	}
+
+	list, err := c.ListJobs(t.Context(), "running", 10)
+	if err != nil || len(list) != 2 || list[0].UUID != "j2" {
+		t.Fatalf("ListJobs = %+v, %v", list, err)
+	}
+
+	if err := c.CancelJob(t.Context(), "j1"); err != nil {
+		t.Fatalf("CancelJob: %v", err)
+	}
+	if cancelled != "cancel" {
+		t.Fatalf("expected PATCH with action=cancel, got action=%q", cancelled)
+	}
+}
@@ -18,7 +18,7 @@ func (c *Client) CreateLUN(ctx context.Context, lun ontap.LUN) error {
 	)
 	responseHeaders := http.Header{}
 
-	builder := c.baseRequestBuilder(`/api/storage/luns`, &statusCode, responseHeaders).
+	builder := c.createRequestBuilder(ctx, `/api/storage/luns`, &statusCode, responseHeaders).
 		BodyJSON(lun).
 		ToBytesBuffer(&buf)
 
@@ -17,7 +17,7 @@ func (c *Client) CreateLunMap(ctx context.Context, lunMap ontap.LunMap) error {
 	)
 	responseHeaders := http.Header{}
 
-	builder := c.baseRequestBuilder(`/api/protocols/san/lun-maps`, &statusCode, responseHeaders).
+	builder := c.createRequestBuilder(ctx, `/api/protocols/san/lun-maps`, &statusCode, responseHeaders).
 		BodyJSON(lunMap).
 		ToBytesBuffer(&buf)
 
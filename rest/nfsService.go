@@ -11,7 +11,7 @@ func (c *Client) CreateNFSService(ctx context.Context, nfsService ontap.NFSServi
 	var statusCode int
 	responseHeaders := http.Header{}
 
-	builder := c.baseRequestBuilder(`/api/protocols/nfs/services`, &statusCode, responseHeaders).
+	builder := c.createRequestBuilder(ctx, `/api/protocols/nfs/services`, &statusCode, responseHeaders).
 		BodyJSON(nfsService)
 
 	if err := c.buildAndExecuteRequest(ctx, builder); err != nil {
@@ -17,7 +17,7 @@ func (c *Client) CreateNVMeService(ctx context.Context, nvmeService ontap.NVMeSe
 	)
 	responseHeaders := http.Header{}
 
-	builder := c.baseRequestBuilder(`/api/protocols/nvme/services`, &statusCode, responseHeaders).
+	builder := c.createRequestBuilder(ctx, `/api/protocols/nvme/services`, &statusCode, responseHeaders).
 		BodyJSON(nvmeService)
 
 	if err := c.buildAndExecuteRequest(ctx, builder); err != nil {
@@ -104,7 +104,7 @@ func (c *Client) CreateNVMeSubsystem(ctx context.Context, nvmeSubsystem ontap.NV
 	)
 	responseHeaders := http.Header{}
 
-	builder := c.baseRequestBuilder(`/api/protocols/nvme/subsystems`, &statusCode, responseHeaders).
+	builder := c.createRequestBuilder(ctx, `/api/protocols/nvme/subsystems`, &statusCode, responseHeaders).
 		BodyJSON(nvmeSubsystem)
 
 	if err := c.buildAndExecuteRequest(ctx, builder); err != nil {
@@ -290,7 +290,7 @@ func (c *Client) CreateNVMeNamespace(ctx context.Context, nvmeNamespace ontap.NV
 	)
 	responseHeaders := http.Header{}
 
-	builder := c.baseRequestBuilder(`/api/storage/namespaces`, &statusCode, responseHeaders).
+	builder := c.createRequestBuilder(ctx, `/api/storage/namespaces`, &statusCode, responseHeaders).
 		BodyJSON(nvmeNamespace).
 		ToBytesBuffer(&buf)
 
@@ -395,7 +395,7 @@ func (c *Client) CreateNVMeSubsystemMap(ctx context.Context, nvmeSubsystemMap on
 
 	responseHeaders := http.Header{}
 
-	builder := c.baseRequestBuilder(`/api/protocols/nvme/subsystem-maps`, &statusCode, responseHeaders).
+	builder := c.createRequestBuilder(ctx, `/api/protocols/nvme/subsystem-maps`, &statusCode, responseHeaders).
 		BodyJSON(nvmeSubsystemMap).
 		ToBytesBuffer(&buf)
 
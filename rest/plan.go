@@ -0,0 +1,90 @@
+package rest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"slices"
+	"sync"
+)
+
+// PlannedRequest is an ONTAP REST call captured in plan mode instead of being
+// sent to the cluster.
+type PlannedRequest struct {
+	Method string          `json:"method"`
+	URL    string          `json:"url"`
+	Body   json.RawMessage `json:"body,omitempty"`
+}
+
+// Plan collects the mutating ONTAP REST calls a tool would make. Read-only
+// requests still reach the cluster so names resolve to UUIDs and inputs are
+// validated exactly as in a real call.
+type Plan struct {
+	mu       sync.Mutex
+	requests []PlannedRequest
+}
+
+type planKey struct{}
+
+// WithPlan returns a context that puts every rest.Client call made with it in
+// plan mode, and the Plan the captured requests are added to.
+func WithPlan(ctx context.Context) (context.Context, *Plan) {
+	p := &Plan{}
+	return context.WithValue(ctx, planKey{}, p), p
+}
+
+func planFromContext(ctx context.Context) *Plan {
+	p, _ := ctx.Value(planKey{}).(*Plan)
+	return p
+}
+
+// Requests returns the captured requests in the order they were made.
+func (p *Plan) Requests() []PlannedRequest {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return slices.Clone(p.requests)
+}
+
+func isMutating(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return false
+	default:
+		return true
+	}
+}
+
+// RoundTrip records the request and answers it with an empty 200 OK, so the
+// caller continues as if ONTAP had completed the change synchronously.
+func (p *Plan) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readAndRestoreRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	if req.Body != nil {
+		_ = req.Body.Close()
+	}
+
+	p.mu.Lock()
+	p.requests = append(p.requests, PlannedRequest{
+		Method: req.Method,
+		URL:    req.URL.String(),
+		Body:   redactBody(body),
+	})
+	p.mu.Unlock()
+
+	respBody := []byte(`{}`)
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          io.NopCloser(bytes.NewReader(respBody)),
+		ContentLength: int64(len(respBody)),
+		Request:       req,
+	}, nil
+}
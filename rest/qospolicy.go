@@ -226,7 +226,7 @@ func (c *Client) CreateQoSPolicy(ctx context.Context, qosPolicy ontap.QoSPolicy)
 	)
 	responseHeaders := http.Header{}
 
-	builder := c.baseRequestBuilder(`/api/storage/qos/policies`, &statusCode, responseHeaders).
+	builder := c.createRequestBuilder(ctx, `/api/storage/qos/policies`, &statusCode, responseHeaders).
 		BodyJSON(qosPolicy).
 		ToBytesBuffer(&buf)
 
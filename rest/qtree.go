@@ -17,7 +17,7 @@ func (c *Client) CreateQtree(ctx context.Context, qtree ontap.Qtree) error {
 	)
 	responseHeaders := http.Header{}
 
-	builder := c.baseRequestBuilder(`/api/storage/qtrees`, &statusCode, responseHeaders).
+	builder := c.createRequestBuilder(ctx, `/api/storage/qtrees`, &statusCode, responseHeaders).
 		ToBytesBuffer(&buf).
 		BodyJSON(qtree)
 
@@ -0,0 +1,352 @@
+package rest
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/netapp/ontap-mcp/config"
+)
+
+const (
+	recorderModeRecord = "record"
+	recorderModeReplay = "replay"
+
+	defaultRecorderKeepLast = 1000
+)
+
+// sensitiveHeaders are never written to a recording.
+var sensitiveHeaders = []string{"Authorization", "Cookie", "Set-Cookie", "Proxy-Authorization"}
+
+// recording is one ONTAP request/response pair as written to disk.
+type recording struct {
+	Seq             int               `json:"seq"`
+	Time            time.Time         `json:"time"`
+	Method          string            `json:"method"`
+	Path            string            `json:"path"`
+	Query           string            `json:"query,omitempty"`
+	RequestBody     json.RawMessage   `json:"request_body,omitempty"`
+	Status          int               `json:"status"`
+	ResponseHeaders map[string]string `json:"response_headers,omitempty"`
+	ResponseBody    json.RawMessage   `json:"response_body,omitempty"`
+	ResponseLength  int               `json:"response_length,omitempty"` // size of a non-JSON response body, which is not stored
+}
+
+// key re-normalizes the stored body, which is indented on disk, so it hashes
+// the same as the live request.
+func (r *recording) key() string {
+	return recordingKey(r.Method, r.Path, r.Query, redactBody(r.RequestBody))
+}
+
+// recordingKey identifies a request independently of host and credentials so
+// a replay matches the same logical call.
+func recordingKey(method, path, query string, body []byte) string {
+	h := sha256.New()
+	_, _ = fmt.Fprintf(h, "%s %s?%s\n", method, path, query)
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// newRecorder wraps next with the poller's recorder when one is configured.
+// In replay mode next is never called, so no cluster is needed.
+func newRecorder(p *config.Poller, next http.RoundTripper) (http.RoundTripper, error) {
+	rc := p.Recorder
+	if rc.Mode == "" && rc.Path == "" {
+		return next, nil
+	}
+	if rc.Path == "" {
+		return nil, errors.New("recorder.path is required when recorder is configured")
+	}
+	dir := filepath.Join(rc.Path, p.Name)
+
+	switch rc.Mode {
+	case recorderModeRecord:
+		keepLast := defaultRecorderKeepLast
+		if rc.KeepLast != "" {
+			n, err := strconv.Atoi(rc.KeepLast)
+			if err != nil || n <= 0 {
+				return nil, fmt.Errorf("invalid recorder.keep_last %q: must be a positive integer", rc.KeepLast)
+			}
+			keepLast = n
+		}
+		return newRecordTransport(dir, keepLast, next)
+	case recorderModeReplay:
+		return newReplayTransport(dir)
+	default:
+		return nil, fmt.Errorf("invalid recorder.mode %q; supported values: %s, %s", rc.Mode, recorderModeRecord, recorderModeReplay)
+	}
+}
+
+func isReplay(p *config.Poller) bool {
+	return p.Recorder.Mode == recorderModeReplay
+}
+
+// recordTransport forwards requests to ONTAP and writes every request/response
+// pair to dir, keeping only the newest keepLast recordings.
+type recordTransport struct {
+	next     http.RoundTripper
+	dir      string
+	keepLast int
+
+	mu    sync.Mutex
+	seq   int
+	files []string // oldest first
+}
+
+func newRecordTransport(dir string, keepLast int, next http.RoundTripper) (*recordTransport, error) {
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return nil, fmt.Errorf("failed to create recorder directory %s: %w", dir, err)
+	}
+	files, err := recordingFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	t := &recordTransport{next: next, dir: dir, keepLast: keepLast, files: files}
+	if len(files) > 0 {
+		t.seq = seqFromFile(files[len(files)-1])
+	}
+	return t, nil
+}
+
+func (t *recordTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, err := readAndRestoreRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	rec := &recording{
+		Time:            time.Now().UTC(),
+		Method:          req.Method,
+		Path:            req.URL.Path,
+		Query:           req.URL.Query().Encode(),
+		RequestBody:     redactBody(reqBody),
+		Status:          resp.StatusCode,
+		ResponseHeaders: flattenHeaders(resp.Header),
+	}
+	// Only JSON bodies can be redacted. Anything else is recorded by its size
+	// and Content-Type header, and replayed empty.
+	if json.Valid(respBody) {
+		rec.ResponseBody = redactBody(respBody)
+	} else {
+		rec.ResponseLength = len(respBody)
+	}
+
+	if err := t.write(rec); err != nil {
+		// A failed recording must not fail the tool call.
+		slog.Warn("failed to write ONTAP recording", slog.String("dir", t.dir), slog.Any("error", err))
+	}
+	return resp, nil
+}
+
+func (t *recordTransport) CloseIdleConnections() {
+	if c, ok := t.next.(interface{ CloseIdleConnections() }); ok {
+		c.CloseIdleConnections()
+	}
+}
+
+func (t *recordTransport) write(rec *recording) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.seq++
+	rec.Seq = t.seq
+	data, err := json.MarshalIndent(rec, "", "  ")
+	if err != nil {
+		return err
+	}
+	name := fmt.Sprintf("%08d_%s_%s.json", rec.Seq, rec.Method, sanitizePath(rec.Path))
+	if err := os.WriteFile(filepath.Join(t.dir, name), data, 0600); err != nil {
+		return err
+	}
+	t.files = append(t.files, name)
+
+	for len(t.files) > t.keepLast {
+		_ = os.Remove(filepath.Join(t.dir, t.files[0]))
+		t.files = t.files[1:]
+	}
+	return nil
+}
+
+// replayTransport serves recorded responses without contacting ONTAP. Repeated
+// identical requests, such as job polls, are answered with their recordings in
+// order, and the last recording is repeated once they run out.
+type replayTransport struct {
+	mu     sync.Mutex
+	byKey  map[string][]*recording
+	cursor map[string]int
+}
+
+func newReplayTransport(dir string) (*replayTransport, error) {
+	files, err := recordingFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	t := &replayTransport{byKey: make(map[string][]*recording), cursor: make(map[string]int)}
+	for _, name := range files {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read recording %s: %w", name, err)
+		}
+		var rec recording
+		if err := json.Unmarshal(data, &rec); err != nil {
+			return nil, fmt.Errorf("failed to parse recording %s: %w", name, err)
+		}
+		k := rec.key()
+		t.byKey[k] = append(t.byKey[k], &rec)
+	}
+	if len(t.byKey) == 0 {
+		return nil, fmt.Errorf("recorder directory %s contains no recordings to replay", dir)
+	}
+	return t, nil
+}
+
+func (t *replayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, err := readAndRestoreRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	if req.Body != nil {
+		_ = req.Body.Close()
+	}
+
+	k := recordingKey(req.Method, req.URL.Path, req.URL.Query().Encode(), redactBody(reqBody))
+
+	t.mu.Lock()
+	recs := t.byKey[k]
+	i := t.cursor[k]
+	if i < len(recs)-1 {
+		t.cursor[k] = i + 1
+	}
+	t.mu.Unlock()
+
+	if len(recs) == 0 {
+		return nil, fmt.Errorf("recorder: no recorded response for %s %s", req.Method, req.URL.RequestURI())
+	}
+	rec := recs[i]
+
+	body := []byte(rec.ResponseBody)
+	header := make(http.Header, len(rec.ResponseHeaders))
+	for name, v := range rec.ResponseHeaders {
+		header.Set(name, v)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", rec.Status, http.StatusText(rec.Status)),
+		StatusCode:    rec.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+func readAndRestoreRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	body, err := io.ReadAll(req.Body)
+	_ = req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+func flattenHeaders(h http.Header) map[string]string {
+	out := make(map[string]string, len(h))
+	for k := range h {
+		if isSensitiveHeader(k) {
+			continue
+		}
+		out[k] = h.Get(k)
+	}
+	return out
+}
+
+func isSensitiveHeader(name string) bool {
+	for _, s := range sensitiveHeaders {
+		if strings.EqualFold(name, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactBody masks credential values in a JSON body. Non-JSON bodies are
+// returned unchanged.
+func redactBody(body []byte) json.RawMessage {
+	if len(body) == 0 {
+		return nil
+	}
+	var v any
+	if err := json.Unmarshal(body, &v); err != nil {
+		return body
+	}
+	out, err := json.Marshal(Redact(v))
+	if err != nil {
+		return body
+	}
+	return out
+}
+
+func recordingFiles(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read recorder directory %s: %w", dir, err)
+	}
+	var files []string
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".json") || seqFromFile(e.Name()) == 0 {
+			continue
+		}
+		files = append(files, e.Name())
+	}
+	sort.Slice(files, func(i, j int) bool { return seqFromFile(files[i]) < seqFromFile(files[j]) })
+	return files, nil
+}
+
+func seqFromFile(name string) int {
+	prefix, _, _ := strings.Cut(name, "_")
+	n, _ := strconv.Atoi(prefix)
+	return n
+}
+
+func sanitizePath(p string) string {
+	p = strings.Trim(p, "/")
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '.':
+			return r
+		default:
+			return '_'
+		}
+	}, p)
+}
@@ -0,0 +1,225 @@
+package rest
+
+import (
+	"bytes"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/netapp/ontap-mcp/config"
+)
+
+func TestRecorder_RecordThenReplay(t *testing.T) {
+	var jobPolls atomic.Int32
+	_, poller := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/cluster":
+			_, _ = w.Write([]byte(clusterJSON))
+		case "/api/security/accounts":
+			_, _ = w.Write([]byte(`{"records":[{"name":"admin","password":"leaked","token":"t0k"}]}`))
+		case "/api/cluster/jobs/j1":
+			if jobPolls.Add(1) == 1 {
+				_, _ = w.Write([]byte(`{"uuid":"j1","state":"running"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"uuid":"j1","state":"success"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	dir := t.TempDir()
+	insecure := true
+	poller.UseInsecureTLS = &insecure
+	poller.Recorder = config.Recorder{Path: dir, Mode: "record"}
+
+	rec := New(poller)
+	rec.jobPollInterval = 10 * time.Millisecond
+	if _, err := rec.GetClusterInfo(t.Context()); err != nil {
+		t.Fatalf("GetClusterInfo: %v", err)
+	}
+	builder := rec.baseRequestBuilder("/api/security/accounts", nil, nil).
+		Patch().
+		BodyJSON(map[string]string{"name": "admin", "password": "new-secret"})
+	if err := rec.buildAndExecuteRequest(t.Context(), builder); err != nil {
+		t.Fatalf("PATCH: %v", err)
+	}
+	if err := rec.waitForJob(t.Context(), "/api/cluster/jobs/j1", time.Second); err != nil {
+		t.Fatalf("waitForJob: %v", err)
+	}
+
+	files, err := os.ReadDir(filepath.Join(dir, poller.Name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) == 0 {
+		t.Fatal("expected recordings to be written")
+	}
+	for _, f := range files {
+		data, err := os.ReadFile(filepath.Join(dir, poller.Name, f.Name()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, secret := range []string{"new-secret", "leaked", "t0k", "Authorization", poller.Password} {
+			if bytes.Contains(data, []byte(secret)) {
+				t.Fatalf("recording %s contains sensitive value %q", f.Name(), secret)
+			}
+		}
+	}
+
+	// Replay against an address with no cluster behind it.
+	replayPoller := &config.Poller{
+		Name:     poller.Name,
+		Addr:     "127.0.0.1:1",
+		Recorder: config.Recorder{Path: dir, Mode: "replay"},
+		CredentialsScript: config.CredentialsScript{
+			Path: "/does/not/exist",
+		},
+	}
+	replay := New(replayPoller)
+	replay.jobPollInterval = 10 * time.Millisecond
+
+	remote, err := replay.GetClusterInfo(t.Context())
+	if err != nil {
+		t.Fatalf("replayed GetClusterInfo: %v", err)
+	}
+	if remote.Name != "cluster1" {
+		t.Fatalf("unexpected replayed remote %+v", remote)
+	}
+	builder = replay.baseRequestBuilder("/api/security/accounts", nil, nil).
+		Patch().
+		BodyJSON(map[string]string{"name": "admin", "password": "different-secret"})
+	if err := replay.buildAndExecuteRequest(t.Context(), builder); err != nil {
+		t.Fatalf("replayed PATCH: %v", err)
+	}
+	if err := replay.waitForJob(t.Context(), "/api/cluster/jobs/j1", time.Second); err != nil {
+		t.Fatalf("replayed waitForJob: %v", err)
+	}
+
+	_, err = replay.GenericGet(t.Context(), "/storage/volumes", nil, 0)
+	if err == nil || !strings.Contains(err.Error(), "no recorded response") {
+		t.Fatalf("expected missing recording error, got %v", err)
+	}
+}
+
+func TestRecorder_NonJSONResponseIsNotStored(t *testing.T) {
+	ts, poller := newTestServer(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = w.Write([]byte("password=hunter2"))
+	})
+	dir := t.TempDir()
+	poller.Recorder = config.Recorder{Path: dir, Mode: "record"}
+	transport, err := newRecorder(poller, ts.Client().Transport)
+	if err != nil {
+		t.Fatalf("newRecorder: %v", err)
+	}
+	resp, err := (&http.Client{Transport: transport}).Get(ts.URL + "/api/cluster")
+	if err != nil {
+		t.Fatalf("GET: %v", err)
+	}
+	_ = resp.Body.Close()
+
+	files, err := os.ReadDir(filepath.Join(dir, poller.Name))
+	if err != nil || len(files) != 1 {
+		t.Fatalf("expected one recording, got %v, %v", files, err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, poller.Name, files[0].Name()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(data, []byte("hunter2")) {
+		t.Fatalf("recording contains the non-JSON body: %s", data)
+	}
+	if !bytes.Contains(data, []byte(`"response_length": 16`)) || !bytes.Contains(data, []byte("text/plain")) {
+		t.Fatalf("expected the body length and content type to be recorded: %s", data)
+	}
+}
+
+type idleCloser struct {
+	http.RoundTripper
+	closed atomic.Int32
+}
+
+func (c *idleCloser) CloseIdleConnections() { c.closed.Add(1) }
+
+func TestRecorder_ForwardsCloseIdleConnections(t *testing.T) {
+	next := &idleCloser{RoundTripper: http.DefaultTransport}
+	transport, err := newRecorder(&config.Poller{Name: "c1", Recorder: config.Recorder{Path: t.TempDir(), Mode: "record"}}, next)
+	if err != nil {
+		t.Fatalf("newRecorder: %v", err)
+	}
+	(&http.Client{Transport: transport}).CloseIdleConnections()
+	if next.closed.Load() != 1 {
+		t.Fatal("expected CloseIdleConnections to reach the wrapped transport")
+	}
+}
+
+func TestRecorder_KeepLast(t *testing.T) {
+	_, poller := newTestServer(t, func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(clusterJSON))
+	})
+
+	dir := t.TempDir()
+	insecure := true
+	poller.UseInsecureTLS = &insecure
+	poller.Recorder = config.Recorder{Path: dir, Mode: "record", KeepLast: "2"}
+
+	c := New(poller)
+	for range 4 {
+		if _, err := c.GetClusterInfo(t.Context()); err != nil {
+			t.Fatalf("GetClusterInfo: %v", err)
+		}
+	}
+
+	files, err := recordingFiles(filepath.Join(dir, poller.Name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 2 {
+		t.Fatalf("expected 2 recordings to be kept, got %d: %v", len(files), files)
+	}
+	if seqFromFile(files[0]) < 3 {
+		t.Fatalf("expected the oldest recordings to be pruned, got %v", files)
+	}
+}
+
+func TestNewRecorder_Errors(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		name        string
+		recorder    config.Recorder
+		errContains string
+	}{
+		{name: "missing path", recorder: config.Recorder{Mode: "record"}, errContains: "recorder.path is required"},
+		{name: "bad mode", recorder: config.Recorder{Path: dir, Mode: "rewind"}, errContains: "invalid recorder.mode"},
+		{name: "bad keep_last", recorder: config.Recorder{Path: dir, Mode: "record", KeepLast: "many"}, errContains: "invalid recorder.keep_last"},
+		{name: "replay without recordings", recorder: config.Recorder{Path: dir, Mode: "replay"}, errContains: "failed to read recorder directory"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newRecorder(&config.Poller{Name: "c1", Recorder: tt.recorder}, http.DefaultTransport)
+			if err == nil || !strings.Contains(err.Error(), tt.errContains) {
+				t.Fatalf("expected error containing %q, got %v", tt.errContains, err)
+			}
+		})
+	}
+}
+
+func TestRedactBody(t *testing.T) {
+	got := string(redactBody([]byte(`{"name":"svm1","ad_domain":{"password":"p","user":"u"},"records":[{"auth.token":"x","passphrase":"y"}]}`)))
+	for _, secret := range []string{`"p"`, `"x"`, `"y"`} {
+		if strings.Contains(got, secret) {
+			t.Fatalf("redacted body %s still contains %s", got, secret)
+		}
+	}
+	if !strings.Contains(got, `"user":"u"`) || !strings.Contains(got, `"name":"svm1"`) {
+		t.Fatalf("redaction removed non-sensitive fields: %s", got)
+	}
+	if string(redactBody([]byte("not json"))) != "not json" {
+		t.Fatal("non-JSON bodies must be returned unchanged")
+	}
+}
@@ -0,0 +1,48 @@
+package rest
+
+import "strings"
+
+// RedactedValue replaces the value of a sensitive key.
+const RedactedValue = "*****"
+
+// sensitiveKeys are JSON object keys whose values must never be shown to a
+// user, written to a log or recorded. Matching is case-insensitive and also
+// applies to keys ending in _<name> or .<name>, e.g. ad_password.
+var sensitiveKeys = []string{"password", "passphrase", "secret", "token", "private_key", "authtoken"}
+
+// IsSensitiveKey reports whether the value of the JSON object key k must be
+// redacted.
+func IsSensitiveKey(k string) bool {
+	lower := strings.ToLower(k)
+	for _, s := range sensitiveKeys {
+		if lower == s || strings.HasSuffix(lower, "_"+s) || strings.HasSuffix(lower, "."+s) {
+			return true
+		}
+	}
+	return false
+}
+
+// Redact returns a copy of the decoded JSON value v with the values of
+// sensitive keys replaced by RedactedValue, in nested objects and arrays too.
+func Redact(v any) any {
+	switch val := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(val))
+		for k, child := range val {
+			if IsSensitiveKey(k) {
+				out[k] = RedactedValue
+				continue
+			}
+			out[k] = Redact(child)
+		}
+		return out
+	case []any:
+		out := make([]any, len(val))
+		for i, item := range val {
+			out[i] = Redact(item)
+		}
+		return out
+	default:
+		return v
+	}
+}
@@ -0,0 +1,29 @@
+package rest
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRedact(t *testing.T) {
+	in := map[string]any{
+		"name":      "svm1",
+		"password":  "p",
+		"AuthToken": "a",
+		"ad_domain": map[string]any{"ad_password": "q", "user": "u"},
+		"records":   []any{map[string]any{"auth.token": "x", "passphrase": "y"}, "plain"},
+	}
+	want := map[string]any{
+		"name":      "svm1",
+		"password":  RedactedValue,
+		"AuthToken": RedactedValue,
+		"ad_domain": map[string]any{"ad_password": RedactedValue, "user": "u"},
+		"records":   []any{map[string]any{"auth.token": RedactedValue, "passphrase": RedactedValue}, "plain"},
+	}
+	if got := Redact(in); !reflect.DeepEqual(got, want) {
+		t.Fatalf("Redact() = %v, want %v", got, want)
+	}
+	if in["password"] != "p" || in["records"].([]any)[0].(map[string]any)["passphrase"] != "y" {
+		t.Fatalf("Redact modified its input: %v", in)
+	}
+}
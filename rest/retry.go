@@ -0,0 +1,152 @@
+package rest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/carlmjohnson/requests"
+	"github.com/netapp/ontap-mcp/config"
+	"github.com/netapp/ontap-mcp/metrics"
+	"github.com/netapp/ontap-mcp/ontap"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// executeWithRetry sends the request and, for GETs, sends it again after a
+// transient failure according to the poller's retry policy. Only GETs are
+// retried: they are idempotent, and they include the polls of waitForJob.
+// Retrying a POST, PATCH or DELETE could apply a change twice.
+func (c *Client) executeWithRetry(ctx context.Context, span trace.Span, builder *requests.Builder) error {
+	policy, err := c.poller.RetryPolicy()
+	if err != nil {
+		return err
+	}
+	if policy.MaxAttempts > 1 {
+		req, err := builder.Request(ctx)
+		if err != nil {
+			return err
+		}
+		if req.Method != http.MethodGet {
+			policy.MaxAttempts = 1
+		}
+	}
+	if policy.MaxAttempts > 1 {
+		builder = builder.Clone().AddValidator(readWholeBody)
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := c.executeRequest(ctx, builder)
+		if err == nil || attempt >= policy.MaxAttempts || !isRetryable(err, policy) {
+			return err
+		}
+		wait, ok := retryWait(policy, attempt, err)
+		if !ok || !fitsDeadline(ctx, wait) {
+			return err
+		}
+
+		metrics.ONTAPRetries.WithLabelValues(c.poller.Name).Inc()
+		span.AddEvent("retry", trace.WithAttributes(
+			attribute.Int("ontap.retry.attempt", attempt),
+			attribute.String("ontap.retry.wait", wait.String()),
+			attribute.String("error.message", err.Error())))
+		slog.Debug("retrying ONTAP request after transient failure",
+			slog.String("cluster", c.poller.Name),
+			slog.Int("attempt", attempt),
+			slog.Duration("wait", wait),
+			slog.Any("error", err))
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+// readWholeBody reads the response body before the handler runs, so a
+// connection that drops mid-body fails the attempt before the handler has
+// written a partial body to its destination, e.g. the buffer of ToBytesBuffer,
+// which the next attempt would append to.
+func readWholeBody(response *http.Response) error {
+	data, err := io.ReadAll(response.Body)
+	_ = response.Body.Close()
+	response.Body = io.NopCloser(bytes.NewReader(data))
+	return err
+}
+
+// isRetryable reports whether err is a transient failure: a connection reset
+// or refused, e.g. during a LIF migration, a 429, 502, 503 or 504 response,
+// e.g. during a node takeover, or one of the ONTAP error codes configured in
+// retry.error_codes. Timeouts are not retried; the request already took
+// client_timeout.
+func isRetryable(err error, policy config.RetryPolicy) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var ontapErr ontap.ClusterError
+	if errors.As(err, &ontapErr) {
+		switch ontapErr.StatusCode {
+		case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
+		}
+		return slices.Contains(policy.ErrorCodes, ontapErr.Err.Code)
+	}
+	return errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.EPIPE) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, io.EOF)
+}
+
+// retryWait is how long to wait before retry number attempt. It honors a
+// Retry-After header, but gives up rather than wait longer than max_backoff.
+// Otherwise it backs off exponentially from initial_backoff with equal
+// jitter, so that clients retrying the same cluster spread out.
+func retryWait(policy config.RetryPolicy, attempt int, err error) (time.Duration, bool) {
+	var ontapErr ontap.ClusterError
+	if errors.As(err, &ontapErr) && ontapErr.RetryAfter > 0 {
+		return ontapErr.RetryAfter, ontapErr.RetryAfter <= policy.MaxBackoff
+	}
+	backoff := policy.InitialBackoff
+	for range attempt - 1 {
+		backoff *= 2
+		if backoff >= policy.MaxBackoff {
+			break
+		}
+	}
+	backoff = min(backoff, policy.MaxBackoff)
+	half := backoff / 2
+	return half + rand.N(backoff-half+1), true //nolint:gosec // jitter does not need a secure random source
+}
+
+// fitsDeadline reports whether ctx leaves time to wait and try again.
+func fitsDeadline(ctx context.Context, wait time.Duration) bool {
+	deadline, ok := ctx.Deadline()
+	return !ok || time.Until(deadline) > wait
+}
+
+// parseRetryAfter parses a Retry-After header given either in seconds or as an
+// HTTP date. It returns 0 when the header is missing or invalid.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(value); err == nil {
+		return max(time.Duration(secs)*time.Second, 0)
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		return max(t.Sub(now), 0)
+	}
+	return 0
+}
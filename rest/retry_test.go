@@ -0,0 +1,189 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/netapp/ontap-mcp/config"
+	"github.com/netapp/ontap-mcp/metrics"
+	"github.com/netapp/ontap-mcp/ontap"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{"", 0},
+		{"3", 3 * time.Second},
+		{"-1", 0},
+		{"Wed, 01 Jan 2025 12:00:05 GMT", 5 * time.Second},
+		{"Wed, 01 Jan 2025 11:59:00 GMT", 0},
+		{"soon", 0},
+	}
+	for _, tt := range tests {
+		if got := parseRetryAfter(tt.value, now); got != tt.want {
+			t.Errorf("parseRetryAfter(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	policy := config.RetryPolicy{ErrorCodes: []string{"2"}}
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"service unavailable", ontap.ClusterError{StatusCode: http.StatusServiceUnavailable}, true},
+		{"too many requests", ontap.ClusterError{StatusCode: http.StatusTooManyRequests}, true},
+		{"busy message without a configured code", ontap.ClusterError{StatusCode: http.StatusBadRequest, Err: ontap.OErr{Message: "The API is busy. Try again later.", Code: "3"}}, false},
+		{"configured code", fmt.Errorf("wrapped: %w", ontap.ClusterError{StatusCode: http.StatusInternalServerError, Err: ontap.OErr{Code: "2"}}), true},
+		{"not found", ontap.ClusterError{StatusCode: http.StatusNotFound, Err: ontap.OErr{Code: "4"}}, false},
+		{"connection reset", &url.Error{Op: "Get", Err: syscall.ECONNRESET}, true},
+		{"unexpected EOF", &url.Error{Op: "Get", Err: io.ErrUnexpectedEOF}, true},
+		{"other error", errors.New("boom"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isRetryable(tt.err, policy); got != tt.want {
+				t.Errorf("isRetryable() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryWait(t *testing.T) {
+	policy := config.RetryPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+	tests := []struct {
+		attempt  int
+		min, max time.Duration
+	}{
+		{1, 50 * time.Millisecond, 100 * time.Millisecond},
+		{2, 100 * time.Millisecond, 200 * time.Millisecond},
+		{4, 400 * time.Millisecond, 800 * time.Millisecond},
+		{10, 500 * time.Millisecond, time.Second},
+	}
+	for _, tt := range tests {
+		for range 20 {
+			got, ok := retryWait(policy, tt.attempt, errors.New("reset"))
+			if !ok || got < tt.min || got > tt.max {
+				t.Fatalf("retryWait(attempt %d) = %v, %v, want between %v and %v", tt.attempt, got, ok, tt.min, tt.max)
+			}
+		}
+	}
+
+	if got, ok := retryWait(policy, 1, ontap.ClusterError{RetryAfter: 300 * time.Millisecond}); !ok || got != 300*time.Millisecond {
+		t.Errorf("expected Retry-After to be honored, got %v, %v", got, ok)
+	}
+	if _, ok := retryWait(policy, 1, ontap.ClusterError{RetryAfter: time.Minute}); ok {
+		t.Error("expected a Retry-After beyond max_backoff to give up")
+	}
+}
+
+func TestClient_RetriesTransientGET(t *testing.T) {
+	var calls atomic.Int32
+	ts, poller := newTestServer(t, func(w http.ResponseWriter, _ *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(`<html>node takeover in progress</html>`))
+			return
+		}
+		_, _ = w.Write([]byte(clusterJSON))
+	})
+	poller.Name = "retry-get"
+	poller.Retry = config.Retry{InitialBackoff: "1ms", MaxBackoff: "10ms"}
+
+	c := NewWithClient(poller, ts.Client())
+	if _, err := c.GetClusterInfo(t.Context()); err != nil {
+		t.Fatalf("GetClusterInfo: %v", err)
+	}
+	if got := calls.Load(); got != 3 {
+		t.Fatalf("expected 3 attempts, got %d", got)
+	}
+	if got := testutil.ToFloat64(metrics.ONTAPRetries.WithLabelValues("retry-get")); got != 2 {
+		t.Fatalf("expected 2 retries to be counted, got %v", got)
+	}
+}
+
+func TestClient_RetryTruncatedBody(t *testing.T) {
+	const body = `{"records":[{"name":"vol1"},{"name":"vol2"}],"num_records":2}`
+	var calls atomic.Int32
+	ts, poller := newTestServer(t, func(w http.ResponseWriter, _ *http.Request) {
+		if calls.Add(1) == 1 {
+			// Promise the whole body, send half of it and drop the connection.
+			w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+			_, _ = w.Write([]byte(body[:len(body)/2]))
+			w.(http.Flusher).Flush()
+			panic(http.ErrAbortHandler)
+		}
+		_, _ = w.Write([]byte(body))
+	})
+	poller.Retry = config.Retry{InitialBackoff: "1ms", MaxBackoff: "10ms"}
+
+	c := NewWithClient(poller, ts.Client())
+	raw, err := c.GenericGet(t.Context(), "/storage/volumes", nil, 0)
+	if err != nil {
+		t.Fatalf("GenericGet: %v", err)
+	}
+	if got := calls.Load(); got != 2 {
+		t.Fatalf("expected 2 attempts, got %d", got)
+	}
+	var resp struct {
+		NumRecords int `json:"num_records"`
+	}
+	if err := json.Unmarshal(raw, &resp); err != nil || resp.NumRecords != 2 {
+		t.Fatalf("expected the retried body alone, got %s (%v)", raw, err)
+	}
+}
+
+func TestClient_RetryLimits(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		retry  config.Retry
+		header string
+		want   int32
+	}{
+		{name: "gives up after max_attempts", method: http.MethodGet, retry: config.Retry{MaxAttempts: 2, InitialBackoff: "1ms"}, want: 2},
+		{name: "max_attempts 1 disables retries", method: http.MethodGet, retry: config.Retry{MaxAttempts: 1}, want: 1},
+		{name: "writes are not retried", method: http.MethodPost, retry: config.Retry{InitialBackoff: "1ms"}, want: 1},
+		{name: "Retry-After beyond max_backoff", method: http.MethodGet, retry: config.Retry{InitialBackoff: "1ms", MaxBackoff: "1s"}, header: "120", want: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			ts, poller := newTestServer(t, func(w http.ResponseWriter, _ *http.Request) {
+				calls.Add(1)
+				if tt.header != "" {
+					w.Header().Set("Retry-After", tt.header)
+				}
+				w.WriteHeader(http.StatusServiceUnavailable)
+				_, _ = w.Write([]byte(`{"error":{"message":"The API is busy","code":"1"}}`))
+			})
+			poller.Retry = tt.retry
+
+			c := NewWithClient(poller, ts.Client())
+			builder := c.baseRequestBuilder(`/api/cluster`, nil, nil).Method(tt.method)
+			err := c.buildAndExecuteRequest(t.Context(), builder)
+			var ontapErr ontap.ClusterError
+			if !errors.As(err, &ontapErr) || ontapErr.StatusCode != http.StatusServiceUnavailable {
+				t.Fatalf("expected the 503 to be returned, got %v", err)
+			}
+			if got := calls.Load(); got != tt.want {
+				t.Fatalf("expected %d attempts, got %d", tt.want, got)
+			}
+		})
+	}
+}
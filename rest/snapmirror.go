@@ -41,7 +41,7 @@ func (c *Client) CreateSnapMirror(ctx context.Context, rel ontap.SnapMirrorRelat
 		statusCode int
 	)
 
-	builder := c.baseRequestBuilder(`/api/snapmirror/relationships`, &statusCode, nil).
+	builder := c.createRequestBuilder(ctx, `/api/snapmirror/relationships`, &statusCode, nil).
 		BodyJSON(rel).
 		ToBytesBuffer(&buf)
 
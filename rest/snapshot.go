@@ -72,7 +72,7 @@ func (c *Client) CreateSnapshot(ctx context.Context, snapshot ontap.Snapshot, vo
 		return err
 	}
 
-	builder := c.baseRequestBuilder(`/api/storage/volumes/`+volumeUUID+`/snapshots`, &statusCode, responseHeaders).
+	builder := c.createRequestBuilder(ctx, `/api/storage/volumes/`+volumeUUID+`/snapshots`, &statusCode, responseHeaders).
 		BodyJSON(snapshot).
 		ToBytesBuffer(&buf)
 
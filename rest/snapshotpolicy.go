@@ -46,7 +46,7 @@ func (c *Client) CreateSnapshotPolicy(ctx context.Context, snapshotPolicy ontap.
 			snapshotPolicy.Name, snapshotPolicy.SVM.Name, scheduleName, oc.NumRecords)
 	}
 
-	builder2 := c.baseRequestBuilder(`/api/storage/snapshot-policies`, &statusCode, responseHeaders).
+	builder2 := c.createRequestBuilder(ctx, `/api/storage/snapshot-policies`, &statusCode, responseHeaders).
 		BodyJSON(snapshotPolicy)
 
 	if err := c.buildAndExecuteRequest(ctx, builder2); err != nil {
@@ -100,7 +100,7 @@ func (c *Client) DeleteSnapshotPolicy(ctx context.Context, snapshotPolicy ontap.
 func (c *Client) CreateSchedule(ctx context.Context, schedule ontap.Schedule) error {
 	var statusCode int
 
-	builder := c.baseRequestBuilder(`/api/cluster/schedules`, &statusCode, nil).
+	builder := c.createRequestBuilder(ctx, `/api/cluster/schedules`, &statusCode, nil).
 		BodyJSON(schedule)
 
 	if err := c.buildAndExecuteRequest(ctx, builder); err != nil {
@@ -16,7 +16,7 @@ func (c *Client) CreateSVM(ctx context.Context, svm ontap.SVMCreate) error {
 	)
 	responseHeaders := http.Header{}
 
-	builder := c.baseRequestBuilder(`/api/svm/svms`, &statusCode, responseHeaders).
+	builder := c.createRequestBuilder(ctx, `/api/svm/svms`, &statusCode, responseHeaders).
 		BodyJSON(svm).
 		ToBytesBuffer(&buf)
 
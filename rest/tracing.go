@@ -0,0 +1,53 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/netapp/ontap-mcp/config"
+	"github.com/netapp/ontap-mcp/metrics"
+	"github.com/netapp/ontap-mcp/tracing"
+	semconv "go.opentelemetry.io/otel/semconv/v1.43.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = tracing.Tracer("github.com/netapp/ontap-mcp/rest")
+
+// instrument wraps the transport of a cluster's HTTP client with metrics and
+// tracing.
+func instrument(p *config.Poller, next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return metrics.Transport(p.Name, tracingTransport{next: next})
+}
+
+// tracingTransport adds the request and response to the span started by
+// buildAndExecuteRequest, which only sees the request builder.
+type tracingTransport struct {
+	next http.RoundTripper
+}
+
+func (t tracingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	span := trace.SpanFromContext(req.Context())
+	if !span.IsRecording() {
+		return t.next.RoundTrip(req)
+	}
+
+	span.SetName(req.Method + " " + req.URL.Path)
+	span.SetAttributes(
+		semconv.HTTPRequestMethodKey.String(req.Method),
+		semconv.URLFull(req.URL.String()),
+		semconv.ServerAddress(req.URL.Hostname()),
+	)
+	resp, err := t.next.RoundTrip(req)
+	if err == nil {
+		span.SetAttributes(semconv.HTTPResponseStatusCode(resp.StatusCode))
+	}
+	return resp, err
+}
+
+func (t tracingTransport) CloseIdleConnections() {
+	if c, ok := t.next.(interface{ CloseIdleConnections() }); ok {
+		c.CloseIdleConnections()
+	}
+}
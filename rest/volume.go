@@ -48,7 +48,7 @@ func (c *Client) CreateVolume(ctx context.Context, volume ontap.Volume) error {
 		}
 	}
 
-	builder := c.baseRequestBuilder(`/api/storage/volumes`, &statusCode, responseHeaders).
+	builder := c.createRequestBuilder(ctx, `/api/storage/volumes`, &statusCode, responseHeaders).
 		BodyJSON(volume).
 		ToBytesBuffer(&buf)
 
@@ -155,7 +155,7 @@ func (c *Client) CreateExportPolicy(ctx context.Context, volume ontap.Volume) er
 		Svm:  volume.SVM,
 	}
 
-	builder := c.baseRequestBuilder(`/api/protocols/nfs/export-policies`, &statusCode, nil).
+	builder := c.createRequestBuilder(ctx, `/api/protocols/nfs/export-policies`, &statusCode, nil).
 		BodyJSON(newExportPolicy)
 
 	if err := c.buildAndExecuteRequest(ctx, builder); err != nil {
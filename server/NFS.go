@@ -10,25 +10,20 @@ import (
 	"strings"
 )
 
-func (a *App) CreateNFSExportPolicy(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.NFSExportPolicyCreate) (*mcp.CallToolResult, any, error) {
-	if !a.locks.TryLock(parameters.Cluster) {
-		return errorResult(fmt.Errorf("another write operation is in progress on cluster %s, please try again", parameters.Cluster)), nil, nil
-	}
-	defer a.locks.Unlock(parameters.Cluster)
-
+func (a *App) CreateNFSExportPolicy(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.NFSExportPolicyCreate) (*mcp.CallToolResult, WriteResponse, error) {
 	nfsExportPolicyCreate, err := newCreateNFSExportPolicy(parameters)
 	if err != nil {
-		return nil, nil, err
+		return nil, WriteResponse{}, err
 	}
 
 	client, err := a.getClient(parameters.Cluster)
 	if err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), WriteResponse{}, err
 	}
 	err = client.CreateNFSExportPolicy(ctx, nfsExportPolicyCreate)
 
 	if err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), WriteResponse{}, err
 	}
 
 	responseText := "NFS Export Policy created successfully"
@@ -37,28 +32,23 @@ func (a *App) CreateNFSExportPolicy(ctx context.Context, _ *mcp.CallToolRequest,
 		Content: []mcp.Content{
 			&mcp.TextContent{Text: responseText},
 		},
-	}, nil, nil
+	}, WriteResponse{}, nil
 }
 
-func (a *App) UpdateNFSExportPolicy(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.NFSExportPolicy) (*mcp.CallToolResult, any, error) {
-	if !a.locks.TryLock(parameters.Cluster) {
-		return errorResult(fmt.Errorf("another write operation is in progress on cluster %s, please try again", parameters.Cluster)), nil, nil
-	}
-	defer a.locks.Unlock(parameters.Cluster)
-
+func (a *App) UpdateNFSExportPolicy(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.NFSExportPolicy) (*mcp.CallToolResult, WriteResponse, error) {
 	nfsExportPolicyUpdate, err := newUpdateNFSExportPolicy(parameters)
 	if err != nil {
-		return nil, nil, err
+		return nil, WriteResponse{}, err
 	}
 
 	client, err := a.getClient(parameters.Cluster)
 	if err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), WriteResponse{}, err
 	}
 	err = client.UpdateNFSExportPolicy(ctx, parameters.ExportPolicy, nfsExportPolicyUpdate)
 
 	if err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), WriteResponse{}, err
 	}
 
 	responseText := "NFS Export Policy updated successfully"
@@ -67,7 +57,7 @@ func (a *App) UpdateNFSExportPolicy(ctx context.Context, _ *mcp.CallToolRequest,
 		Content: []mcp.Content{
 			&mcp.TextContent{Text: responseText},
 		},
-	}, nil, nil
+	}, WriteResponse{}, nil
 }
 
 // newUpdateNFSExportPolicy validates the customer provided arguments and converts them into
@@ -113,25 +103,20 @@ func newUpdateNFSExportPolicy(in tool.NFSExportPolicy) (ontap.ExportPolicy, erro
 	return out, nil
 }
 
-func (a *App) DeleteNFSExportPolicy(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.NFSExportPolicy) (*mcp.CallToolResult, any, error) {
-	if !a.locks.TryLock(parameters.Cluster) {
-		return errorResult(fmt.Errorf("another write operation is in progress on cluster %s, please try again", parameters.Cluster)), nil, nil
-	}
-	defer a.locks.Unlock(parameters.Cluster)
-
+func (a *App) DeleteNFSExportPolicy(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.NFSExportPolicy) (*mcp.CallToolResult, WriteResponse, error) {
 	nfsExportPolicyDelete, err := newDeleteNFSExportPolicy(parameters)
 	if err != nil {
-		return nil, nil, err
+		return nil, WriteResponse{}, err
 	}
 
 	client, err := a.getClient(parameters.Cluster)
 	if err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), WriteResponse{}, err
 	}
 	err = client.DeleteNFSExportPolicy(ctx, nfsExportPolicyDelete)
 
 	if err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), WriteResponse{}, err
 	}
 
 	responseText := "NFS Export Policy deleted successfully"
@@ -140,41 +125,36 @@ func (a *App) DeleteNFSExportPolicy(ctx context.Context, _ *mcp.CallToolRequest,
 		Content: []mcp.Content{
 			&mcp.TextContent{Text: responseText},
 		},
-	}, nil, nil
+	}, WriteResponse{}, nil
 }
 
-func (a *App) ModifyNFSExportPolicy(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.NFSExportPolicyModify) (*mcp.CallToolResult, any, error) {
-	if !a.locks.TryLock(parameters.Cluster) {
-		return errorResult(fmt.Errorf("another write operation is in progress on cluster %s, please try again", parameters.Cluster)), nil, nil
-	}
-	defer a.locks.Unlock(parameters.Cluster)
-
+func (a *App) ModifyNFSExportPolicy(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.NFSExportPolicyModify) (*mcp.CallToolResult, WriteResponse, error) {
 	if parameters.ExportPolicy == "" {
-		return nil, nil, errors.New("export policy name is required")
+		return nil, WriteResponse{}, errors.New("export policy name is required")
 	}
 
 	client, err := a.getClient(parameters.Cluster)
 	if err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), WriteResponse{}, err
 	}
 
 	switch parameters.Operation {
 	case "update":
 		nfsExportPolicyUpdate, err := updateNFSExportPolicyValidation(parameters.NFSExportPolicyUpdate)
 		if err != nil {
-			return nil, nil, err
+			return nil, WriteResponse{}, err
 		}
 
 		err = client.UpdateNFSExportPolicy(ctx, parameters.ExportPolicy, nfsExportPolicyUpdate)
 		if err != nil {
-			return errorResult(err), nil, err
+			return errorResult(err), WriteResponse{}, err
 		}
 
 		return &mcp.CallToolResult{
 			Content: []mcp.Content{
 				&mcp.TextContent{Text: "NFS Export Policy updated successfully"},
 			},
-		}, nil, nil
+		}, WriteResponse{}, nil
 	case "delete":
 		nfsExportPolicyDelete := ontap.ExportPolicy{
 			Name: parameters.ExportPolicy,
@@ -182,16 +162,16 @@ func (a *App) ModifyNFSExportPolicy(ctx context.Context, _ *mcp.CallToolRequest,
 
 		err = client.DeleteNFSExportPolicy(ctx, nfsExportPolicyDelete)
 		if err != nil {
-			return errorResult(err), nil, err
+			return errorResult(err), WriteResponse{}, err
 		}
 
 		return &mcp.CallToolResult{
 			Content: []mcp.Content{
 				&mcp.TextContent{Text: "NFS Export Policy deleted successfully"},
 			},
-		}, nil, nil
+		}, WriteResponse{}, nil
 	default:
-		return errorResult(fmt.Errorf("unsupported operation %q; supported values: update, delete", parameters.Operation)), nil, nil
+		return errorResult(fmt.Errorf("unsupported operation %q; supported values: update, delete", parameters.Operation)), WriteResponse{}, nil
 	}
 }
 
@@ -282,25 +262,20 @@ func newCreateNFSExportPolicy(in tool.NFSExportPolicyCreate) (ontap.ExportPolicy
 	return out, nil
 }
 
-func (a *App) CreateNFSExportPoliciesRule(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.NFSExportPolicyRulesCreate) (*mcp.CallToolResult, any, error) {
-	if !a.locks.TryLock(parameters.Cluster) {
-		return errorResult(fmt.Errorf("another write operation is in progress on cluster %s, please try again", parameters.Cluster)), nil, nil
-	}
-	defer a.locks.Unlock(parameters.Cluster)
-
+func (a *App) CreateNFSExportPoliciesRule(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.NFSExportPolicyRulesCreate) (*mcp.CallToolResult, WriteResponse, error) {
 	nfsExportPolicyRulesCreate, err := newCreateNFSExportPolicyRules(parameters)
 	if err != nil {
-		return nil, nil, err
+		return nil, WriteResponse{}, err
 	}
 
 	client, err := a.getClient(parameters.Cluster)
 	if err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), WriteResponse{}, err
 	}
 	err = client.CreateNFSExportPolicyRules(ctx, parameters.ExportPolicy, nfsExportPolicyRulesCreate)
 
 	if err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), WriteResponse{}, err
 	}
 
 	responseText := "NFS Export Policy Rules created successfully"
@@ -309,7 +284,7 @@ func (a *App) CreateNFSExportPoliciesRule(ctx context.Context, _ *mcp.CallToolRe
 		Content: []mcp.Content{
 			&mcp.TextContent{Text: responseText},
 		},
-	}, nil, nil
+	}, WriteResponse{}, nil
 }
 
 // newCreateNFSExportPolicyRules validates the customer provided arguments and converts them into
@@ -337,25 +312,20 @@ func newCreateNFSExportPolicyRules(in tool.NFSExportPolicyRulesCreate) (ontap.Ru
 	return out, nil
 }
 
-func (a *App) UpdateNFSExportPoliciesRule(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.NFSExportPolicyRules) (*mcp.CallToolResult, any, error) {
-	if !a.locks.TryLock(parameters.Cluster) {
-		return errorResult(fmt.Errorf("another write operation is in progress on cluster %s, please try again", parameters.Cluster)), nil, nil
-	}
-	defer a.locks.Unlock(parameters.Cluster)
-
+func (a *App) UpdateNFSExportPoliciesRule(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.NFSExportPolicyRules) (*mcp.CallToolResult, WriteResponse, error) {
 	nfsExportPolicyRulesUpdate, err := newUpdateNFSExportPolicyRules(parameters)
 	if err != nil {
-		return nil, nil, err
+		return nil, WriteResponse{}, err
 	}
 
 	client, err := a.getClient(parameters.Cluster)
 	if err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), WriteResponse{}, err
 	}
 	err = client.UpdateNFSExportPolicyRules(ctx, parameters.ExportPolicy, parameters.OldClientMatch, parameters.OldROrule, parameters.OldRWrule, nfsExportPolicyRulesUpdate)
 
 	if err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), WriteResponse{}, err
 	}
 
 	responseText := "NFS Export Policy Rules updated successfully"
@@ -364,7 +334,7 @@ func (a *App) UpdateNFSExportPoliciesRule(ctx context.Context, _ *mcp.CallToolRe
 		Content: []mcp.Content{
 			&mcp.TextContent{Text: responseText},
 		},
-	}, nil, nil
+	}, WriteResponse{}, nil
 }
 
 // newUpdateNFSExportPolicyRules validates the customer provided arguments and converts them into
@@ -401,25 +371,20 @@ func newUpdateNFSExportPolicyRules(in tool.NFSExportPolicyRules) (ontap.Rule, er
 	return out, nil
 }
 
-func (a *App) DeleteNFSExportPoliciesRule(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.NFSExportPolicyRules) (*mcp.CallToolResult, any, error) {
-	if !a.locks.TryLock(parameters.Cluster) {
-		return errorResult(fmt.Errorf("another write operation is in progress on cluster %s, please try again", parameters.Cluster)), nil, nil
-	}
-	defer a.locks.Unlock(parameters.Cluster)
-
+func (a *App) DeleteNFSExportPoliciesRule(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.NFSExportPolicyRules) (*mcp.CallToolResult, WriteResponse, error) {
 	nfsExportPolicyRulesDelete, err := newDeleteNFSExportPolicyRules(parameters)
 	if err != nil {
-		return nil, nil, err
+		return nil, WriteResponse{}, err
 	}
 
 	client, err := a.getClient(parameters.Cluster)
 	if err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), WriteResponse{}, err
 	}
 	err = client.DeleteNFSExportPolicyRules(ctx, parameters.ExportPolicy, nfsExportPolicyRulesDelete)
 
 	if err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), WriteResponse{}, err
 	}
 
 	responseText := "NFS Export Policy Rules deleted successfully"
@@ -428,29 +393,24 @@ func (a *App) DeleteNFSExportPoliciesRule(ctx context.Context, _ *mcp.CallToolRe
 		Content: []mcp.Content{
 			&mcp.TextContent{Text: responseText},
 		},
-	}, nil, nil
+	}, WriteResponse{}, nil
 }
 
-func (a *App) ModifyNFSExportPoliciesRule(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.NFSExportPolicyRulesModify) (*mcp.CallToolResult, any, error) {
-	if !a.locks.TryLock(parameters.Cluster) {
-		return errorResult(fmt.Errorf("another write operation is in progress on cluster %s, please try again", parameters.Cluster)), nil, nil
-	}
-	defer a.locks.Unlock(parameters.Cluster)
-
+func (a *App) ModifyNFSExportPoliciesRule(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.NFSExportPolicyRulesModify) (*mcp.CallToolResult, WriteResponse, error) {
 	if parameters.ExportPolicy == "" {
-		return nil, nil, errors.New("export policy name is required")
+		return nil, WriteResponse{}, errors.New("export policy name is required")
 	}
 
 	client, err := a.getClient(parameters.Cluster)
 	if err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), WriteResponse{}, err
 	}
 
 	switch parameters.Operation {
 	case "update":
 		nfsExportPolicyRulesUpdate, err := updateNFSExportPoliciesRuleValidation(parameters.NFSExportPolicyRulesUpdate)
 		if err != nil {
-			return nil, nil, err
+			return nil, WriteResponse{}, err
 		}
 
 		oldClientMatch := parameters.NFSExportPolicyRulesUpdate.OldClientMatch
@@ -459,32 +419,32 @@ func (a *App) ModifyNFSExportPoliciesRule(ctx context.Context, _ *mcp.CallToolRe
 
 		err = client.UpdateNFSExportPolicyRules(ctx, parameters.ExportPolicy, oldClientMatch, oldROrule, oldRWrule, nfsExportPolicyRulesUpdate)
 		if err != nil {
-			return errorResult(err), nil, err
+			return errorResult(err), WriteResponse{}, err
 		}
 
 		return &mcp.CallToolResult{
 			Content: []mcp.Content{
 				&mcp.TextContent{Text: "NFS Export Policy Rule updated successfully"},
 			},
-		}, nil, nil
+		}, WriteResponse{}, nil
 	case "delete":
 		nfsExportPolicyRulesDelete, err := deleteNFSExportPoliciesRuleValidation(parameters.NFSExportPolicyRulesUpdate)
 		if err != nil {
-			return nil, nil, err
+			return nil, WriteResponse{}, err
 		}
 
 		err = client.DeleteNFSExportPolicyRules(ctx, parameters.ExportPolicy, nfsExportPolicyRulesDelete)
 		if err != nil {
-			return errorResult(err), nil, err
+			return errorResult(err), WriteResponse{}, err
 		}
 
 		return &mcp.CallToolResult{
 			Content: []mcp.Content{
 				&mcp.TextContent{Text: "NFS Export Policy Rule deleted successfully"},
 			},
-		}, nil, nil
+		}, WriteResponse{}, nil
 	default:
-		return errorResult(fmt.Errorf("unsupported operation %q; supported values: update, delete", parameters.Operation)), nil, nil
+		return errorResult(fmt.Errorf("unsupported operation %q; supported values: update, delete", parameters.Operation)), WriteResponse{}, nil
 	}
 }
 
@@ -26,25 +26,20 @@ func validateAdaptiveAllocationFields(expectedAlloc, peakAlloc, blockSize string
 	return nil
 }
 
-func (a *App) CreateQoSPolicy(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.QoSPolicy) (*mcp.CallToolResult, any, error) {
-	if !a.locks.TryLock(parameters.Cluster) {
-		return errorResult(fmt.Errorf("another write operation is in progress on cluster %s, please try again", parameters.Cluster)), nil, nil
-	}
-	defer a.locks.Unlock(parameters.Cluster)
-
+func (a *App) CreateQoSPolicy(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.QoSPolicy) (*mcp.CallToolResult, WriteResponse, error) {
 	qosPolicyCreate, err := newCreateQoSPolicy(parameters)
 	if err != nil {
-		return nil, nil, err
+		return nil, WriteResponse{}, err
 	}
 
 	client, err := a.getClient(parameters.Cluster)
 	if err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), WriteResponse{}, err
 	}
 	err = client.CreateQoSPolicy(ctx, qosPolicyCreate)
 
 	if err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), WriteResponse{}, err
 	}
 
 	responseText := "QoS Policy created successfully"
@@ -53,28 +48,23 @@ func (a *App) CreateQoSPolicy(ctx context.Context, _ *mcp.CallToolRequest, param
 		Content: []mcp.Content{
 			&mcp.TextContent{Text: responseText},
 		},
-	}, nil, nil
+	}, WriteResponse{}, nil
 }
 
-func (a *App) UpdateQosPolicy(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.QoSPolicy) (*mcp.CallToolResult, any, error) {
-	if !a.locks.TryLock(parameters.Cluster) {
-		return errorResult(fmt.Errorf("another write operation is in progress on cluster %s, please try again", parameters.Cluster)), nil, nil
-	}
-	defer a.locks.Unlock(parameters.Cluster)
-
+func (a *App) UpdateQosPolicy(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.QoSPolicy) (*mcp.CallToolResult, WriteResponse, error) {
 	qosPolicyUpdate, err := newUpdateQoSPolicy(parameters)
 	if err != nil {
-		return nil, nil, err
+		return nil, WriteResponse{}, err
 	}
 
 	client, err := a.getClient(parameters.Cluster)
 	if err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), WriteResponse{}, err
 	}
 	err = client.UpdateQoSPolicy(ctx, qosPolicyUpdate, parameters.Name, parameters.SVM)
 
 	if err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), WriteResponse{}, err
 	}
 
 	responseText := "QoS Policy updated successfully"
@@ -83,28 +73,23 @@ func (a *App) UpdateQosPolicy(ctx context.Context, _ *mcp.CallToolRequest, param
 		Content: []mcp.Content{
 			&mcp.TextContent{Text: responseText},
 		},
-	}, nil, nil
+	}, WriteResponse{}, nil
 }
 
-func (a *App) DeleteQoSPolicy(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.QoSPolicy) (*mcp.CallToolResult, any, error) {
-	if !a.locks.TryLock(parameters.Cluster) {
-		return errorResult(fmt.Errorf("another write operation is in progress on cluster %s, please try again", parameters.Cluster)), nil, nil
-	}
-	defer a.locks.Unlock(parameters.Cluster)
-
+func (a *App) DeleteQoSPolicy(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.QoSPolicy) (*mcp.CallToolResult, WriteResponse, error) {
 	qosPolicyDelete, err := newDeleteQoSPolicy(parameters)
 	if err != nil {
-		return nil, nil, err
+		return nil, WriteResponse{}, err
 	}
 
 	client, err := a.getClient(parameters.Cluster)
 	if err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), WriteResponse{}, err
 	}
 	err = client.DeleteQoSPolicy(ctx, qosPolicyDelete)
 
 	if err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), WriteResponse{}, err
 	}
 
 	responseText := "QoS policy deleted successfully"
@@ -113,44 +98,39 @@ func (a *App) DeleteQoSPolicy(ctx context.Context, _ *mcp.CallToolRequest, param
 		Content: []mcp.Content{
 			&mcp.TextContent{Text: responseText},
 		},
-	}, nil, nil
+	}, WriteResponse{}, nil
 }
 
-func (a *App) ModifyQoSPolicy(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.QoSPolicyModify) (*mcp.CallToolResult, any, error) {
-	if !a.locks.TryLock(parameters.Cluster) {
-		return errorResult(fmt.Errorf("another write operation is in progress on cluster %s, please try again", parameters.Cluster)), nil, nil
-	}
-	defer a.locks.Unlock(parameters.Cluster)
-
+func (a *App) ModifyQoSPolicy(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.QoSPolicyModify) (*mcp.CallToolResult, WriteResponse, error) {
 	if parameters.SVM == "" {
-		return nil, nil, errors.New("SVM name is required")
+		return nil, WriteResponse{}, errors.New("SVM name is required")
 	}
 	if parameters.Name == "" {
-		return nil, nil, errors.New("QoS policy name is required")
+		return nil, WriteResponse{}, errors.New("QoS policy name is required")
 	}
 
 	client, err := a.getClient(parameters.Cluster)
 	if err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), WriteResponse{}, err
 	}
 
 	switch parameters.Operation {
 	case "update":
 		qosPolicyUpdate, err := updateQoSPolicyValidation(parameters.QoSPolicyUpdate)
 		if err != nil {
-			return nil, nil, err
+			return nil, WriteResponse{}, err
 		}
 
 		err = client.UpdateQoSPolicy(ctx, qosPolicyUpdate, parameters.Name, parameters.SVM)
 		if err != nil {
-			return errorResult(err), nil, err
+			return errorResult(err), WriteResponse{}, err
 		}
 
 		return &mcp.CallToolResult{
 			Content: []mcp.Content{
 				&mcp.TextContent{Text: "QoS policy updated successfully"},
 			},
-		}, nil, nil
+		}, WriteResponse{}, nil
 	case "delete":
 		qosPolicyDelete := ontap.QoSPolicy{
 			SVM:  ontap.NameAndUUID{Name: parameters.SVM},
@@ -159,16 +139,16 @@ func (a *App) ModifyQoSPolicy(ctx context.Context, _ *mcp.CallToolRequest, param
 
 		err = client.DeleteQoSPolicy(ctx, qosPolicyDelete)
 		if err != nil {
-			return errorResult(err), nil, err
+			return errorResult(err), WriteResponse{}, err
 		}
 
 		return &mcp.CallToolResult{
 			Content: []mcp.Content{
 				&mcp.TextContent{Text: "QoS policy deleted successfully"},
 			},
-		}, nil, nil
+		}, WriteResponse{}, nil
 	default:
-		return errorResult(fmt.Errorf("unsupported operation %q; supported values: update, delete", parameters.Operation)), nil, nil
+		return errorResult(fmt.Errorf("unsupported operation %q; supported values: update, delete", parameters.Operation)), WriteResponse{}, nil
 	}
 }
 
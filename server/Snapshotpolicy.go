@@ -10,25 +10,20 @@ import (
 	"strings"
 )
 
-func (a *App) CreateSnapshotPolicy(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.SnapshotPolicyCreate) (*mcp.CallToolResult, any, error) {
-	if !a.locks.TryLock(parameters.Cluster) {
-		return errorResult(fmt.Errorf("another write operation is in progress on cluster %s, please try again", parameters.Cluster)), nil, nil
-	}
-	defer a.locks.Unlock(parameters.Cluster)
-
+func (a *App) CreateSnapshotPolicy(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.SnapshotPolicyCreate) (*mcp.CallToolResult, WriteResponse, error) {
 	snapshotPolicyCreate, err := newCreateSnapshotPolicy(parameters)
 	if err != nil {
-		return nil, nil, err
+		return nil, WriteResponse{}, err
 	}
 
 	client, err := a.getClient(parameters.Cluster)
 	if err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), WriteResponse{}, err
 	}
 	err = client.CreateSnapshotPolicy(ctx, snapshotPolicyCreate)
 
 	if err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), WriteResponse{}, err
 	}
 
 	responseText := "Snapshot policy created successfully"
@@ -37,28 +32,23 @@ func (a *App) CreateSnapshotPolicy(ctx context.Context, _ *mcp.CallToolRequest,
 		Content: []mcp.Content{
 			&mcp.TextContent{Text: responseText},
 		},
-	}, nil, nil
+	}, WriteResponse{}, nil
 }
 
-func (a *App) UpdateSnapshotPolicy(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.SnapshotPolicy) (*mcp.CallToolResult, any, error) {
-	if !a.locks.TryLock(parameters.Cluster) {
-		return errorResult(fmt.Errorf("another write operation is in progress on cluster %s, please try again", parameters.Cluster)), nil, nil
-	}
-	defer a.locks.Unlock(parameters.Cluster)
-
+func (a *App) UpdateSnapshotPolicy(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.SnapshotPolicy) (*mcp.CallToolResult, WriteResponse, error) {
 	snapshotPolicyUpdate, err := newUpdateSnapshotPolicy(parameters)
 	if err != nil {
-		return nil, nil, err
+		return nil, WriteResponse{}, err
 	}
 
 	client, err := a.getClient(parameters.Cluster)
 	if err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), WriteResponse{}, err
 	}
 	err = client.UpdateSnapshotPolicy(ctx, snapshotPolicyUpdate, parameters.Name, parameters.SVM)
 
 	if err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), WriteResponse{}, err
 	}
 
 	responseText := "Snapshot policy updated successfully"
@@ -67,28 +57,23 @@ func (a *App) UpdateSnapshotPolicy(ctx context.Context, _ *mcp.CallToolRequest,
 		Content: []mcp.Content{
 			&mcp.TextContent{Text: responseText},
 		},
-	}, nil, nil
+	}, WriteResponse{}, nil
 }
 
-func (a *App) DeleteSnapshotPolicy(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.SnapshotPolicy) (*mcp.CallToolResult, any, error) {
-	if !a.locks.TryLock(parameters.Cluster) {
-		return errorResult(fmt.Errorf("another write operation is in progress on cluster %s, please try again", parameters.Cluster)), nil, nil
-	}
-	defer a.locks.Unlock(parameters.Cluster)
-
+func (a *App) DeleteSnapshotPolicy(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.SnapshotPolicy) (*mcp.CallToolResult, WriteResponse, error) {
 	snapshotPolicyDelete, err := newDeleteSnapshotPolicy(parameters)
 	if err != nil {
-		return nil, nil, err
+		return nil, WriteResponse{}, err
 	}
 
 	client, err := a.getClient(parameters.Cluster)
 	if err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), WriteResponse{}, err
 	}
 	err = client.DeleteSnapshotPolicy(ctx, snapshotPolicyDelete)
 
 	if err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), WriteResponse{}, err
 	}
 
 	responseText := "Snapshot policy deleted successfully"
@@ -97,44 +82,39 @@ func (a *App) DeleteSnapshotPolicy(ctx context.Context, _ *mcp.CallToolRequest,
 		Content: []mcp.Content{
 			&mcp.TextContent{Text: responseText},
 		},
-	}, nil, nil
+	}, WriteResponse{}, nil
 }
 
-func (a *App) ModifySnapshotPolicy(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.SnapshotPolicyModify) (*mcp.CallToolResult, any, error) {
-	if !a.locks.TryLock(parameters.Cluster) {
-		return errorResult(fmt.Errorf("another write operation is in progress on cluster %s, please try again", parameters.Cluster)), nil, nil
-	}
-	defer a.locks.Unlock(parameters.Cluster)
-
+func (a *App) ModifySnapshotPolicy(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.SnapshotPolicyModify) (*mcp.CallToolResult, WriteResponse, error) {
 	if parameters.SVM == "" {
-		return nil, nil, errors.New("SVM name is required")
+		return nil, WriteResponse{}, errors.New("SVM name is required")
 	}
 	if parameters.Name == "" {
-		return nil, nil, errors.New("snapshot policy name is required")
+		return nil, WriteResponse{}, errors.New("snapshot policy name is required")
 	}
 
 	client, err := a.getClient(parameters.Cluster)
 	if err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), WriteResponse{}, err
 	}
 
 	switch parameters.Operation {
 	case "update":
 		snapshotPolicyUpdate, err := updateSnapshotPolicyValidation(parameters.SnapshotPolicyUpdate)
 		if err != nil {
-			return nil, nil, err
+			return nil, WriteResponse{}, err
 		}
 
 		err = client.UpdateSnapshotPolicy(ctx, snapshotPolicyUpdate, parameters.Name, parameters.SVM)
 		if err != nil {
-			return errorResult(err), nil, err
+			return errorResult(err), WriteResponse{}, err
 		}
 
 		return &mcp.CallToolResult{
 			Content: []mcp.Content{
 				&mcp.TextContent{Text: "Snapshot policy updated successfully"},
 			},
-		}, nil, nil
+		}, WriteResponse{}, nil
 	case "delete":
 		snapshotPolicyDelete := ontap.SnapshotPolicy{
 			SVM:  ontap.NameAndUUID{Name: parameters.SVM},
@@ -143,16 +123,16 @@ func (a *App) ModifySnapshotPolicy(ctx context.Context, _ *mcp.CallToolRequest,
 
 		err = client.DeleteSnapshotPolicy(ctx, snapshotPolicyDelete)
 		if err != nil {
-			return errorResult(err), nil, err
+			return errorResult(err), WriteResponse{}, err
 		}
 
 		return &mcp.CallToolResult{
 			Content: []mcp.Content{
 				&mcp.TextContent{Text: "Snapshot policy deleted successfully"},
 			},
-		}, nil, nil
+		}, WriteResponse{}, nil
 	default:
-		return errorResult(fmt.Errorf("unsupported operation %q; supported values: update, delete", parameters.Operation)), nil, nil
+		return errorResult(fmt.Errorf("unsupported operation %q; supported values: update, delete", parameters.Operation)), WriteResponse{}, nil
 	}
 }
 
@@ -250,25 +230,20 @@ func newDeleteSnapshotPolicy(in tool.SnapshotPolicy) (ontap.SnapshotPolicy, erro
 	return out, nil
 }
 
-func (a *App) CreateSchedule(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.Schedule) (*mcp.CallToolResult, any, error) {
-	if !a.locks.TryLock(parameters.Cluster) {
-		return errorResult(fmt.Errorf("another write operation is in progress on cluster %s, please try again", parameters.Cluster)), nil, nil
-	}
-	defer a.locks.Unlock(parameters.Cluster)
-
+func (a *App) CreateSchedule(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.Schedule) (*mcp.CallToolResult, WriteResponse, error) {
 	scheduleCreate, err := newCreateSchedule(parameters)
 	if err != nil {
-		return nil, nil, err
+		return nil, WriteResponse{}, err
 	}
 
 	client, err := a.getClient(parameters.Cluster)
 	if err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), WriteResponse{}, err
 	}
 	err = client.CreateSchedule(ctx, scheduleCreate)
 
 	if err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), WriteResponse{}, err
 	}
 
 	responseText := "Schedule created successfully"
@@ -277,7 +252,7 @@ func (a *App) CreateSchedule(ctx context.Context, _ *mcp.CallToolRequest, parame
 		Content: []mcp.Content{
 			&mcp.TextContent{Text: responseText},
 		},
-	}, nil, nil
+	}, WriteResponse{}, nil
 }
 
 // newCreateSchedule validates the customer provided arguments and converts them into
@@ -338,6 +313,9 @@ func readRanges(minRange int, maxRange int, r string, out *[]int) {
 
 func convertCron(cronStr string, out *ontap.Schedule) error {
 	fields := strings.Fields(cronStr)
+	if len(fields) > 5 {
+		return fmt.Errorf("cron expression %q has %d fields; use at most five: minute hour day month weekday", cronStr, len(fields))
+	}
 	for i := range 5 {
 		var field string
 		if i < len(fields) {
@@ -363,146 +341,123 @@ func convertCron(cronStr string, out *ontap.Schedule) error {
 		default:
 		}
 	}
-	if len(fields) > 5 {
-		fmt.Println("Ignoring extra fields in cron")
-	}
 	return nil
 }
 
-func (a *App) AddScheduleInSnapshotPolicy(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.SnapshotPolicySchedule) (*mcp.CallToolResult, any, error) {
-	if !a.locks.TryLock(parameters.Cluster) {
-		return errorResult(fmt.Errorf("another write operation is in progress on cluster %s, please try again", parameters.Cluster)), nil, nil
-	}
-	defer a.locks.Unlock(parameters.Cluster)
-
+func (a *App) AddScheduleInSnapshotPolicy(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.SnapshotPolicySchedule) (*mcp.CallToolResult, WriteResponse, error) {
 	scheduleEntry, err := newAddScheduleInSnapshotPolicy(parameters)
 	if err != nil {
-		return nil, nil, err
+		return nil, WriteResponse{}, err
 	}
 
 	client, err := a.getClient(parameters.Cluster)
 	if err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), WriteResponse{}, err
 	}
 
 	err = client.AddScheduleInSnapshotPolicy(ctx, parameters.PolicyName, parameters.SVM, scheduleEntry)
 	if err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), WriteResponse{}, err
 	}
 
 	return &mcp.CallToolResult{
 		Content: []mcp.Content{
 			&mcp.TextContent{Text: "Schedule added to snapshot policy successfully"},
 		},
-	}, nil, nil
+	}, WriteResponse{}, nil
 }
 
-func (a *App) UpdateScheduleInSnapshotPolicy(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.SnapshotPolicySchedule) (*mcp.CallToolResult, any, error) {
-	if !a.locks.TryLock(parameters.Cluster) {
-		return errorResult(fmt.Errorf("another write operation is in progress on cluster %s, please try again", parameters.Cluster)), nil, nil
-	}
-	defer a.locks.Unlock(parameters.Cluster)
-
+func (a *App) UpdateScheduleInSnapshotPolicy(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.SnapshotPolicySchedule) (*mcp.CallToolResult, WriteResponse, error) {
 	scheduleEntry, err := newUpdateScheduleInSnapshotPolicy(parameters)
 	if err != nil {
-		return nil, nil, err
+		return nil, WriteResponse{}, err
 	}
 
 	client, err := a.getClient(parameters.Cluster)
 	if err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), WriteResponse{}, err
 	}
 
 	err = client.UpdateScheduleInSnapshotPolicy(ctx, parameters.PolicyName, parameters.SVM, parameters.ScheduleName, scheduleEntry)
 	if err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), WriteResponse{}, err
 	}
 
 	return &mcp.CallToolResult{
 		Content: []mcp.Content{
 			&mcp.TextContent{Text: "Schedule in snapshot policy updated successfully"},
 		},
-	}, nil, nil
+	}, WriteResponse{}, nil
 }
 
-func (a *App) RemoveScheduleInSnapshotPolicy(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.SnapshotPolicySchedule) (*mcp.CallToolResult, any, error) {
-	if !a.locks.TryLock(parameters.Cluster) {
-		return errorResult(fmt.Errorf("another write operation is in progress on cluster %s, please try again", parameters.Cluster)), nil, nil
-	}
-	defer a.locks.Unlock(parameters.Cluster)
-
+func (a *App) RemoveScheduleInSnapshotPolicy(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.SnapshotPolicySchedule) (*mcp.CallToolResult, WriteResponse, error) {
 	if err := validateDeleteScheduleInSnapshotPolicy(parameters); err != nil {
-		return nil, nil, err
+		return nil, WriteResponse{}, err
 	}
 
 	client, err := a.getClient(parameters.Cluster)
 	if err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), WriteResponse{}, err
 	}
 
 	err = client.RemoveScheduleInSnapshotPolicy(ctx, parameters.PolicyName, parameters.SVM, parameters.ScheduleName)
 	if err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), WriteResponse{}, err
 	}
 
 	return &mcp.CallToolResult{
 		Content: []mcp.Content{
 			&mcp.TextContent{Text: "Schedule removed from snapshot policy successfully"},
 		},
-	}, nil, nil
+	}, WriteResponse{}, nil
 }
 
-func (a *App) ModifyScheduleInSnapshotPolicy(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.SnapshotPolicyScheduleModify) (*mcp.CallToolResult, any, error) {
-	if !a.locks.TryLock(parameters.Cluster) {
-		return errorResult(fmt.Errorf("another write operation is in progress on cluster %s, please try again", parameters.Cluster)), nil, nil
-	}
-	defer a.locks.Unlock(parameters.Cluster)
-
+func (a *App) ModifyScheduleInSnapshotPolicy(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.SnapshotPolicyScheduleModify) (*mcp.CallToolResult, WriteResponse, error) {
 	if parameters.SVM == "" {
-		return nil, nil, errors.New("SVM name is required")
+		return nil, WriteResponse{}, errors.New("SVM name is required")
 	}
 	if parameters.PolicyName == "" {
-		return nil, nil, errors.New("snapshot policy name is required")
+		return nil, WriteResponse{}, errors.New("snapshot policy name is required")
 	}
 	if parameters.ScheduleName == "" {
-		return nil, nil, errors.New("schedule name is required")
+		return nil, WriteResponse{}, errors.New("schedule name is required")
 	}
 
 	client, err := a.getClient(parameters.Cluster)
 	if err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), WriteResponse{}, err
 	}
 
 	switch parameters.Operation {
 	case "update":
 		scheduleUpdate, err := updateScheduleInSnapshotPolicyValidation(parameters.SnapshotPolicyScheduleUpdate)
 		if err != nil {
-			return nil, nil, err
+			return nil, WriteResponse{}, err
 		}
 
 		err = client.UpdateScheduleInSnapshotPolicy(ctx, parameters.PolicyName, parameters.SVM, parameters.ScheduleName, scheduleUpdate)
 		if err != nil {
-			return errorResult(err), nil, err
+			return errorResult(err), WriteResponse{}, err
 		}
 
 		return &mcp.CallToolResult{
 			Content: []mcp.Content{
 				&mcp.TextContent{Text: "Schedule updated in snapshot policy successfully"},
 			},
-		}, nil, nil
+		}, WriteResponse{}, nil
 	case "remove":
 		err = client.RemoveScheduleInSnapshotPolicy(ctx, parameters.PolicyName, parameters.SVM, parameters.ScheduleName)
 		if err != nil {
-			return errorResult(err), nil, err
+			return errorResult(err), WriteResponse{}, err
 		}
 
 		return &mcp.CallToolResult{
 			Content: []mcp.Content{
 				&mcp.TextContent{Text: "Schedule removed from snapshot policy successfully"},
 			},
-		}, nil, nil
+		}, WriteResponse{}, nil
 	default:
-		return errorResult(fmt.Errorf("unsupported operation %q; supported values: update, remove", parameters.Operation)), nil, nil
+		return errorResult(fmt.Errorf("unsupported operation %q; supported values: update, remove", parameters.Operation)), WriteResponse{}, nil
 	}
 }
 
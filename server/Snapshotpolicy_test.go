@@ -23,6 +23,11 @@ func TestConvertCron(t *testing.T) {
 			cronExpression: "*/5 * * * *",
 			wantErrorText:  "wrong cron format",
 		},
+		{
+			name:           "seconds field",
+			cronExpression: "0 5 * * * *",
+			wantErrorText:  "use at most five",
+		},
 		{
 			name:           "At 5 minutes past the hour",
 			cronExpression: "5 * * * *",
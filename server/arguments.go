@@ -0,0 +1,36 @@
+package server
+
+import (
+	"encoding/json"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/netapp/ontap-mcp/rest"
+)
+
+// toolArguments decodes the raw arguments of a tool call. Calls without
+// arguments, or with arguments that are not a JSON object, return nil.
+func toolArguments(req *mcp.CallToolRequest) map[string]any {
+	if req == nil || req.Params == nil || len(req.Params.Arguments) == 0 {
+		return nil
+	}
+	var args map[string]any
+	if err := json.Unmarshal(req.Params.Arguments, &args); err != nil {
+		return nil
+	}
+	return args
+}
+
+// maskArguments returns a copy of args with sensitive values replaced by
+// rest.RedactedValue.
+func maskArguments(args map[string]any) map[string]any {
+	if args == nil {
+		return nil
+	}
+	masked, _ := rest.Redact(args).(map[string]any)
+	return masked
+}
+
+func stringArgument(args map[string]any, key string) string {
+	s, _ := args[key].(string)
+	return s
+}
@@ -0,0 +1,173 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/netapp/ontap-mcp/config"
+)
+
+// Outcomes recorded in the audit log.
+const (
+	auditSuccess            = "success"
+	auditError              = "error"
+	auditDenied             = "denied"              // rejected by McpAuth roles
+	auditPending            = "pending"             // waiting for the user to confirm a delete
+	auditNotConfirmed       = "not_confirmed"       // the user declined, or could not be asked
+	auditUnconfirmedAllowed = "unconfirmed_allowed" // a delete ran unconfirmed, the client cannot be asked
+	auditDryRun             = "dry_run"
+)
+
+// auditEntry is one line of the audit log.
+type auditEntry struct {
+	Time       time.Time      `json:"time"`
+	Subject    string         `json:"subject,omitempty"`
+	SessionID  string         `json:"session_id,omitempty"`
+	Tool       string         `json:"tool"`
+	Cluster    string         `json:"cluster,omitempty"`
+	Clusters   []string       `json:"clusters,omitempty"`
+	Arguments  map[string]any `json:"arguments,omitempty"`
+	Outcome    string         `json:"outcome"`
+	Error      string         `json:"error,omitempty"`
+	DurationMs int64          `json:"duration_ms"`
+}
+
+// auditLog writes an auditEntry as a JSON line to every configured output. A
+// nil auditLog discards entries.
+type auditLog struct {
+	mu      sync.Mutex
+	outputs []io.Writer
+}
+
+func newAuditLog(cfg *config.Audit) (*auditLog, error) {
+	if cfg == nil {
+		return nil, nil
+	}
+
+	var outputs []io.Writer
+	if path := strings.TrimSpace(cfg.File); path != "" {
+		f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open audit log: %w", err)
+		}
+		outputs = append(outputs, f)
+	}
+	if cfg.Syslog != nil {
+		w, err := newSyslogWriter(cfg.Syslog)
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to syslog for the audit log: %w", err)
+		}
+		outputs = append(outputs, w)
+	}
+	if len(outputs) == 0 {
+		return nil, errors.New("section `Audit` requires file, syslog, or both")
+	}
+	return &auditLog{outputs: outputs}, nil
+}
+
+func (l *auditLog) write(entry auditEntry) error {
+	if l == nil {
+		return nil
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var errs []error
+	for _, w := range l.outputs {
+		if _, err := w.Write(data); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// callOutcome returns the outcome of a finished tool call and its error
+// text. An empty outcome is derived from the result.
+func callOutcome(outcome string, res *mcp.CallToolResult, err error) (string, string) {
+	var errText string
+	switch {
+	case err != nil:
+		errText = err.Error()
+	case res != nil && res.IsError:
+		errText = resultText(res)
+	}
+	if outcome == "" {
+		outcome = auditSuccess
+		if errText != "" {
+			outcome = auditError
+		}
+	}
+	return outcome, errText
+}
+
+// multiClusterOutput is implemented by the outputs of tools that query
+// several clusters, so the audit log records the clusters a call reached
+// rather than the clusters or datacenter arguments.
+type multiClusterOutput interface {
+	queriedClusters() []string
+}
+
+// auditToolCall records a finished tool call. out is the tool's output.
+func (a *App) auditToolCall(req *mcp.CallToolRequest, name string, args map[string]any, out any, outcome, errText string, elapsed time.Duration) {
+	if a.audit == nil {
+		return
+	}
+
+	entry := auditEntry{
+		Time:       time.Now().UTC(),
+		Tool:       name,
+		Cluster:    stringArgument(args, "cluster_name"),
+		Arguments:  maskArguments(args),
+		Outcome:    outcome,
+		Error:      errText,
+		DurationMs: elapsed.Milliseconds(),
+	}
+	if multi, ok := out.(multiClusterOutput); ok {
+		entry.Clusters = multi.queriedClusters()
+	}
+	if extra := callExtra(req); extra != nil {
+		entry.Subject = tokenSubject(extra)
+	}
+	if req != nil && req.Session != nil {
+		entry.SessionID = req.Session.ID()
+	}
+
+	if err := a.audit.write(entry); err != nil {
+		a.logger.Error("failed to write audit log entry", slog.String("tool", name), slog.Any("error", err))
+	}
+}
+
+// tokenSubject identifies the caller: the token's sub claim, or the user ID
+// the token verifier reported.
+func tokenSubject(extra *mcp.RequestExtra) string {
+	if extra.TokenInfo == nil {
+		return ""
+	}
+	if sub, ok := tokenClaims(extra.TokenInfo)["sub"].(string); ok && sub != "" {
+		return sub
+	}
+	return extra.TokenInfo.UserID
+}
+
+func resultText(res *mcp.CallToolResult) string {
+	var parts []string
+	for _, c := range res.Content {
+		if t, ok := c.(*mcp.TextContent); ok {
+			parts = append(parts, t.Text)
+		}
+	}
+	return strings.Join(parts, "\n")
+}
@@ -0,0 +1,15 @@
+//go:build !windows && !plan9
+
+package server
+
+import (
+	"cmp"
+	"io"
+	"log/syslog"
+
+	"github.com/netapp/ontap-mcp/config"
+)
+
+func newSyslogWriter(cfg *config.AuditSyslog) (io.Writer, error) {
+	return syslog.Dial(cfg.Network, cfg.Address, syslog.LOG_INFO|syslog.LOG_AUTH, cmp.Or(cfg.Tag, config.AppName))
+}
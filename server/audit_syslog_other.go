@@ -0,0 +1,14 @@
+//go:build windows || plan9
+
+package server
+
+import (
+	"errors"
+	"io"
+
+	"github.com/netapp/ontap-mcp/config"
+)
+
+func newSyslogWriter(*config.AuditSyslog) (io.Writer, error) {
+	return nil, errors.New("syslog is not supported on this platform")
+}
@@ -0,0 +1,142 @@
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/modelcontextprotocol/go-sdk/auth"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/netapp/ontap-mcp/config"
+	"github.com/netapp/ontap-mcp/rest"
+)
+
+func readAuditEntries(t *testing.T, data []byte) []auditEntry {
+	t.Helper()
+	var entries []auditEntry
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		var e auditEntry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			t.Fatalf("audit line is not JSON: %v: %s", err, scanner.Text())
+		}
+		entries = append(entries, e)
+	}
+	return entries
+}
+
+func TestAuditLogRecordsToolCalls(t *testing.T) {
+	app := newONTAPTestApp(t, Options{}, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":{"message":"duplicate entry","code":"1"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"num_records":1,"records":[{"uuid":"svm-uuid"}]}`))
+	})
+	path := filepath.Join(t.TempDir(), "audit.log")
+	audit, err := newAuditLog(&config.Audit{File: path})
+	if err != nil {
+		t.Fatalf("newAuditLog: %v", err)
+	}
+	app.audit = audit
+	session := newTestSession(t, app, nil)
+
+	calls := []*mcp.CallToolParams{
+		{Name: "list_registered_clusters", Arguments: map[string]any{}},
+		{Name: "create_cifs_service", Arguments: map[string]any{
+			"cluster_name":     "dc1",
+			"svm_name":         "vs1",
+			"cifs_server_name": "CIFS1",
+			"ad_domain":        "example.com",
+			"ad_user":          "admin",
+			"ad_password":      "hunter2",
+		}},
+		{Name: "create_cifs_service", Arguments: map[string]any{
+			"cluster_name":     "dc1",
+			"svm_name":         "vs1",
+			"cifs_server_name": "CIFS1",
+			"ad_domain":        "example.com",
+			"ad_user":          "admin",
+			"ad_password":      "hunter2",
+			"dry_run":          true,
+		}},
+	}
+	for _, params := range calls {
+		if _, err := session.CallTool(t.Context(), params); err != nil {
+			t.Fatalf("CallTool %s: %v", params.Name, err)
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read audit log: %v", err)
+	}
+	if strings.Contains(string(data), "hunter2") {
+		t.Fatalf("audit log leaks the password: %s", data)
+	}
+	entries := readAuditEntries(t, data)
+	if len(entries) != len(calls) {
+		t.Fatalf("expected %d audit entries, got %d: %s", len(calls), len(entries), data)
+	}
+
+	tests := []struct {
+		tool    string
+		cluster string
+		outcome string
+		hasErr  bool
+	}{
+		{tool: "list_registered_clusters", outcome: auditSuccess},
+		{tool: "create_cifs_service", cluster: "dc1", outcome: auditError, hasErr: true},
+		{tool: "create_cifs_service", cluster: "dc1", outcome: auditDryRun},
+	}
+	for i, tt := range tests {
+		e := entries[i]
+		if e.Tool != tt.tool || e.Cluster != tt.cluster || e.Outcome != tt.outcome || (e.Error != "") != tt.hasErr {
+			t.Errorf("entry %d = %+v, want tool=%s cluster=%s outcome=%s error=%t", i, e, tt.tool, tt.cluster, tt.outcome, tt.hasErr)
+		}
+		if e.Time.IsZero() {
+			t.Errorf("entry %d has no time: %+v", i, e)
+		}
+	}
+	if got := entries[1].Arguments["ad_password"]; got != rest.RedactedValue {
+		t.Errorf("ad_password = %v, want %s", got, rest.RedactedValue)
+	}
+	if got := entries[1].Arguments["ad_user"]; got != "admin" {
+		t.Errorf("ad_user = %v, want admin", got)
+	}
+}
+
+func TestNewAuditLog_RequiresOutput(t *testing.T) {
+	if _, err := newAuditLog(&config.Audit{}); err == nil {
+		t.Fatal("expected an error for an Audit section without outputs")
+	}
+	if l, err := newAuditLog(nil); l != nil || err != nil {
+		t.Fatalf("expected no audit log without an Audit section, got %v, %v", l, err)
+	}
+}
+
+func TestTokenSubject(t *testing.T) {
+	tests := []struct {
+		name string
+		info *auth.TokenInfo
+		want string
+	}{
+		{name: "no token"},
+		{name: "sub claim", info: &auth.TokenInfo{UserID: "u1", Extra: map[string]any{"claims": jwt.MapClaims{"sub": "alice"}}}, want: "alice"},
+		{name: "user id", info: &auth.TokenInfo{UserID: "u1"}, want: "u1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tokenSubject(&mcp.RequestExtra{TokenInfo: tt.info}); got != tt.want {
+				t.Errorf("tokenSubject() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
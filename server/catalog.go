@@ -0,0 +1,210 @@
+package server
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/netapp/ontap-mcp/catalog"
+	"github.com/netapp/ontap-mcp/conf"
+	"github.com/netapp/ontap-mcp/tool"
+)
+
+// embeddedCatalog is parsed once and shared by every App, which only read it.
+var embeddedCatalog = sync.OnceValues(func() (catalog.APICatalog, error) {
+	return catalog.Parse(conf.APICatalog)
+})
+
+// loadCatalog returns the API catalog and where it came from. The --api-catalog
+// flag takes precedence over ApiCatalog in the config, and the embedded
+// catalog is used when neither is set. A configured catalog that cannot be
+// loaded is an error, so the catalog tools never disappear unnoticed.
+func loadCatalog(flagPath, configPath string) (catalog.APICatalog, string, error) {
+	path := flagPath
+	if path == "" {
+		path = configPath
+	}
+	if path == "" {
+		cat, err := embeddedCatalog()
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to parse the embedded API catalog: %w", err)
+		}
+		return cat, "embedded", nil
+	}
+	cat, err := catalog.Load(path)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to load API catalog: %w", err)
+	}
+	return cat, path, nil
+}
+
+// checkCatalog rejects an ontap_get query the catalog knows ONTAP cannot
+// answer: a filter value of the wrong type or not among the values of an
+// enum, and, when ontapVersion is known, an endpoint, or a field or filter of
+// it, that was added later or already removed. Paths, fields and filters the
+// catalog does not know are left for ONTAP to judge.
+func (a *App) checkCatalog(p tool.OntapGetParams, ontapVersion string) error {
+	if a.catalog == nil {
+		return nil
+	}
+	ep, ok := a.catalog[p.Path]
+	if !ok {
+		return nil
+	}
+	if err := checkFilterValues(ep, p.Filters); err != nil {
+		return err
+	}
+	if !knownVersion(ontapVersion) {
+		return nil
+	}
+	if !ep.AvailableIn(ontapVersion) {
+		return fmt.Errorf("%s is not available on cluster %s, which runs ONTAP %s. It is available %s",
+			p.Path, p.Cluster, ontapVersion, availability(ep.Introduced, ep.Until))
+	}
+
+	var unsupported []string
+	for _, field := range splitFields(p.Fields) {
+		name, _, _ := strings.Cut(field, ".")
+		if f, ok := ep.Fields[name]; ok && !catalog.InRange(f.Since, f.Until, ontapVersion) {
+			unsupported = append(unsupported, fmt.Sprintf("field %s (available %s)", field, availability(f.Since, f.Until)))
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(p.Filters)) {
+		if f, ok := ep.Filters[name]; ok && !catalog.InRange(f.Since, f.Until, ontapVersion) {
+			unsupported = append(unsupported, fmt.Sprintf("filter %s (available %s)", name, availability(f.Since, f.Until)))
+		}
+	}
+	if len(unsupported) > 0 {
+		return fmt.Errorf("cluster %s runs ONTAP %s, which does not support %s. Call describe_ontap_endpoint with cluster_name %s for the fields and filters it supports",
+			p.Cluster, ontapVersion, strings.Join(unsupported, "; "), p.Cluster)
+	}
+	return nil
+}
+
+// checkFilterValues checks the filters of a query against the types and enums
+// of ep. A value may use the ONTAP query syntax: alternatives separated by |,
+// each optionally prefixed with !, <, >, <= or >=, or a range such as 1..10.
+// Values with a * wildcard, and null, are not checked.
+func checkFilterValues(ep catalog.APIEndpoint, filters map[string]string) error {
+	var problems []string
+	for _, name := range slices.Sorted(maps.Keys(filters)) {
+		f, ok := ep.Filters[name]
+		if !ok {
+			continue
+		}
+		for alt := range strings.SplitSeq(filters[name], "|") {
+			alt = strings.TrimLeft(strings.TrimSpace(alt), "!<>=")
+			if alt == "" || alt == "null" || strings.Contains(alt, "*") {
+				continue
+			}
+			for v := range strings.SplitSeq(alt, "..") {
+				if problem := checkFilterValue(f, v); problem != "" {
+					problems = append(problems, fmt.Sprintf("filter %s: %q %s", name, v, problem))
+				}
+			}
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid filter values: %s. Call describe_ontap_endpoint for the type of each filter", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// checkFilterValue returns what is wrong with one value of filter f, or "".
+func checkFilterValue(f catalog.FilterInfo, v string) string {
+	var unit string
+	if f.Unit != "" {
+		unit = " in " + f.Unit
+	}
+	switch f.Type {
+	case "integer":
+		if _, err := strconv.ParseInt(v, 10, 64); err != nil {
+			return "is not an integer" + unit
+		}
+	case "number":
+		if _, err := strconv.ParseFloat(v, 64); err != nil {
+			return "is not a number" + unit
+		}
+	case "boolean":
+		if v != "true" && v != "false" {
+			return "is not true or false"
+		}
+	}
+	if len(f.Enum) > 0 && !slices.ContainsFunc(f.Enum, func(e string) bool { return strings.EqualFold(e, v) }) {
+		return "is not one of " + strings.Join(f.Enum, ", ")
+	}
+	return ""
+}
+
+// typeNotes returns the annotations describe_ontap_endpoint shows for the type
+// of a field or filter, e.g. "integer" and "in bytes". Strings, the most
+// common type, are not annotated.
+func typeNotes(typ, items, format, unit string, readOnly bool) []string {
+	var notes []string
+	switch {
+	case typ == "array" && items != "":
+		notes = append(notes, "array of "+items)
+	case typ != "" && typ != "string":
+		notes = append(notes, typ)
+	}
+	if format != "" {
+		notes = append(notes, format)
+	}
+	if unit != "" {
+		notes = append(notes, "in "+unit)
+	}
+	if readOnly {
+		notes = append(notes, "read-only")
+	}
+	return notes
+}
+
+// writeCatalogEntry writes a field or filter line of describe_ontap_endpoint,
+// e.g. "  state(9.12) — The state of the volume. One of: online, offline".
+func writeCatalogEntry(sb *strings.Builder, name string, notes []string, desc string, enum []string) {
+	fmt.Fprintf(sb, "  %s", name)
+	if len(notes) > 0 {
+		fmt.Fprintf(sb, "(%s)", strings.Join(notes, ","))
+	}
+	if len(enum) > 0 {
+		desc = strings.TrimSpace(strings.TrimSuffix(desc, ".") + ". One of: " + strings.Join(enum, ", "))
+		desc = strings.TrimPrefix(desc, ". ")
+	}
+	if desc != "" {
+		fmt.Fprintf(sb, " — %s", desc)
+	}
+	sb.WriteString("\n")
+}
+
+// knownVersion reports whether a cluster reported its ONTAP version. The REST
+// API was introduced in ONTAP 9.6, so anything older means it did not.
+func knownVersion(ontapVersion string) bool {
+	return ontapVersion != "" && catalog.CompareVersions(ontapVersion, "9.6") >= 0
+}
+
+// availability describes the ONTAP versions from since until until.
+func availability(since, until string) string {
+	if since == "" {
+		since = "9.6"
+	}
+	if until == "" {
+		return "in ONTAP " + since + " and later"
+	}
+	return "from ONTAP " + since + " until " + until + ", which removed it"
+}
+
+// versionNotes returns the annotations describe_ontap_endpoint shows for the
+// version range of a field or filter, e.g. "9.12" or "removed in 9.15".
+func versionNotes(since, until string) []string {
+	var notes []string
+	if since != "" {
+		notes = append(notes, since)
+	}
+	if until != "" {
+		notes = append(notes, "removed in "+until)
+	}
+	return notes
+}
@@ -0,0 +1,271 @@
+package server
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/netapp/ontap-mcp/catalog"
+)
+
+func TestCatalogChecks(t *testing.T) {
+	app := newONTAPTestApp(t, Options{}, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/cluster" {
+			_, _ = w.Write([]byte(`{"name":"dc1","version":{"generation":9,"major":10}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"num_records":0,"records":[]}`))
+	})
+	app.catalog = catalog.APICatalog{
+		"/storage/volumes": {
+			Introduced: "9.6",
+			Fields: map[string]catalog.FieldInfo{
+				"name": {Desc: "name"}, "anti_ransomware": {Since: "9.12", Desc: "arw"}, "legacy": {Until: "9.14", Desc: "old"},
+				"uuid": {Format: "uuid", ReadOnly: true, Desc: "Unique identifier"}, "aggregates": {Type: "array", Items: "object", Desc: "Aggregates"},
+			},
+			Filters: map[string]catalog.FilterInfo{
+				"name": {}, "anti_ransomware.state": {Since: "9.12"},
+				"space.size":  {Type: "integer", Unit: "bytes"},
+				"state":       {Enum: []string{"online", "offline"}, Desc: "The state."},
+				"is_svm_root": {Type: "boolean"},
+			},
+		},
+		"/storage/pools":   {Introduced: "9.11"},
+		"/storage/retired": {Introduced: "9.6", Until: "9.9"},
+	}
+	session := newTestSession(t, app, nil)
+
+	tests := []struct {
+		name     string
+		tool     string
+		args     map[string]any
+		want     []string
+		notWant  []string
+		wantFail bool
+	}{
+		{
+			name: "supported query",
+			tool: "ontap_get",
+			args: map[string]any{"cluster_name": "dc1", "path": "/storage/volumes", "fields": "name,legacy,space.used", "filters": map[string]string{"name": "vol1"}},
+			want: []string{`"num_records":0`},
+		},
+		{
+			name: "valid filter values",
+			tool: "ontap_get",
+			args: map[string]any{"cluster_name": "dc1", "path": "/storage/volumes", "filters": map[string]string{
+				"space.size": ">=1073741824", "state": "Online|!offline", "is_svm_root": "false", "name": "vol*",
+			}},
+			want: []string{`"num_records":0`},
+		},
+		{
+			name: "invalid filter values",
+			tool: "ontap_get",
+			args: map[string]any{"cluster_name": "dc1", "path": "/storage/volumes", "filters": map[string]string{
+				"space.size": "1..10GB", "state": "up", "is_svm_root": "yes",
+			}},
+			want: []string{
+				`filter is_svm_root: "yes" is not true or false`,
+				`filter space.size: "10GB" is not an integer in bytes`,
+				`filter state: "up" is not one of online, offline`,
+			},
+			notWant:  []string{`"1"`},
+			wantFail: true,
+		},
+		{
+			name:     "fields and filters added later",
+			tool:     "ontap_get",
+			args:     map[string]any{"cluster_name": "dc1", "path": "/storage/volumes", "fields": "name,anti_ransomware.state", "filters": map[string]string{"anti_ransomware.state": "enabled"}},
+			want:     []string{"ONTAP 9.10", "field anti_ransomware.state (available in ONTAP 9.12 and later)", "filter anti_ransomware.state"},
+			wantFail: true,
+		},
+		{
+			name:     "endpoint added later",
+			tool:     "ontap_get",
+			args:     map[string]any{"cluster_name": "dc1", "path": "/storage/pools"},
+			want:     []string{"/storage/pools is not available on cluster dc1", "in ONTAP 9.11 and later"},
+			wantFail: true,
+		},
+		{
+			name:     "endpoint removed",
+			tool:     "ontap_get",
+			args:     map[string]any{"cluster_name": "dc1", "path": "/storage/retired"},
+			want:     []string{"from ONTAP 9.6 until 9.9, which removed it"},
+			wantFail: true,
+		},
+		{
+			name:    "describe for a cluster",
+			tool:    "describe_ontap_endpoint",
+			args:    map[string]any{"cluster_name": "dc1", "path": "/storage/volumes"},
+			want:    []string{"filtered for ONTAP 9.10", "legacy(removed in 9.14) — old"},
+			notWant: []string{"anti_ransomware"},
+		},
+		{
+			name: "describe without a cluster",
+			tool: "describe_ontap_endpoint",
+			args: map[string]any{"path": "/storage/volumes"},
+			want: []string{
+				"anti_ransomware(9.12) — arw", "anti_ransomware.state(9.12)", "legacy(removed in 9.14)",
+				"space.size(integer,in bytes)\n", "state — The state. One of: online, offline\n",
+				"uuid(uuid,read-only) — Unique identifier", "aggregates(array of object) — Aggregates",
+			},
+		},
+		{
+			name: "describe an endpoint the cluster lacks",
+			tool: "describe_ontap_endpoint",
+			args: map[string]any{"cluster_name": "dc1", "path": "/storage/pools"},
+			want: []string{"Endpoint /storage/pools is not available on cluster dc1, which runs ONTAP 9.10"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := session.CallTool(t.Context(), &mcp.CallToolParams{Name: tt.tool, Arguments: tt.args})
+			if err != nil {
+				t.Fatalf("CallTool: %v", err)
+			}
+			text := toolText(t, res)
+			if res.IsError != tt.wantFail {
+				t.Fatalf("IsError = %v, want %v: %s", res.IsError, tt.wantFail, text)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(text, want) {
+					t.Errorf("expected %q in:\n%s", want, text)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(text, notWant) {
+					t.Errorf("expected no %q in:\n%s", notWant, text)
+				}
+			}
+		})
+	}
+}
+
+func TestLoadCatalog(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, contents string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	flagCatalog := write("flag.json", `{"ontap_version":"9.17","endpoints":{"/storage/flag":{"summary":"flag"}}}`)
+	configCatalog := write("config.json", `{"ontap_version":"9.17","endpoints":{"/storage/config":{"summary":"config"}}}`)
+
+	tests := []struct {
+		name       string
+		flagPath   string
+		configPath string
+		wantPath   string
+		wantErr    string
+	}{
+		{name: "embedded", wantPath: "/storage/volumes"},
+		{name: "flag over config", flagPath: flagCatalog, configPath: configCatalog, wantPath: "/storage/flag"},
+		{name: "config", configPath: configCatalog, wantPath: "/storage/config"},
+		{name: "missing", configPath: filepath.Join(dir, "missing.json"), wantErr: "no such file"},
+		{name: "invalid", flagPath: write("invalid.json", "{"), wantErr: "parse catalog"},
+		{name: "no endpoints", flagPath: write("empty.json", `{"ontap_version":"9.17"}`), wantErr: "catalog has no endpoints"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cat, _, err := loadCatalog(tt.flagPath, tt.configPath)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("loadCatalog: %v", err)
+			}
+			if _, ok := cat[tt.wantPath]; !ok {
+				t.Fatalf("expected %s in the catalog, got %d endpoints", tt.wantPath, len(cat))
+			}
+		})
+	}
+}
+
+func TestSearchOntapEndpoints(t *testing.T) {
+	app := newONTAPTestApp(t, Options{}, func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	session := newTestSession(t, app, nil)
+
+	res, err := session.CallTool(t.Context(), &mcp.CallToolParams{Name: "search_ontap_endpoints", Arguments: map[string]any{"query": "lif", "limit": 2}})
+	if err != nil {
+		t.Fatalf("CallTool: %v", err)
+	}
+	text := toolText(t, res)
+	if !strings.Contains(text, `matching "lif", best 2 first:`) || strings.Count(text, "why: ") != 2 {
+		t.Fatalf("expected the two best endpoints with reasons, got:\n%s", text)
+	}
+	if !strings.Contains(text, `"lif" matched path segment interfaces (synonym "interface")`) {
+		t.Fatalf("expected lif to match interfaces, got:\n%s", text)
+	}
+}
+
+func TestListOntapEndpoints(t *testing.T) {
+	app := newONTAPTestApp(t, Options{}, func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	app.catalog = catalog.APICatalog{
+		"/storage/aggregates": {Summary: "Retrieves aggregates", Tags: []string{"Storage"}},
+		"/storage/luns":       {Summary: "Retrieves LUNs", Tags: []string{"SAN"}},
+		"/storage/volumes":    {Summary: "Retrieves volumes", Tags: []string{"Storage"}},
+		"/network/ip/routes":  {Summary: "Retrieves IP routes", Tags: []string{"Networking"}},
+	}
+	session := newTestSession(t, app, nil)
+
+	tests := []struct {
+		name     string
+		args     map[string]any
+		want     string
+		wantFail bool
+	}{
+		{
+			name: "regex",
+			args: map[string]any{"match": "^/storage/(luns|volumes)$"},
+			want: "Found 2 endpoints, showing 1-2:\n/storage/luns — Retrieves LUNs\n/storage/volumes — Retrieves volumes\n",
+		},
+		{
+			name: "tag, compact and paging",
+			args: map[string]any{"tag": "storage", "compact": true, "limit": 1},
+			want: "Found 2 endpoints, showing 1-1:\n/storage/aggregates\n\n1 more; call again with offset 1.\n",
+		},
+		{
+			name: "next page",
+			args: map[string]any{"tag": "storage", "compact": true, "limit": 1, "offset": 1},
+			want: "Found 2 endpoints, showing 2-2:\n/storage/volumes\n",
+		},
+		{
+			name:     "offset past the end",
+			args:     map[string]any{"offset": 4},
+			want:     "offset 4 is past the last of 4 endpoints",
+			wantFail: true,
+		},
+		{
+			name:     "invalid regex",
+			args:     map[string]any{"match": "(storage"},
+			want:     "invalid pattern",
+			wantFail: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := session.CallTool(t.Context(), &mcp.CallToolParams{Name: "list_ontap_endpoints", Arguments: tt.args})
+			if err != nil {
+				t.Fatalf("CallTool: %v", err)
+			}
+			text := toolText(t, res)
+			if res.IsError != tt.wantFail {
+				t.Fatalf("IsError = %v, want %v: %s", res.IsError, tt.wantFail, text)
+			}
+			if tt.wantFail && !strings.Contains(text, tt.want) || !tt.wantFail && text != tt.want {
+				t.Fatalf("got:\n%s\nwant:\n%s", text, tt.want)
+			}
+		})
+	}
+}
@@ -9,25 +9,20 @@ import (
 	"github.com/netapp/ontap-mcp/tool"
 )
 
-func (a *App) CreateCIFSShare(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.CIFSShareCreate) (*mcp.CallToolResult, any, error) {
-	if !a.locks.TryLock(parameters.Cluster) {
-		return errorResult(fmt.Errorf("another write operation is in progress on cluster %s, please try again", parameters.Cluster)), nil, nil
-	}
-	defer a.locks.Unlock(parameters.Cluster)
-
+func (a *App) CreateCIFSShare(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.CIFSShareCreate) (*mcp.CallToolResult, WriteResponse, error) {
 	cifsShareCreate, err := newCreateCIFSShare(parameters)
 	if err != nil {
-		return nil, nil, err
+		return nil, WriteResponse{}, err
 	}
 
 	client, err := a.getClient(parameters.Cluster)
 	if err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), WriteResponse{}, err
 	}
 	err = client.CreateCIFSShare(ctx, cifsShareCreate)
 
 	if err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), WriteResponse{}, err
 	}
 
 	responseText := "CIFS share created successfully"
@@ -36,28 +31,23 @@ func (a *App) CreateCIFSShare(ctx context.Context, _ *mcp.CallToolRequest, param
 		Content: []mcp.Content{
 			&mcp.TextContent{Text: responseText},
 		},
-	}, nil, nil
+	}, WriteResponse{}, nil
 }
 
-func (a *App) UpdateCIFSShare(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.CIFSShare) (*mcp.CallToolResult, any, error) {
-	if !a.locks.TryLock(parameters.Cluster) {
-		return errorResult(fmt.Errorf("another write operation is in progress on cluster %s, please try again", parameters.Cluster)), nil, nil
-	}
-	defer a.locks.Unlock(parameters.Cluster)
-
+func (a *App) UpdateCIFSShare(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.CIFSShare) (*mcp.CallToolResult, WriteResponse, error) {
 	cifsShareUpdate, err := newUpdateCIFSShare(parameters)
 	if err != nil {
-		return nil, nil, err
+		return nil, WriteResponse{}, err
 	}
 
 	client, err := a.getClient(parameters.Cluster)
 	if err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), WriteResponse{}, err
 	}
 	err = client.UpdateCIFSShare(ctx, parameters.SVM, parameters.Name, cifsShareUpdate)
 
 	if err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), WriteResponse{}, err
 	}
 
 	responseText := "CIFS share updated successfully"
@@ -66,28 +56,23 @@ func (a *App) UpdateCIFSShare(ctx context.Context, _ *mcp.CallToolRequest, param
 		Content: []mcp.Content{
 			&mcp.TextContent{Text: responseText},
 		},
-	}, nil, nil
+	}, WriteResponse{}, nil
 }
 
-func (a *App) DeleteCIFSShare(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.CIFSShare) (*mcp.CallToolResult, any, error) {
-	if !a.locks.TryLock(parameters.Cluster) {
-		return errorResult(fmt.Errorf("another write operation is in progress on cluster %s, please try again", parameters.Cluster)), nil, nil
-	}
-	defer a.locks.Unlock(parameters.Cluster)
-
+func (a *App) DeleteCIFSShare(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.CIFSShare) (*mcp.CallToolResult, WriteResponse, error) {
 	cifsShareDelete, err := newDeleteCIFSShare(parameters)
 	if err != nil {
-		return nil, nil, err
+		return nil, WriteResponse{}, err
 	}
 
 	client, err := a.getClient(parameters.Cluster)
 	if err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), WriteResponse{}, err
 	}
 	err = client.DeleteCIFSShare(ctx, cifsShareDelete)
 
 	if err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), WriteResponse{}, err
 	}
 
 	responseText := "CIFS share deleted successfully"
@@ -96,7 +81,7 @@ func (a *App) DeleteCIFSShare(ctx context.Context, _ *mcp.CallToolRequest, param
 		Content: []mcp.Content{
 			&mcp.TextContent{Text: responseText},
 		},
-	}, nil, nil
+	}, WriteResponse{}, nil
 }
 
 // newCreateCIFSShare validates the customer provided arguments and converts them into
@@ -119,41 +104,36 @@ func newCreateCIFSShare(in tool.CIFSShareCreate) (ontap.CIFSShare, error) {
 	return out, nil
 }
 
-func (a *App) ModifyCIFSShare(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.CIFSShareModify) (*mcp.CallToolResult, any, error) {
-	if !a.locks.TryLock(parameters.Cluster) {
-		return errorResult(fmt.Errorf("another write operation is in progress on cluster %s, please try again", parameters.Cluster)), nil, nil
-	}
-	defer a.locks.Unlock(parameters.Cluster)
-
+func (a *App) ModifyCIFSShare(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.CIFSShareModify) (*mcp.CallToolResult, WriteResponse, error) {
 	if parameters.SVM == "" {
-		return nil, nil, errors.New("SVM name is required")
+		return nil, WriteResponse{}, errors.New("SVM name is required")
 	}
 	if parameters.Name == "" {
-		return nil, nil, errors.New("CIFS share name is required")
+		return nil, WriteResponse{}, errors.New("CIFS share name is required")
 	}
 
 	client, err := a.getClient(parameters.Cluster)
 	if err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), WriteResponse{}, err
 	}
 
 	switch parameters.Operation {
 	case "update":
 		cifsShareUpdate, err := updateCIFSShareValidation(parameters.CIFSShareUpdate)
 		if err != nil {
-			return nil, nil, err
+			return nil, WriteResponse{}, err
 		}
 
 		err = client.UpdateCIFSShare(ctx, parameters.SVM, parameters.Name, cifsShareUpdate)
 		if err != nil {
-			return errorResult(err), nil, err
+			return errorResult(err), WriteResponse{}, err
 		}
 
 		return &mcp.CallToolResult{
 			Content: []mcp.Content{
 				&mcp.TextContent{Text: "CIFS share updated successfully"},
 			},
-		}, nil, nil
+		}, WriteResponse{}, nil
 	case "delete":
 		cifsShareDelete := ontap.CIFSShare{
 			SVM:  ontap.NameAndUUID{Name: parameters.SVM},
@@ -162,16 +142,16 @@ func (a *App) ModifyCIFSShare(ctx context.Context, _ *mcp.CallToolRequest, param
 
 		err = client.DeleteCIFSShare(ctx, cifsShareDelete)
 		if err != nil {
-			return errorResult(err), nil, err
+			return errorResult(err), WriteResponse{}, err
 		}
 
 		return &mcp.CallToolResult{
 			Content: []mcp.Content{
 				&mcp.TextContent{Text: "CIFS share deleted successfully"},
 			},
-		}, nil, nil
+		}, WriteResponse{}, nil
 	default:
-		return errorResult(fmt.Errorf("unsupported operation %q; supported values: update, delete", parameters.Operation)), nil, nil
+		return errorResult(fmt.Errorf("unsupported operation %q; supported values: update, delete", parameters.Operation)), WriteResponse{}, nil
 	}
 }
 
@@ -10,142 +10,122 @@ import (
 	"github.com/netapp/ontap-mcp/tool"
 )
 
-func (a *App) CreateCIFSService(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.CIFSServiceCreate) (*mcp.CallToolResult, any, error) {
-	if !a.locks.TryLock(parameters.Cluster) {
-		return errorResult(fmt.Errorf("another write operation is in progress on cluster %s, please try again", parameters.Cluster)), nil, nil
-	}
-	defer a.locks.Unlock(parameters.Cluster)
-
+func (a *App) CreateCIFSService(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.CIFSServiceCreate) (*mcp.CallToolResult, WriteResponse, error) {
 	cifsService, err := newCreateCIFSService(parameters)
 	if err != nil {
-		return nil, nil, err
+		return nil, WriteResponse{}, err
 	}
 
 	client, err := a.getClient(parameters.Cluster)
 	if err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), WriteResponse{}, err
 	}
 
 	err = client.CreateCIFSService(ctx, cifsService)
 	if err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), WriteResponse{}, err
 	}
 
 	return &mcp.CallToolResult{
 		Content: []mcp.Content{
 			&mcp.TextContent{Text: "CIFS service created successfully"},
 		},
-	}, nil, nil
+	}, WriteResponse{}, nil
 }
 
-func (a *App) UpdateCIFSService(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.CIFSService) (*mcp.CallToolResult, any, error) {
-	if !a.locks.TryLock(parameters.Cluster) {
-		return errorResult(fmt.Errorf("another write operation is in progress on cluster %s, please try again", parameters.Cluster)), nil, nil
-	}
-	defer a.locks.Unlock(parameters.Cluster)
-
+func (a *App) UpdateCIFSService(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.CIFSService) (*mcp.CallToolResult, WriteResponse, error) {
 	cifsService, err := newUpdateCIFSService(parameters)
 	if err != nil {
-		return nil, nil, err
+		return nil, WriteResponse{}, err
 	}
 
 	client, err := a.getClient(parameters.Cluster)
 	if err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), WriteResponse{}, err
 	}
 
 	err = client.UpdateCIFSService(ctx, parameters.SVM, cifsService)
 	if err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), WriteResponse{}, err
 	}
 
 	return &mcp.CallToolResult{
 		Content: []mcp.Content{
 			&mcp.TextContent{Text: "CIFS service updated successfully"},
 		},
-	}, nil, nil
+	}, WriteResponse{}, nil
 }
 
-func (a *App) DeleteCIFSService(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.CIFSService) (*mcp.CallToolResult, any, error) {
-	if !a.locks.TryLock(parameters.Cluster) {
-		return errorResult(fmt.Errorf("another write operation is in progress on cluster %s, please try again", parameters.Cluster)), nil, nil
-	}
-	defer a.locks.Unlock(parameters.Cluster)
-
+func (a *App) DeleteCIFSService(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.CIFSService) (*mcp.CallToolResult, WriteResponse, error) {
 	if parameters.SVM == "" {
-		return nil, nil, errors.New("SVM name is required")
+		return nil, WriteResponse{}, errors.New("SVM name is required")
 	}
 
 	if (parameters.ADUser == "") != (parameters.ADPassword == "") {
-		return nil, nil, errors.New("both ad_user and ad_password must be provided together, or neither")
+		return nil, WriteResponse{}, errors.New("both ad_user and ad_password must be provided together, or neither")
 	}
 
 	client, err := a.getClient(parameters.Cluster)
 	if err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), WriteResponse{}, err
 	}
 
 	err = client.DeleteCIFSService(ctx, parameters.SVM, parameters.ADUser, parameters.ADPassword)
 	if err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), WriteResponse{}, err
 	}
 
 	return &mcp.CallToolResult{
 		Content: []mcp.Content{
 			&mcp.TextContent{Text: "CIFS service deleted successfully"},
 		},
-	}, nil, nil
+	}, WriteResponse{}, nil
 }
 
-func (a *App) ModifyCIFSService(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.CIFSServiceModify) (*mcp.CallToolResult, any, error) {
-	if !a.locks.TryLock(parameters.Cluster) {
-		return errorResult(fmt.Errorf("another write operation is in progress on cluster %s, please try again", parameters.Cluster)), nil, nil
-	}
-	defer a.locks.Unlock(parameters.Cluster)
-
+func (a *App) ModifyCIFSService(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.CIFSServiceModify) (*mcp.CallToolResult, WriteResponse, error) {
 	if parameters.SVM == "" {
-		return nil, nil, errors.New("SVM name is required")
+		return nil, WriteResponse{}, errors.New("SVM name is required")
 	}
 
 	client, err := a.getClient(parameters.Cluster)
 	if err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), WriteResponse{}, err
 	}
 
 	switch parameters.Operation {
 	case "update":
 		cifsService, err := updateCIFSServiceValidation(parameters.CIFSServiceUpdate)
 		if err != nil {
-			return nil, nil, err
+			return nil, WriteResponse{}, err
 		}
 
 		err = client.UpdateCIFSService(ctx, parameters.SVM, cifsService)
 		if err != nil {
-			return errorResult(err), nil, err
+			return errorResult(err), WriteResponse{}, err
 		}
 
 		return &mcp.CallToolResult{
 			Content: []mcp.Content{
 				&mcp.TextContent{Text: "CIFS service updated successfully"},
 			},
-		}, nil, nil
+		}, WriteResponse{}, nil
 	case "delete":
 		if (parameters.ADUser == "") != (parameters.ADPassword == "") {
-			return nil, nil, errors.New("both ad_user and ad_password must be provided together, or neither")
+			return nil, WriteResponse{}, errors.New("both ad_user and ad_password must be provided together, or neither")
 		}
 
 		err = client.DeleteCIFSService(ctx, parameters.SVM, parameters.ADUser, parameters.ADPassword)
 		if err != nil {
-			return errorResult(err), nil, err
+			return errorResult(err), WriteResponse{}, err
 		}
 
 		return &mcp.CallToolResult{
 			Content: []mcp.Content{
 				&mcp.TextContent{Text: "CIFS service deleted successfully"},
 			},
-		}, nil, nil
+		}, WriteResponse{}, nil
 	default:
-		return errorResult(fmt.Errorf("unsupported operation %q; supported values: update, delete", parameters.Operation)), nil, nil
+		return errorResult(fmt.Errorf("unsupported operation %q; supported values: update, delete", parameters.Operation)), WriteResponse{}, nil
 	}
 }
 
@@ -0,0 +1,132 @@
+package server
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/netapp/ontap-mcp/config"
+	"github.com/netapp/ontap-mcp/rest"
+)
+
+// clientRegistry holds one pooled rest.Client per cluster so tool calls reuse
+// keep-alive connections, TLS sessions and the cached cluster Remote instead
+// of building a new client and re-fetching cluster info on every call.
+type clientRegistry struct {
+	mu      sync.Mutex
+	clients map[string]registeredClient // canonical poller name → client
+}
+
+type registeredClient struct {
+	client      *rest.Client
+	fingerprint string
+}
+
+func newClientRegistry() *clientRegistry {
+	return &clientRegistry{clients: make(map[string]registeredClient)}
+}
+
+// get returns the registered client for the cluster. A new client is built
+// when none exists yet or when the poller's connection settings or
+// credentials changed since the client was registered.
+func (r *clientRegistry) get(name string, poller *config.Poller, build func(*config.Poller) *rest.Client) *rest.Client {
+	fp := pollerFingerprint(poller)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if rc, ok := r.clients[name]; ok {
+		if rc.fingerprint == fp {
+			return rc.client
+		}
+		rc.client.CloseIdleConnections()
+	}
+
+	client := build(poller)
+	r.clients[name] = registeredClient{client: client, fingerprint: fp}
+	return client
+}
+
+// reset drops every registered client and closes their idle connections.
+func (r *clientRegistry) reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for name, rc := range r.clients {
+		rc.client.CloseIdleConnections()
+		delete(r.clients, name)
+	}
+}
+
+// pollerFingerprint summarizes everything that affects how a rest.Client
+// connects and authenticates. The modification time and size of the
+// credentials file and the certificate files are included so rotating any of
+// them invalidates the pooled client.
+func pollerFingerprint(p *config.Poller) string {
+	data, err := json.Marshal(p)
+	if err != nil {
+		return ""
+	}
+	h := sha256.New()
+	h.Write(data)
+	for _, path := range []string{p.CredentialsFile, p.CaCertPath, p.SslCert, p.SslKey} {
+		if path == "" {
+			continue
+		}
+		if fi, err := os.Stat(path); err == nil {
+			_, _ = fmt.Fprintf(h, "|%d|%d", fi.ModTime().UnixNano(), fi.Size())
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func (a *App) getClient(cluster string) (*rest.Client, error) {
+	canonical, poller, err := a.resolveCluster(cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.clients.get(canonical, poller, func(p *config.Poller) *rest.Client {
+		if a.options.TestHTTPClient != nil {
+			return rest.NewWithClient(p, a.options.TestHTTPClient)
+		}
+		return rest.New(p)
+	}), nil
+}
+
+// ReloadConfig swaps in a freshly read config. Pooled clients are dropped so
+// the next tool call against each cluster reconnects with the new settings.
+func (a *App) ReloadConfig(cfg *config.ONTAP) error {
+	index, err := buildClusterIndex(cfg)
+	if err != nil {
+		return err
+	}
+
+	a.cfgMu.Lock()
+	a.cfg = cfg
+	a.clusterIndex = index
+	a.cfgMu.Unlock()
+
+	a.clients.reset()
+	a.logger.Info("reloaded cluster config", slog.Int("clusters", len(cfg.Pollers)))
+	return nil
+}
+
+// buildClusterIndex maps lower-cased poller names to their canonical config
+// name and rejects names that differ only by case.
+func buildClusterIndex(cfg *config.ONTAP) (map[string]string, error) {
+	index := make(map[string]string, len(cfg.Pollers))
+	for name := range cfg.Pollers {
+		key := strings.ToLower(name)
+		if existing, collision := index[key]; collision {
+			return nil, fmt.Errorf("poller names %q and %q differ only by case; rename one to avoid ambiguity", existing, name)
+		}
+		index[key] = name
+	}
+	return index, nil
+}
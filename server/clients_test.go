@@ -0,0 +1,239 @@
+package server
+
+import (
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/netapp/ontap-mcp/config"
+	"github.com/netapp/ontap-mcp/tool"
+)
+
+func TestGetClient_ReusesClientPerCluster(t *testing.T) {
+	cfg := &config.ONTAP{Pollers: map[string]*config.Poller{
+		"DC1": {Name: "DC1", Addr: "10.0.0.1", Username: "admin", Password: "p1"},
+		"dc2": {Name: "dc2", Addr: "10.0.0.2", Username: "admin", Password: "p2"},
+	}}
+	app, err := NewApp(cfg, Options{}, slog.Default())
+	if err != nil {
+		t.Fatalf("NewApp: %v", err)
+	}
+
+	c1, err := app.getClient("DC1")
+	if err != nil {
+		t.Fatalf("getClient: %v", err)
+	}
+	c1Again, err := app.getClient("dc1")
+	if err != nil {
+		t.Fatalf("getClient: %v", err)
+	}
+	if c1 != c1Again {
+		t.Fatal("expected the same pooled client for the same cluster")
+	}
+
+	c2, err := app.getClient("dc2")
+	if err != nil {
+		t.Fatalf("getClient: %v", err)
+	}
+	if c1 == c2 {
+		t.Fatal("expected different clients for different clusters")
+	}
+
+	if _, err := app.getClient("missing"); err == nil {
+		t.Fatal("expected error for unknown cluster")
+	}
+
+	// Changing credentials on the poller replaces the pooled client.
+	cfg.Pollers["DC1"].Password = "rotated"
+	c1Rotated, err := app.getClient("DC1")
+	if err != nil {
+		t.Fatalf("getClient: %v", err)
+	}
+	if c1Rotated == c1 {
+		t.Fatal("expected a new client after credentials changed")
+	}
+}
+
+func TestGetClient_CredentialsFileChangeReplacesClient(t *testing.T) {
+	credFile := filepath.Join(t.TempDir(), "creds.yaml")
+	if err := os.WriteFile(credFile, []byte("Pollers:\n  dc1:\n    password: one\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := &config.ONTAP{Pollers: map[string]*config.Poller{
+		"dc1": {Name: "dc1", Addr: "10.0.0.1", CredentialsFile: credFile},
+	}}
+	app, err := NewApp(cfg, Options{}, slog.Default())
+	if err != nil {
+		t.Fatalf("NewApp: %v", err)
+	}
+
+	before, _ := app.getClient("dc1")
+
+	later := time.Now().Add(time.Minute)
+	if err := os.WriteFile(credFile, []byte("Pollers:\n  dc1:\n    password: two\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(credFile, later, later); err != nil {
+		t.Fatal(err)
+	}
+
+	after, _ := app.getClient("dc1")
+	if before == after {
+		t.Fatal("expected a new client after the credentials file changed")
+	}
+}
+
+func TestGetClient_CertificateFileChangeReplacesClient(t *testing.T) {
+	dir := t.TempDir()
+	caFile := filepath.Join(dir, "ca.pem")
+	if err := os.WriteFile(caFile, []byte("one"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := &config.ONTAP{Pollers: map[string]*config.Poller{
+		"dc1": {Name: "dc1", Addr: "10.0.0.1", CaCertPath: caFile},
+	}}
+	app, err := NewApp(cfg, Options{}, slog.Default())
+	if err != nil {
+		t.Fatalf("NewApp: %v", err)
+	}
+
+	before, _ := app.getClient("dc1")
+	if again, _ := app.getClient("dc1"); again != before {
+		t.Fatal("expected the client to be reused while ca_cert is unchanged")
+	}
+
+	later := time.Now().Add(time.Minute)
+	if err := os.WriteFile(caFile, []byte("two"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(caFile, later, later); err != nil {
+		t.Fatal(err)
+	}
+
+	after, _ := app.getClient("dc1")
+	if before == after {
+		t.Fatal("expected a new client after ca_cert changed")
+	}
+}
+
+// TestGetClient_ConcurrentWithToolCall runs tool calls, which initialize the
+// pooled client, while the registry closes its idle connections. Run with
+// -race.
+func TestGetClient_ConcurrentWithToolCall(t *testing.T) {
+	app := newONTAPTestApp(t, Options{}, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/cluster" {
+			_, _ = w.Write([]byte(`{"name":"dc1","version":{"full":"NetApp Release 9.16.1","generation":9,"major":16,"minor":1}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"records":[],"num_records":0}`))
+	})
+	// Let the registry build real clients, which set up their HTTP client on
+	// first use.
+	app.options.TestHTTPClient = nil
+	insecure := true
+	app.cfg.Pollers["dc1"].UseInsecureTLS = &insecure
+	session := newTestSession(t, app, nil)
+
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+	wg.Go(func() {
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			if _, err := app.getClient("dc1"); err != nil {
+				t.Errorf("getClient: %v", err)
+				return
+			}
+			app.clients.reset()
+		}
+	})
+	for range 5 {
+		_, err := session.CallTool(t.Context(), &mcp.CallToolParams{Name: "ontap_get", Arguments: map[string]any{
+			"cluster_name": "dc1", "path": "/storage/volumes", "fields": "name",
+		}})
+		if err != nil {
+			t.Errorf("CallTool: %v", err)
+		}
+	}
+	close(stop)
+	wg.Wait()
+}
+
+func TestReloadConfig_ResetsRegistry(t *testing.T) {
+	cfg := &config.ONTAP{Pollers: map[string]*config.Poller{
+		"dc1": {Name: "dc1", Addr: "10.0.0.1"},
+	}}
+	app, err := NewApp(cfg, Options{}, slog.Default())
+	if err != nil {
+		t.Fatalf("NewApp: %v", err)
+	}
+	before, _ := app.getClient("dc1")
+
+	newCfg := &config.ONTAP{
+		Pollers: map[string]*config.Poller{
+			"dc1": {Name: "dc1", Addr: "10.0.0.1"},
+			"dc3": {Name: "dc3", Addr: "10.0.0.3"},
+		},
+		PollersOrdered: []string{"dc1", "dc3"},
+	}
+	if err := app.ReloadConfig(newCfg); err != nil {
+		t.Fatalf("ReloadConfig: %v", err)
+	}
+
+	after, _ := app.getClient("dc1")
+	if before == after {
+		t.Fatal("expected registry to be cleared on config reload")
+	}
+	if _, err := app.getClient("dc3"); err != nil {
+		t.Fatalf("expected new cluster to be resolvable after reload: %v", err)
+	}
+
+	bad := &config.ONTAP{Pollers: map[string]*config.Poller{"A": {}, "a": {}}}
+	if err := app.ReloadConfig(bad); err == nil {
+		t.Fatal("expected case-collision error on reload")
+	}
+	if _, err := app.getClient("dc3"); err != nil {
+		t.Fatal("a rejected reload must keep the previous config")
+	}
+}
+
+func TestDisabledClusterIsSkipped(t *testing.T) {
+	cfg := &config.ONTAP{
+		Pollers: map[string]*config.Poller{
+			"dc1":   {Name: "dc1", Addr: "127.0.0.1:1", Username: "admin", Password: "p1"},
+			"maint": {Name: "maint", Addr: "127.0.0.1:1", Username: "admin", Password: "p2", IsDisabled: true},
+		},
+		PollersOrdered: []string{"dc1", "maint"},
+	}
+	app, err := NewApp(cfg, Options{}, slog.Default())
+	if err != nil {
+		t.Fatalf("NewApp: %v", err)
+	}
+
+	if _, err := app.getClient("MAINT"); err == nil || !strings.Contains(err.Error(), "disabled") {
+		t.Fatalf("expected disabled cluster error, got %v", err)
+	}
+	if _, err := app.getClient("dc1"); err != nil {
+		t.Fatalf("getClient: %v", err)
+	}
+
+	result, _, err := app.ListClusters(t.Context(), nil, tool.ListClusterParams{})
+	if err != nil {
+		t.Fatalf("ListClusters: %v", err)
+	}
+	text := result.Content[0].(*mcp.TextContent).Text
+	if strings.Contains(text, "maint") || !strings.Contains(text, "dc1") {
+		t.Fatalf("expected only enabled clusters to be listed, got %s", text)
+	}
+}
@@ -0,0 +1,219 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	// completionTTL is how long names looked up for completion are reused.
+	completionTTL = 30 * time.Second
+	// completionTimeout bounds a lookup so completion stays responsive.
+	completionTimeout = 5 * time.Second
+	// maxCompletionValues is the most values a completion may return.
+	maxCompletionValues = 100
+)
+
+// nameLookup describes how to list the names of one kind of object.
+type nameLookup struct {
+	path   string
+	field  string
+	scopes map[string]string // ONTAP query parameter → argument that narrows the lookup
+}
+
+// nameLookups maps argument names to the objects they name. Resource
+// template variables use the short names, e.g. svm for svm_name.
+var nameLookups = map[string]nameLookup{
+	"svm_name": {path: "/svm/svms", field: "name"},
+	"volume_name": {path: "/storage/volumes", field: "name",
+		scopes: map[string]string{"svm.name": "svm_name"}},
+	"lun_name": {path: "/storage/luns", field: "location.logical_unit",
+		scopes: map[string]string{"svm.name": "svm_name", "location.volume.name": "volume_name"}},
+	"igroup_name": {path: "/protocols/san/igroups", field: "name",
+		scopes: map[string]string{"svm.name": "svm_name"}},
+	"snapshot_policy_name": {path: "/storage/snapshot-policies", field: "name"},
+	"qos_policy_name":      {path: "/storage/qos/policies", field: "name"},
+}
+
+var argumentAliases = map[string]string{
+	"cluster":              "cluster_name",
+	"svm":                  "svm_name",
+	"volume":               "volume_name",
+	"lun":                  "lun_name",
+	"igroup":               "igroup_name",
+	"snapshot_policy":      "snapshot_policy_name",
+	"snapshot_policy.name": "snapshot_policy_name",
+	"qos_policy":           "qos_policy_name",
+}
+
+func canonicalArgument(name string) string {
+	if alias, ok := argumentAliases[name]; ok {
+		return alias
+	}
+	return name
+}
+
+// nameCache keeps looked up names for completionTTL.
+type nameCache struct {
+	mu      sync.Mutex
+	entries map[string]nameCacheEntry
+}
+
+type nameCacheEntry struct {
+	names   []string
+	fetched time.Time
+}
+
+func (c *nameCache) get(key string) ([]string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[key]
+	if !ok || time.Since(e.fetched) > completionTTL {
+		return nil, false
+	}
+	return e.names, true
+}
+
+func (c *nameCache) put(key string, names []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.entries == nil {
+		c.entries = make(map[string]nameCacheEntry)
+	}
+	for k, e := range c.entries {
+		if time.Since(e.fetched) > completionTTL {
+			delete(c.entries, k)
+		}
+	}
+	c.entries[key] = nameCacheEntry{names: names, fetched: time.Now()}
+}
+
+// Complete implements completion/complete for prompt and resource template
+// arguments. cluster_name and destination_cluster_name complete from the
+// configured clusters, other names from the selected cluster. Lookup failures
+// return no values rather than an error, so a typo in one argument does not
+// break completion of the others.
+func (a *App) Complete(ctx context.Context, req *mcp.CompleteRequest) (*mcp.CompleteResult, error) {
+	arg := canonicalArgument(req.Params.Argument.Name)
+	prefix := req.Params.Argument.Value
+	perms := a.permissionsFor(req.Extra)
+
+	args := make(map[string]string)
+	if req.Params.Context != nil {
+		for k, v := range req.Params.Context.Arguments {
+			args[canonicalArgument(k)] = v
+		}
+	}
+
+	var names []string
+	if arg == "cluster_name" || arg == "destination_cluster_name" {
+		names = a.clusterNames(perms)
+	} else if lookup, ok := nameLookups[arg]; ok {
+		cluster := args["cluster_name"]
+		if cluster != "" && perms.allows(accessRead, cluster) {
+			names = a.lookupNames(ctx, cluster, lookup, args)
+		}
+	}
+	return completionResult(names, prefix), nil
+}
+
+// clusterNames returns the enabled clusters perms allows, sorted.
+func (a *App) clusterNames(perms *permissions) []string {
+	a.cfgMu.RLock()
+	defer a.cfgMu.RUnlock()
+	clusters := make([]string, 0, len(a.cfg.PollersOrdered))
+	for _, name := range a.cfg.PollersOrdered {
+		if p := a.cfg.Pollers[name]; p != nil && !p.IsDisabled && perms.allowsCluster(name) {
+			clusters = append(clusters, name)
+		}
+	}
+	slices.Sort(clusters)
+	return clusters
+}
+
+func (a *App) lookupNames(ctx context.Context, cluster string, lookup nameLookup, args map[string]string) []string {
+	params := url.Values{}
+	params.Set("fields", lookup.field)
+	for param, arg := range lookup.scopes {
+		if v := args[arg]; v != "" {
+			params.Set(param, v)
+		}
+	}
+	key := strings.ToLower(cluster) + lookup.path + "?" + params.Encode()
+	if names, ok := a.completions.get(key); ok {
+		return names
+	}
+
+	client, err := a.getClient(cluster)
+	if err != nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, completionTimeout)
+	defer cancel()
+	raw, err := client.GenericGet(ctx, lookup.path, params, 0)
+	if err != nil {
+		a.logger.Debug("failed to look up names for completion", slog.String("cluster", cluster), slog.String("path", lookup.path), slog.Any("error", err))
+		return nil
+	}
+
+	var resp struct {
+		Records []map[string]any `json:"records"`
+	}
+	if err := json.Unmarshal(raw, &resp); err != nil {
+		return nil
+	}
+	names := make([]string, 0, len(resp.Records))
+	for _, r := range resp.Records {
+		if name, ok := fieldValue(r, lookup.field).(string); ok && name != "" {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	names = slices.Compact(names)
+	a.completions.put(key, names)
+	return names
+}
+
+// fieldValue returns the value at a dotted path such as location.logical_unit.
+func fieldValue(record map[string]any, path string) any {
+	head, rest, nested := strings.Cut(path, ".")
+	if !nested {
+		return record[head]
+	}
+	child, ok := record[head].(map[string]any)
+	if !ok {
+		return nil
+	}
+	return fieldValue(child, rest)
+}
+
+// completionResult returns the names that start with prefix, ignoring case.
+func completionResult(names []string, prefix string) *mcp.CompleteResult {
+	lower := strings.ToLower(prefix)
+	values := make([]string, 0, min(len(names), maxCompletionValues))
+	total := 0
+	for _, name := range names {
+		if !strings.HasPrefix(strings.ToLower(name), lower) {
+			continue
+		}
+		total++
+		if len(values) < maxCompletionValues {
+			values = append(values, name)
+		}
+	}
+	return &mcp.CompleteResult{
+		Completion: mcp.CompletionResultDetails{
+			Values:  values,
+			Total:   total,
+			HasMore: total > len(values),
+		},
+	}
+}
@@ -0,0 +1,77 @@
+package server
+
+import (
+	"net/http"
+	"slices"
+	"sync/atomic"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestCompletionResult(t *testing.T) {
+	names := []string{"vol1", "Vol2", "data"}
+	got := completionResult(names, "vo")
+	if !slices.Equal(got.Completion.Values, []string{"vol1", "Vol2"}) || got.Completion.Total != 2 || got.Completion.HasMore {
+		t.Fatalf("unexpected completion %+v", got.Completion)
+	}
+
+	many := make([]string, maxCompletionValues+5)
+	for i := range many {
+		many[i] = "v"
+	}
+	got = completionResult(many, "")
+	if len(got.Completion.Values) != maxCompletionValues || got.Completion.Total != len(many) || !got.Completion.HasMore {
+		t.Fatalf("expected completion to be capped, got %d values, total %d", len(got.Completion.Values), got.Completion.Total)
+	}
+}
+
+func TestComplete(t *testing.T) {
+	var lookups atomic.Int32
+	app := newONTAPTestApp(t, Options{}, func(w http.ResponseWriter, r *http.Request) {
+		lookups.Add(1)
+		if r.URL.Path != "/api/storage/volumes" || r.URL.Query().Get("svm.name") != "vs1" || r.URL.Query().Get("fields") != "name" {
+			_, _ = w.Write([]byte(`{"num_records":0,"records":[]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"num_records":3,"records":[{"name":"vol_data"},{"name":"vol_logs"},{"name":"backup"}]}`))
+	})
+	session := newTestSession(t, app, nil)
+	ref := &mcp.CompleteReference{Type: "ref/resource", URI: "ontap://{cluster}/svms/{svm}/volumes/{volume}"}
+
+	res, err := session.Complete(t.Context(), &mcp.CompleteParams{
+		Ref:      ref,
+		Argument: mcp.CompleteParamsArgument{Name: "cluster", Value: "D"},
+	})
+	if err != nil {
+		t.Fatalf("Complete: %v", err)
+	}
+	if !slices.Equal(res.Completion.Values, []string{"dc1"}) {
+		t.Fatalf("cluster completion = %v, want [dc1]", res.Completion.Values)
+	}
+
+	for range 2 {
+		res, err = session.Complete(t.Context(), &mcp.CompleteParams{
+			Ref:      ref,
+			Argument: mcp.CompleteParamsArgument{Name: "volume", Value: "vol"},
+			Context:  &mcp.CompleteContext{Arguments: map[string]string{"cluster": "dc1", "svm": "vs1"}},
+		})
+		if err != nil {
+			t.Fatalf("Complete: %v", err)
+		}
+		if !slices.Equal(res.Completion.Values, []string{"vol_data", "vol_logs"}) {
+			t.Fatalf("volume completion = %v, want [vol_data vol_logs]", res.Completion.Values)
+		}
+	}
+	if got := lookups.Load(); got != 1 {
+		t.Fatalf("expected the volume names to be looked up once and cached, got %d lookups", got)
+	}
+
+	res, err = session.Complete(t.Context(), &mcp.CompleteParams{
+		Ref:      ref,
+		Argument: mcp.CompleteParamsArgument{Name: "svm", Value: ""},
+	})
+	if err != nil || len(res.Completion.Values) != 0 {
+		t.Fatalf("expected no SVM completion without a cluster, got %v, %v", res, err)
+	}
+}
@@ -0,0 +1,260 @@
+package server
+
+import (
+	"cmp"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// Values for Options.ConfirmDestructive.
+const (
+	ConfirmOff   = "off"   // destructive tools run without confirmation
+	ConfirmDeny  = "deny"  // ask for confirmation; deny when the client cannot be asked
+	ConfirmAllow = "allow" // ask for confirmation; allow and log when the client cannot be asked
+)
+
+var confirmSchema = &jsonschema.Schema{
+	Type: "object",
+	Properties: map[string]*jsonschema.Schema{
+		"confirm": {Type: "boolean", Description: "Set to true to proceed with this change."},
+	},
+	Required: []string{"confirm"},
+}
+
+// needsConfirmation reports whether a tool call deletes something: every tool
+// registered with deleteAnnotation, and modify_* tools called with an
+// operation that deletes, see operationAccess.
+func needsConfirmation(name string, annotations toolAnnotation, args map[string]any) bool {
+	return callAccess(name, annotations, args) == accessDelete
+}
+
+func (a *App) confirmationEnabled() bool {
+	return a.options.ConfirmDestructive != "" && a.options.ConfirmDestructive != ConfirmOff
+}
+
+func supportsElicitation(req *mcp.CallToolRequest) bool {
+	if req == nil || req.Session == nil {
+		return false
+	}
+	params := req.Session.InitializeParams()
+	return params != nil && params.Capabilities != nil && params.Capabilities.Elicitation != nil
+}
+
+// confirmInputID identifies the confirmation in the tool call's input
+// requests and responses.
+const confirmInputID = "confirm_destructive"
+
+// confirmStateTTL is how long the user has to answer a confirmation.
+const confirmStateTTL = 10 * time.Minute
+
+// confirmDestructive asks the user to approve a destructive tool call through
+// MCP elicitation. It returns nil when the call may proceed, otherwise the
+// result to return instead of running the tool. The outcome is what the audit
+// log records for the call.
+//
+// The question is sent as an input request on the tool result. The SDK asks
+// the client and calls the tool again with the answer in InputResponses, or,
+// for clients on protocols with multi round-trip requests, the client does so
+// itself. Either way the retry echoes the RequestState of the question, which
+// binds the answer to this tool and these arguments, so an answer sent
+// without a question from this server is rejected.
+func (a *App) confirmDestructive(ctx context.Context, name string, req *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, string) {
+	if resp, ok := req.Params.InputResponses[confirmInputID]; ok {
+		if !a.validConfirmState(req.Params.RequestState, name, args, time.Now()) {
+			a.logger.Warn("destructive tool confirmation was not issued by this server", slog.String("tool", name))
+			return errorResult(fmt.Errorf("the confirmation of %s does not match a question asked by this server. No changes were made", name)), auditNotConfirmed
+		}
+		res, ok := resp.(*mcp.ElicitResult)
+		if !ok || res.Action != "accept" || res.Content["confirm"] != true {
+			action := ""
+			if res != nil {
+				action = res.Action
+			}
+			a.logger.Info("destructive tool not confirmed by user", slog.String("tool", name), slog.String("action", action))
+			return errorResult(fmt.Errorf("the user did not confirm %s. No changes were made", name)), auditNotConfirmed
+		}
+		return nil, ""
+	}
+
+	if !supportsElicitation(req) {
+		if a.options.ConfirmDestructive == ConfirmAllow {
+			a.logger.Warn("running destructive tool without confirmation, MCP client does not support elicitation",
+				slog.String("tool", name),
+				slog.Any("arguments", maskArguments(args)))
+			return nil, auditUnconfirmedAllowed
+		}
+		return errorResult(fmt.Errorf("%s requires user confirmation, but the MCP client does not support elicitation. No changes were made", name)), auditNotConfirmed
+	}
+
+	return &mcp.CallToolResult{
+		InputRequests: mcp.InputRequestMap{
+			confirmInputID: &mcp.ElicitParams{
+				Message:         a.confirmationMessage(ctx, name, args),
+				RequestedSchema: confirmSchema,
+			},
+		},
+		RequestState: a.confirmState(name, args, time.Now().Add(confirmStateTTL)),
+	}, auditPending
+}
+
+func newConfirmKey() []byte {
+	key := make([]byte, sha256.Size)
+	_, _ = rand.Read(key)
+	return key
+}
+
+// confirmState returns the RequestState of a confirmation of name called with
+// args: when it expires and an HMAC of the expiry, tool and arguments.
+func (a *App) confirmState(name string, args map[string]any, expires time.Time) string {
+	exp := strconv.FormatInt(expires.Unix(), 10)
+	return exp + "." + base64.RawURLEncoding.EncodeToString(a.confirmMAC(exp, name, args))
+}
+
+// validConfirmState reports whether state was issued by confirmState for name
+// and args and has not expired.
+func (a *App) validConfirmState(state, name string, args map[string]any, now time.Time) bool {
+	exp, sig, ok := strings.Cut(state, ".")
+	if !ok {
+		return false
+	}
+	expires, err := strconv.ParseInt(exp, 10, 64)
+	if err != nil || now.Unix() > expires {
+		return false
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(sig)
+	if err != nil {
+		return false
+	}
+	return hmac.Equal(mac, a.confirmMAC(exp, name, args))
+}
+
+func (a *App) confirmMAC(exp, name string, args map[string]any) []byte {
+	// json.Marshal sorts map keys, so equal arguments always encode the same.
+	data, _ := json.Marshal(args)
+	mac := hmac.New(sha256.New, a.confirmKey)
+	mac.Write([]byte(exp + "\n" + name + "\n"))
+	mac.Write(data)
+	return mac.Sum(nil)
+}
+
+// confirmationMessage summarizes what the tool is about to change, including
+// the size and mapped state of the targeted volume or LUN when they can be
+// looked up.
+func (a *App) confirmationMessage(ctx context.Context, name string, args map[string]any) string {
+	cluster := stringArgument(args, "cluster_name")
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Run %s on cluster %s? This cannot be undone.\n", name, cluster)
+
+	masked := maskArguments(args)
+	keys := make([]string, 0, len(masked))
+	for k := range masked {
+		if k == "cluster_name" || k == dryRunArgument || k == asyncArgument {
+			continue
+		}
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	for _, k := range keys {
+		fmt.Fprintf(&sb, "  %s: %v\n", k, masked[k])
+	}
+
+	for _, line := range a.targetDetails(ctx, cluster, args) {
+		fmt.Fprintf(&sb, "  %s\n", line)
+	}
+	return sb.String()
+}
+
+// targetDetails looks up the current size and state of the volume or LUN
+// named in args. Lookup failures are ignored; the confirmation is still sent.
+func (a *App) targetDetails(ctx context.Context, cluster string, args map[string]any) []string {
+	svm := stringArgument(args, "svm_name")
+	volume := stringArgument(args, "volume_name")
+	if cluster == "" || svm == "" || volume == "" {
+		return nil
+	}
+	client, err := a.getClient(cluster)
+	if err != nil {
+		return nil
+	}
+
+	params := url.Values{}
+	params.Set("svm.name", svm)
+	path := "/storage/volumes"
+	if lun := stringArgument(args, "lun_name"); lun != "" {
+		path = "/storage/luns"
+		params.Set("name", lunPath(volume, lun))
+		params.Set("fields", "space.size,status.state,status.mapped")
+	} else {
+		params.Set("name", volume)
+		params.Set("fields", "size,space.used,state")
+	}
+
+	raw, err := client.GenericGet(ctx, path, params, 1)
+	if err != nil {
+		a.logger.Debug("failed to look up confirmation details", slog.String("path", path), slog.Any("error", err))
+		return nil
+	}
+	var resp struct {
+		Records []struct {
+			Size  *int64 `json:"size"`
+			State string `json:"state"`
+			Space struct {
+				Size *int64 `json:"size"`
+				Used *int64 `json:"used"`
+			} `json:"space"`
+			Status struct {
+				State  string `json:"state"`
+				Mapped *bool  `json:"mapped"`
+			} `json:"status"`
+		} `json:"records"`
+	}
+	if err := json.Unmarshal(raw, &resp); err != nil || len(resp.Records) == 0 {
+		return nil
+	}
+	r := resp.Records[0]
+
+	var lines []string
+	if r.Size != nil {
+		lines = append(lines, "size: "+formatBytes(*r.Size))
+	} else if r.Space.Size != nil {
+		lines = append(lines, "size: "+formatBytes(*r.Space.Size))
+	}
+	if r.Space.Used != nil {
+		lines = append(lines, "used: "+formatBytes(*r.Space.Used))
+	}
+	if state := cmp.Or(r.State, r.Status.State); state != "" {
+		lines = append(lines, "state: "+state)
+	}
+	if r.Status.Mapped != nil {
+		lines = append(lines, fmt.Sprintf("mapped: %t", *r.Status.Mapped))
+	}
+	return lines
+}
+
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,242 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/netapp/ontap-mcp/config"
+)
+
+func TestConfirmDestructive(t *testing.T) {
+	deleteVolume := map[string]any{"cluster_name": "dc1", "svm_name": "vs1", "volume_name": "vol1"}
+
+	tests := []struct {
+		name         string
+		policy       string
+		tool         string
+		args         map[string]any
+		responses    mcp.InputResponseMap                                // sent with the first call
+		elicit       func(*mcp.ElicitRequest) (*mcp.ElicitResult, error) // nil: client without elicitation
+		wantDeleted  bool
+		wantElicited bool
+		wantOutcome  string
+	}{
+		{
+			name:   "accepted",
+			policy: ConfirmDeny,
+			tool:   "delete_volume",
+			args:   deleteVolume,
+			elicit: func(*mcp.ElicitRequest) (*mcp.ElicitResult, error) {
+				return &mcp.ElicitResult{Action: "accept", Content: map[string]any{"confirm": true}}, nil
+			},
+			wantDeleted:  true,
+			wantElicited: true,
+			wantOutcome:  auditSuccess,
+		},
+		{
+			name:   "accepted without confirm",
+			policy: ConfirmDeny,
+			tool:   "delete_volume",
+			args:   deleteVolume,
+			elicit: func(*mcp.ElicitRequest) (*mcp.ElicitResult, error) {
+				return &mcp.ElicitResult{Action: "accept", Content: map[string]any{"confirm": false}}, nil
+			},
+			wantElicited: true,
+			wantOutcome:  auditNotConfirmed,
+		},
+		{
+			name:   "declined",
+			policy: ConfirmAllow,
+			tool:   "delete_volume",
+			args:   deleteVolume,
+			elicit: func(*mcp.ElicitRequest) (*mcp.ElicitResult, error) {
+				return &mcp.ElicitResult{Action: "decline"}, nil
+			},
+			wantElicited: true,
+			wantOutcome:  auditNotConfirmed,
+		},
+		{
+			name:        "no elicitation support, deny",
+			policy:      ConfirmDeny,
+			tool:        "delete_volume",
+			args:        deleteVolume,
+			wantOutcome: auditNotConfirmed,
+		},
+		{
+			name:        "no elicitation support, allow",
+			policy:      ConfirmAllow,
+			tool:        "delete_volume",
+			args:        deleteVolume,
+			wantDeleted: true,
+			wantOutcome: auditUnconfirmedAllowed,
+		},
+		{
+			name:        "confirmation off",
+			policy:      ConfirmOff,
+			tool:        "delete_volume",
+			args:        deleteVolume,
+			wantDeleted: true,
+			wantOutcome: auditSuccess,
+		},
+		{
+			name:   "modify with delete operation",
+			policy: ConfirmDeny,
+			tool:   "modify_volume",
+			args:   map[string]any{"cluster_name": "dc1", "svm_name": "vs1", "volume_name": "vol1", "operation": "delete"},
+			elicit: func(*mcp.ElicitRequest) (*mcp.ElicitResult, error) {
+				return &mcp.ElicitResult{Action: "cancel"}, nil
+			},
+			wantElicited: true,
+			wantOutcome:  auditNotConfirmed,
+		},
+		{
+			name:        "dry run skips confirmation",
+			policy:      ConfirmDeny,
+			tool:        "delete_volume",
+			args:        map[string]any{"cluster_name": "dc1", "svm_name": "vs1", "volume_name": "vol1", "dry_run": true},
+			wantOutcome: auditDryRun,
+		},
+		{
+			name:      "answer without a question",
+			policy:    ConfirmDeny,
+			tool:      "delete_volume",
+			args:      deleteVolume,
+			responses: mcp.InputResponseMap{confirmInputID: &mcp.ElicitResult{Action: "accept", Content: map[string]any{"confirm": true}}},
+			elicit: func(*mcp.ElicitRequest) (*mcp.ElicitResult, error) {
+				return &mcp.ElicitResult{Action: "accept", Content: map[string]any{"confirm": true}}, nil
+			},
+			wantOutcome: auditNotConfirmed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				mu       sync.Mutex
+				deleted  bool
+				messages []string
+			)
+			app := newONTAPTestApp(t, Options{ConfirmDestructive: tt.policy, ToolMode: "both"}, func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodDelete {
+					mu.Lock()
+					deleted = true
+					mu.Unlock()
+					_, _ = w.Write([]byte(`{}`))
+					return
+				}
+				_, _ = w.Write([]byte(`{"num_records":1,"records":[{"uuid":"v-uuid","size":107374182400,"state":"online"}]}`))
+			})
+
+			var opts *mcp.ClientOptions
+			if tt.elicit != nil {
+				opts = &mcp.ClientOptions{
+					ElicitationHandler: func(_ context.Context, req *mcp.ElicitRequest) (*mcp.ElicitResult, error) {
+						mu.Lock()
+						messages = append(messages, req.Params.Message)
+						mu.Unlock()
+						return tt.elicit(req)
+					},
+				}
+			}
+			auditPath := filepath.Join(t.TempDir(), "audit.log")
+			app.audit, _ = newAuditLog(&config.Audit{File: auditPath})
+			session := newTestSession(t, app, opts)
+
+			res, err := session.CallTool(t.Context(), &mcp.CallToolParams{Name: tt.tool, Arguments: tt.args, InputResponses: tt.responses})
+			if err != nil {
+				t.Fatalf("CallTool: %v", err)
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			if deleted != tt.wantDeleted {
+				t.Fatalf("deleted=%v, want %v (result: %s)", deleted, tt.wantDeleted, toolText(t, res))
+			}
+			if !tt.wantDeleted && !strings.Contains(toolText(t, res), "o changes were made") {
+				t.Fatalf("expected the result to say nothing changed, got %s", toolText(t, res))
+			}
+			if (len(messages) > 0) != tt.wantElicited {
+				t.Fatalf("elicited=%v, want %v", len(messages) > 0, tt.wantElicited)
+			}
+			data, err := os.ReadFile(auditPath)
+			if err != nil {
+				t.Fatalf("read audit log: %v", err)
+			}
+			entries := readAuditEntries(t, data)
+			if len(entries) == 0 || entries[len(entries)-1].Outcome != tt.wantOutcome {
+				t.Fatalf("audit entries %+v, want last outcome %s", entries, tt.wantOutcome)
+			}
+			if tt.wantElicited {
+				msg := messages[0]
+				for _, want := range []string{tt.tool, "dc1", "volume_name: vol1", "size: 100.0 GiB", "state: online"} {
+					if !strings.Contains(msg, want) {
+						t.Errorf("confirmation message %q does not contain %q", msg, want)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestNeedsConfirmation(t *testing.T) {
+	tests := []struct {
+		name        string
+		tool        string
+		annotations toolAnnotation
+		args        map[string]any
+		want        bool
+	}{
+		{name: "delete tool", tool: "delete_volume", annotations: deleteAnnotation, want: true},
+		{name: "update tool", tool: "update_volume", annotations: updateAnnotation},
+		{name: "create tool", tool: "create_volume", annotations: createAnnotation},
+		{name: "modify delete", tool: "modify_lun", annotations: updateAnnotation, args: map[string]any{"operation": "delete"}, want: true},
+		{name: "modify update", tool: "modify_lun", annotations: updateAnnotation, args: map[string]any{"operation": "update"}},
+		{name: "modify remove", tool: "modify_schedule_in_snapshot_policy", annotations: updateAnnotation, args: map[string]any{"operation": "remove"}, want: true},
+		{name: "modify restore", tool: "modify_snapshot", annotations: updateAnnotation, args: map[string]any{"operation": "restore"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := needsConfirmation(tt.tool, tt.annotations, tt.args); got != tt.want {
+				t.Fatalf("needsConfirmation() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfirmState(t *testing.T) {
+	app := &App{confirmKey: newConfirmKey()}
+	args := map[string]any{"cluster_name": "dc1", "svm_name": "vs1", "volume_name": "vol1"}
+	now := time.Now()
+	state := app.confirmState("delete_volume", args, now.Add(confirmStateTTL))
+
+	tests := []struct {
+		name  string
+		state string
+		tool  string
+		args  map[string]any
+		now   time.Time
+		want  bool
+	}{
+		{name: "valid", state: state, tool: "delete_volume", args: args, now: now, want: true},
+		{name: "other tool", state: state, tool: "delete_lun", args: args, now: now},
+		{name: "other arguments", state: state, tool: "delete_volume", args: map[string]any{"cluster_name": "dc1", "svm_name": "vs1", "volume_name": "vol2"}, now: now},
+		{name: "expired", state: state, tool: "delete_volume", args: args, now: now.Add(confirmStateTTL + time.Minute)},
+		{name: "empty", tool: "delete_volume", args: args, now: now},
+		{name: "tampered expiry", state: "9999999999" + state[strings.Index(state, "."):], tool: "delete_volume", args: args, now: now},
+		{name: "other key", state: (&App{confirmKey: newConfirmKey()}).confirmState("delete_volume", args, now.Add(time.Minute)), tool: "delete_volume", args: args, now: now},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := app.validConfirmState(tt.state, tt.tool, tt.args, tt.now); got != tt.want {
+				t.Fatalf("validConfirmState() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -3,66 +3,55 @@ package server
 import (
 	"context"
 	"errors"
-	"fmt"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/netapp/ontap-mcp/ontap"
 	"github.com/netapp/ontap-mcp/tool"
 )
 
-func (a *App) CreateDNS(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.DNSServiceCreate) (*mcp.CallToolResult, any, error) {
-	if !a.locks.TryLock(parameters.Cluster) {
-		return errorResult(fmt.Errorf("another write operation is in progress on cluster %s, please try again", parameters.Cluster)), nil, nil
-	}
-	defer a.locks.Unlock(parameters.Cluster)
-
+func (a *App) CreateDNS(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.DNSServiceCreate) (*mcp.CallToolResult, WriteResponse, error) {
 	dns, err := newCreateDNS(parameters)
 	if err != nil {
-		return nil, nil, err
+		return nil, WriteResponse{}, err
 	}
 
 	client, err := a.getClient(parameters.Cluster)
 	if err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), WriteResponse{}, err
 	}
 
 	err = client.CreateDNS(ctx, dns)
 	if err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), WriteResponse{}, err
 	}
 
 	return &mcp.CallToolResult{
 		Content: []mcp.Content{
 			&mcp.TextContent{Text: "DNS configuration created successfully"},
 		},
-	}, nil, nil
+	}, WriteResponse{}, nil
 }
 
-func (a *App) DeleteDNS(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.DNSServiceDelete) (*mcp.CallToolResult, any, error) {
-	if !a.locks.TryLock(parameters.Cluster) {
-		return errorResult(fmt.Errorf("another write operation is in progress on cluster %s, please try again", parameters.Cluster)), nil, nil
-	}
-	defer a.locks.Unlock(parameters.Cluster)
-
+func (a *App) DeleteDNS(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.DNSServiceDelete) (*mcp.CallToolResult, WriteResponse, error) {
 	if parameters.SVM == "" {
-		return nil, nil, errors.New("SVM name is required")
+		return nil, WriteResponse{}, errors.New("SVM name is required")
 	}
 
 	client, err := a.getClient(parameters.Cluster)
 	if err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), WriteResponse{}, err
 	}
 
 	err = client.DeleteDNS(ctx, parameters.SVM)
 	if err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), WriteResponse{}, err
 	}
 
 	return &mcp.CallToolResult{
 		Content: []mcp.Content{
 			&mcp.TextContent{Text: "DNS configuration deleted successfully"},
 		},
-	}, nil, nil
+	}, WriteResponse{}, nil
 }
 
 func newCreateDNS(in tool.DNSServiceCreate) (ontap.DNSConfig, error) {
@@ -0,0 +1,179 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/netapp/ontap-mcp/server/lock"
+	"github.com/netapp/ontap-mcp/tool"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// allClusters selects every cluster in ontap_get_multi.
+	allClusters = "all"
+	// fanOutWorkers bounds how many clusters ontap_get_multi queries at once.
+	fanOutWorkers = 8
+)
+
+// OntapGetMultiResponse is the structured output of ontap_get_multi.
+type OntapGetMultiResponse struct {
+	Clusters   []string         `json:"clusters" jsonschema:"the clusters that were queried"`
+	NumRecords int              `json:"num_records" jsonschema:"number of records returned by all clusters"`
+	Records    []map[string]any `json:"records" jsonschema:"the records of every cluster, each with a cluster_name field"`
+	Errors     []ClusterFailure `json:"errors,omitzero" jsonschema:"clusters whose query failed"`
+}
+
+func (r OntapGetMultiResponse) queriedClusters() []string {
+	return r.Clusters
+}
+
+type ClusterFailure struct {
+	Cluster string `json:"cluster_name"`
+	Error   string `json:"error"`
+}
+
+func (a *App) OntapGetMulti(ctx context.Context, req *mcp.CallToolRequest, p tool.OntapGetMultiParams) (*mcp.CallToolResult, OntapGetMultiResponse, error) {
+	path, err := resolveGetPath(p.Path, p.PathParams)
+	if err != nil {
+		return errorResult(err), OntapGetMultiResponse{}, nil
+	}
+	perms := a.permissionsFor(callExtra(req))
+	clusters, failures, err := a.selectClusters(p.Clusters, p.Datacenter, perms)
+	if err != nil {
+		return errorResult(err), OntapGetMultiResponse{}, nil
+	}
+
+	results := make([]OntapGetResponse, len(clusters))
+	errs := make([]error, len(clusters))
+	var g errgroup.Group
+	g.SetLimit(fanOutWorkers)
+	for i, cluster := range clusters {
+		g.Go(func() error {
+			results[i], errs[i] = a.fanOutGet(ctx, path, tool.OntapGetParams{
+				Cluster:    cluster,
+				Fields:     p.Fields,
+				Path:       p.Path,
+				Filters:    p.Filters,
+				MaxRecords: p.MaxRecords,
+			})
+			return nil
+		})
+	}
+	_ = g.Wait()
+
+	out := OntapGetMultiResponse{Clusters: clusters, Records: []map[string]any{}}
+	failed := 0
+	for i, cluster := range clusters {
+		if errs[i] != nil {
+			failures = append(failures, ClusterFailure{Cluster: cluster, Error: errs[i].Error()})
+			failed++
+			continue
+		}
+		for _, record := range results[i].Records {
+			record["cluster_name"] = cluster
+			out.Records = append(out.Records, record)
+		}
+	}
+	out.NumRecords = len(out.Records)
+	out.Errors = failures
+
+	if failed == len(clusters) {
+		return errorResult(fanOutError(failures)), out, nil
+	}
+	return nil, out, nil
+}
+
+// fanOutGet runs one cluster's query of ontap_get_multi under a shared lock.
+func (a *App) fanOutGet(ctx context.Context, path string, p tool.OntapGetParams) (OntapGetResponse, error) {
+	release, err := a.acquireLock(ctx, nil, lock.Key{Cluster: p.Cluster}, true)
+	if err != nil {
+		return OntapGetResponse{}, err
+	}
+	defer release()
+
+	raw, err := a.ontapGet(ctx, p, path)
+	if err != nil {
+		return OntapGetResponse{}, err
+	}
+	return newOntapGetResponse(raw), nil
+}
+
+// selectClusters returns the clusters to query, sorted, and a failure for each
+// named cluster that cannot be queried. "all" and datacenter select only the
+// enabled clusters perms allows.
+func (a *App) selectClusters(names []string, datacenter string, perms *permissions) ([]string, []ClusterFailure, error) {
+	datacenter = strings.TrimSpace(datacenter)
+	switch {
+	case len(names) == 0 && datacenter == "":
+		return nil, nil, errors.New(`clusters or datacenter is required; use ["all"] to query every cluster`)
+	case len(names) > 0 && datacenter != "":
+		return nil, nil, errors.New("set either clusters or datacenter, not both")
+	}
+
+	if datacenter != "" || slices.ContainsFunc(names, func(n string) bool { return strings.EqualFold(n, allClusters) }) {
+		if len(names) > 1 {
+			return nil, nil, fmt.Errorf("%q cannot be combined with other cluster names", allClusters)
+		}
+		clusters := a.clustersIn(datacenter, perms)
+		if len(clusters) == 0 {
+			if datacenter != "" {
+				return nil, nil, fmt.Errorf("no enabled clusters in datacenter %s", datacenter)
+			}
+			return nil, nil, errors.New("no enabled clusters are registered")
+		}
+		return clusters, nil, nil
+	}
+
+	var (
+		clusters []string
+		failures []ClusterFailure
+	)
+	for _, name := range names {
+		canonical, _, err := a.resolveCluster(name)
+		switch {
+		case err != nil:
+			failures = append(failures, ClusterFailure{Cluster: name, Error: err.Error()})
+		case !perms.allows(accessRead, canonical):
+			failures = append(failures, ClusterFailure{Cluster: canonical, Error: "not authorized: requires read access on cluster " + canonical})
+		case !slices.Contains(clusters, canonical):
+			clusters = append(clusters, canonical)
+		}
+	}
+	slices.Sort(clusters)
+	if len(clusters) == 0 {
+		return nil, nil, fanOutError(failures)
+	}
+	return clusters, failures, nil
+}
+
+// clustersIn returns the enabled clusters perms allows, sorted. An empty
+// datacenter matches every cluster.
+func (a *App) clustersIn(datacenter string, perms *permissions) []string {
+	a.cfgMu.RLock()
+	defer a.cfgMu.RUnlock()
+	var clusters []string
+	for _, name := range a.cfg.PollersOrdered {
+		p := a.cfg.Pollers[name]
+		if p == nil || p.IsDisabled || !perms.allows(accessRead, name) {
+			continue
+		}
+		if datacenter == "" || strings.EqualFold(p.Datacenter, datacenter) {
+			clusters = append(clusters, name)
+		}
+	}
+	slices.Sort(clusters)
+	return clusters
+}
+
+func fanOutError(failures []ClusterFailure) error {
+	msgs := make([]string, 0, len(failures))
+	for _, f := range failures {
+		msgs = append(msgs, f.Cluster+": "+f.Error)
+	}
+	return errors.New("no cluster could be queried: " + strings.Join(msgs, "; "))
+}
@@ -0,0 +1,171 @@
+package server
+
+import (
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/netapp/ontap-mcp/config"
+)
+
+// newFanOutTestApp returns an App with clusters a1 and a2 in datacenter east,
+// b1 in west, whose volume queries fail, and a disabled cluster c1.
+func newFanOutTestApp(t *testing.T) *App {
+	t.Helper()
+	volumes := func(name string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/api/cluster" {
+				_, _ = w.Write([]byte(`{"name":"` + name + `","version":{"generation":9,"major":16}}`))
+				return
+			}
+			if r.URL.Query().Get("space.percent_used") != ">90" {
+				t.Errorf("expected the filters to be sent to %s, got %s", name, r.URL.RawQuery)
+			}
+			_, _ = w.Write([]byte(`{"num_records":1,"records":[{"name":"` + name + `_vol","_links":{"self":{}}}]}`))
+		}
+	}
+	failing := func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error":{"message":"entry doesn't exist","code":"4"}}`))
+	}
+
+	cfg := &config.ONTAP{Pollers: map[string]*config.Poller{}}
+	var client *http.Client
+	for _, c := range []struct {
+		name, datacenter string
+		handler          http.HandlerFunc
+		disabled         bool
+	}{
+		{"a1", "east", volumes("a1"), false},
+		{"a2", "east", volumes("a2"), false},
+		{"b1", "west", failing, false},
+		{"c1", "east", failing, true},
+	} {
+		ts := httptest.NewTLSServer(c.handler)
+		t.Cleanup(ts.Close)
+		client = ts.Client()
+		cfg.Pollers[c.name] = &config.Poller{
+			Name: c.name, Addr: strings.TrimPrefix(ts.URL, "https://"), Username: "admin", Password: "secret",
+			Datacenter: c.datacenter, IsDisabled: c.disabled, Retry: config.Retry{MaxAttempts: 1},
+		}
+		cfg.PollersOrdered = append(cfg.PollersOrdered, c.name)
+	}
+	app, err := NewApp(cfg, Options{TestHTTPClient: client, ToolMode: "legacy"}, slog.Default())
+	if err != nil {
+		t.Fatalf("NewApp: %v", err)
+	}
+	return app
+}
+
+func TestOntapGetMulti(t *testing.T) {
+	session := newTestSession(t, newFanOutTestApp(t), nil)
+
+	tests := []struct {
+		name         string
+		args         map[string]any
+		wantClusters string
+		wantRecords  []string
+		wantErrors   []string
+		wantIsError  bool
+	}{
+		{
+			name:         "all",
+			args:         map[string]any{"clusters": []string{"all"}},
+			wantClusters: "a1,a2,b1",
+			wantRecords:  []string{"a1:a1_vol", "a2:a2_vol"},
+			wantErrors:   []string{"b1"},
+		},
+		{
+			name:         "datacenter",
+			args:         map[string]any{"datacenter": "EAST"},
+			wantClusters: "a1,a2",
+			wantRecords:  []string{"a1:a1_vol", "a2:a2_vol"},
+		},
+		{
+			name:         "named clusters",
+			args:         map[string]any{"clusters": []string{"A2", "missing", "c1"}},
+			wantClusters: "a2",
+			wantRecords:  []string{"a2:a2_vol"},
+			wantErrors:   []string{"missing", "c1"},
+		},
+		{
+			name:        "every cluster fails",
+			args:        map[string]any{"datacenter": "west"},
+			wantErrors:  []string{"b1"},
+			wantIsError: true,
+		},
+		{
+			name:        "no selector",
+			args:        map[string]any{},
+			wantIsError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := map[string]any{"path": "/storage/volumes", "fields": "name", "filters": map[string]string{"space.percent_used": ">90"}}
+			for k, v := range tt.args {
+				args[k] = v
+			}
+			res, err := session.CallTool(t.Context(), &mcp.CallToolParams{Name: "ontap_get_multi", Arguments: args})
+			if err != nil {
+				t.Fatalf("CallTool: %v", err)
+			}
+			if res.IsError != tt.wantIsError {
+				t.Fatalf("IsError = %v, want %v: %s", res.IsError, tt.wantIsError, toolText(t, res))
+			}
+			if tt.wantIsError {
+				return
+			}
+
+			var out OntapGetMultiResponse
+			decodeStructured(t, res, &out)
+			if got := strings.Join(out.Clusters, ","); got != tt.wantClusters {
+				t.Errorf("clusters = %s, want %s", got, tt.wantClusters)
+			}
+			records := make([]string, 0, len(out.Records))
+			for _, r := range out.Records {
+				if r["_links"] != nil {
+					t.Errorf("expected _links to be removed, got %v", r)
+				}
+				records = append(records, r["cluster_name"].(string)+":"+r["name"].(string))
+			}
+			if strings.Join(records, ",") != strings.Join(tt.wantRecords, ",") || out.NumRecords != len(tt.wantRecords) {
+				t.Errorf("records = %v (num_records %d), want %v", records, out.NumRecords, tt.wantRecords)
+			}
+			failed := make([]string, 0, len(out.Errors))
+			for _, e := range out.Errors {
+				failed = append(failed, e.Cluster)
+			}
+			if strings.Join(failed, ",") != strings.Join(tt.wantErrors, ",") {
+				t.Errorf("errors = %+v, want clusters %v", out.Errors, tt.wantErrors)
+			}
+		})
+	}
+}
+
+func TestOntapGetMultiAuditsQueriedClusters(t *testing.T) {
+	app := newFanOutTestApp(t)
+	path := filepath.Join(t.TempDir(), "audit.log")
+	app.audit, _ = newAuditLog(&config.Audit{File: path})
+	session := newTestSession(t, app, nil)
+
+	_, err := session.CallTool(t.Context(), &mcp.CallToolParams{Name: "ontap_get_multi", Arguments: map[string]any{
+		"datacenter": "east", "path": "/storage/volumes", "fields": "name", "filters": map[string]string{"space.percent_used": ">90"},
+	}})
+	if err != nil {
+		t.Fatalf("CallTool: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read audit log: %v", err)
+	}
+	entries := readAuditEntries(t, data)
+	if len(entries) != 1 || strings.Join(entries[0].Clusters, ",") != "a1,a2" {
+		t.Fatalf("expected the audit entry to list clusters a1 and a2, got %+v", entries)
+	}
+}
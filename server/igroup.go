@@ -11,106 +11,86 @@ import (
 	"github.com/netapp/ontap-mcp/tool"
 )
 
-func (a *App) CreateIGroup(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.IGroupCreate) (*mcp.CallToolResult, any, error) {
-	if !a.locks.TryLock(parameters.Cluster) {
-		return errorResult(fmt.Errorf("another write operation is in progress on cluster %s, please try again", parameters.Cluster)), nil, nil
-	}
-	defer a.locks.Unlock(parameters.Cluster)
-
+func (a *App) CreateIGroup(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.IGroupCreate) (*mcp.CallToolResult, WriteResponse, error) {
 	igroupCreate, err := newCreateIGroup(parameters)
 	if err != nil {
-		return nil, nil, err
+		return nil, WriteResponse{}, err
 	}
 
 	client, err := a.getClient(parameters.Cluster)
 	if err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), WriteResponse{}, err
 	}
 	err = client.CreateIGroup(ctx, igroupCreate)
 
 	if err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), WriteResponse{}, err
 	}
 
 	return &mcp.CallToolResult{
 		Content: []mcp.Content{
 			&mcp.TextContent{Text: "igroup created successfully"},
 		},
-	}, nil, nil
+	}, WriteResponse{}, nil
 }
 
-func (a *App) UpdateIGroup(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.IGroup) (*mcp.CallToolResult, any, error) {
-	if !a.locks.TryLock(parameters.Cluster) {
-		return errorResult(fmt.Errorf("another write operation is in progress on cluster %s, please try again", parameters.Cluster)), nil, nil
-	}
-	defer a.locks.Unlock(parameters.Cluster)
-
+func (a *App) UpdateIGroup(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.IGroup) (*mcp.CallToolResult, WriteResponse, error) {
 	igroupUpdate, err := newUpdateIGroup(parameters)
 	if err != nil {
-		return nil, nil, err
+		return nil, WriteResponse{}, err
 	}
 
 	client, err := a.getClient(parameters.Cluster)
 	if err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), WriteResponse{}, err
 	}
 	err = client.UpdateIGroup(ctx, igroupUpdate, parameters.Name, parameters.SVM)
 
 	if err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), WriteResponse{}, err
 	}
 
 	return &mcp.CallToolResult{
 		Content: []mcp.Content{
 			&mcp.TextContent{Text: "igroup updated successfully"},
 		},
-	}, nil, nil
+	}, WriteResponse{}, nil
 }
 
-func (a *App) DeleteIGroup(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.IGroup) (*mcp.CallToolResult, any, error) {
-	if !a.locks.TryLock(parameters.Cluster) {
-		return errorResult(fmt.Errorf("another write operation is in progress on cluster %s, please try again", parameters.Cluster)), nil, nil
-	}
-	defer a.locks.Unlock(parameters.Cluster)
-
+func (a *App) DeleteIGroup(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.IGroup) (*mcp.CallToolResult, WriteResponse, error) {
 	igroupDelete, err := newDeleteIGroup(parameters)
 	if err != nil {
-		return nil, nil, err
+		return nil, WriteResponse{}, err
 	}
 
 	client, err := a.getClient(parameters.Cluster)
 	if err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), WriteResponse{}, err
 	}
 	err = client.DeleteIGroup(ctx, igroupDelete, parameters.AllowDeleteWhileMapped)
 
 	if err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), WriteResponse{}, err
 	}
 
 	return &mcp.CallToolResult{
 		Content: []mcp.Content{
 			&mcp.TextContent{Text: "igroup deleted successfully"},
 		},
-	}, nil, nil
+	}, WriteResponse{}, nil
 }
 
-func (a *App) ModifyIGroup(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.IGroupModify) (*mcp.CallToolResult, any, error) {
-	if !a.locks.TryLock(parameters.Cluster) {
-		return errorResult(fmt.Errorf("another write operation is in progress on cluster %s, please try again", parameters.Cluster)), nil, nil
-	}
-	defer a.locks.Unlock(parameters.Cluster)
-
+func (a *App) ModifyIGroup(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.IGroupModify) (*mcp.CallToolResult, WriteResponse, error) {
 	if parameters.SVM == "" {
-		return nil, nil, errors.New("SVM name is required")
+		return nil, WriteResponse{}, errors.New("SVM name is required")
 	}
 	if parameters.Name == "" {
-		return nil, nil, errors.New("igroup name is required")
+		return nil, WriteResponse{}, errors.New("igroup name is required")
 	}
 
 	client, err := a.getClient(parameters.Cluster)
 	if err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), WriteResponse{}, err
 	}
 
 	switch parameters.Operation {
@@ -123,86 +103,76 @@ func (a *App) ModifyIGroup(ctx context.Context, _ *mcp.CallToolRequest, paramete
 			OSType:  parameters.IGroupUpdate.OSType,
 		})
 		if err != nil {
-			return nil, nil, err
+			return nil, WriteResponse{}, err
 		}
 
 		err = client.UpdateIGroup(ctx, rawUpdate, parameters.Name, parameters.SVM)
 		if err != nil {
-			return errorResult(err), nil, err
+			return errorResult(err), WriteResponse{}, err
 		}
 
-		return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: "igroup updated successfully"}}}, nil, nil
+		return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: "igroup updated successfully"}}}, WriteResponse{}, nil
 	case "delete":
 		rawDelete, err := newDeleteIGroup(tool.IGroup{SVM: parameters.SVM, Name: parameters.Name})
 		if err != nil {
-			return nil, nil, err
+			return nil, WriteResponse{}, err
 		}
 
 		err = client.DeleteIGroup(ctx, rawDelete, parameters.AllowDeleteWhileMapped)
 		if err != nil {
-			return errorResult(err), nil, err
+			return errorResult(err), WriteResponse{}, err
 		}
 
-		return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: "igroup deleted successfully"}}}, nil, nil
+		return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: "igroup deleted successfully"}}}, WriteResponse{}, nil
 	default:
-		return errorResult(fmt.Errorf("unsupported operation %q; supported values: update, delete", parameters.Operation)), nil, nil
+		return errorResult(fmt.Errorf("unsupported operation %q; supported values: update, delete", parameters.Operation)), WriteResponse{}, nil
 	}
 }
 
-func (a *App) AddIGroupInitiator(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.IGroupInitiator) (*mcp.CallToolResult, any, error) {
-	if !a.locks.TryLock(parameters.Cluster) {
-		return errorResult(fmt.Errorf("another write operation is in progress on cluster %s, please try again", parameters.Cluster)), nil, nil
-	}
-	defer a.locks.Unlock(parameters.Cluster)
-
+func (a *App) AddIGroupInitiator(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.IGroupInitiator) (*mcp.CallToolResult, WriteResponse, error) {
 	initiatorAdd, err := addIGroupInitiator(parameters)
 	if err != nil {
-		return nil, nil, err
+		return nil, WriteResponse{}, err
 	}
 
 	client, err := a.getClient(parameters.Cluster)
 	if err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), WriteResponse{}, err
 	}
 
 	err = client.AddIGroupInitiator(ctx, parameters.IGroupName, parameters.SVM, initiatorAdd)
 	if err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), WriteResponse{}, err
 	}
 
 	return &mcp.CallToolResult{
 		Content: []mcp.Content{
 			&mcp.TextContent{Text: "initiator added to igroup successfully"},
 		},
-	}, nil, nil
+	}, WriteResponse{}, nil
 }
 
-func (a *App) RemoveIGroupInitiator(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.IGroupInitiator) (*mcp.CallToolResult, any, error) {
-	if !a.locks.TryLock(parameters.Cluster) {
-		return errorResult(fmt.Errorf("another write operation is in progress on cluster %s, please try again", parameters.Cluster)), nil, nil
-	}
-	defer a.locks.Unlock(parameters.Cluster)
-
+func (a *App) RemoveIGroupInitiator(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.IGroupInitiator) (*mcp.CallToolResult, WriteResponse, error) {
 	initiatorRemove, err := removeIGroupInitiator(parameters)
 	if err != nil {
-		return nil, nil, err
+		return nil, WriteResponse{}, err
 	}
 
 	client, err := a.getClient(parameters.Cluster)
 	if err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), WriteResponse{}, err
 	}
 
 	err = client.RemoveIGroupInitiator(ctx, parameters.IGroupName, parameters.SVM, initiatorRemove, parameters.AllowDeleteWhileMapped)
 	if err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), WriteResponse{}, err
 	}
 
 	return &mcp.CallToolResult{
 		Content: []mcp.Content{
 			&mcp.TextContent{Text: "initiator removed from igroup successfully"},
 		},
-	}, nil, nil
+	}, WriteResponse{}, nil
 }
 
 func newCreateIGroup(in tool.IGroupCreate) (ontap.IGroup, error) {
@@ -9,25 +9,20 @@ import (
 	"github.com/netapp/ontap-mcp/tool"
 )
 
-func (a *App) CreateIscsiService(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.IscsiService) (*mcp.CallToolResult, any, error) {
-	if !a.locks.TryLock(parameters.Cluster) {
-		return errorResult(fmt.Errorf("another write operation is in progress on cluster %s, please try again", parameters.Cluster)), nil, nil
-	}
-	defer a.locks.Unlock(parameters.Cluster)
-
+func (a *App) CreateIscsiService(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.IscsiService) (*mcp.CallToolResult, WriteResponse, error) {
 	iscsiServiceCreate, err := newCreateIscsiService(parameters)
 	if err != nil {
-		return nil, nil, err
+		return nil, WriteResponse{}, err
 	}
 
 	client, err := a.getClient(parameters.Cluster)
 	if err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), WriteResponse{}, err
 	}
 	err = client.CreateIscsiService(ctx, iscsiServiceCreate)
 
 	if err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), WriteResponse{}, err
 	}
 
 	responseText := "iSCSI Service created successfully"
@@ -36,28 +31,23 @@ func (a *App) CreateIscsiService(ctx context.Context, _ *mcp.CallToolRequest, pa
 		Content: []mcp.Content{
 			&mcp.TextContent{Text: responseText},
 		},
-	}, nil, nil
+	}, WriteResponse{}, nil
 }
 
-func (a *App) UpdateIscsiService(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.IscsiService) (*mcp.CallToolResult, any, error) {
-	if !a.locks.TryLock(parameters.Cluster) {
-		return errorResult(fmt.Errorf("another write operation is in progress on cluster %s, please try again", parameters.Cluster)), nil, nil
-	}
-	defer a.locks.Unlock(parameters.Cluster)
-
+func (a *App) UpdateIscsiService(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.IscsiService) (*mcp.CallToolResult, WriteResponse, error) {
 	iscsiServiceUpdate, err := newUpdateIscsiService(parameters)
 	if err != nil {
-		return nil, nil, err
+		return nil, WriteResponse{}, err
 	}
 
 	client, err := a.getClient(parameters.Cluster)
 	if err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), WriteResponse{}, err
 	}
 	err = client.UpdateIscsiService(ctx, parameters.SVM, iscsiServiceUpdate)
 
 	if err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), WriteResponse{}, err
 	}
 
 	responseText := "iSCSI Service updated successfully"
@@ -66,27 +56,22 @@ func (a *App) UpdateIscsiService(ctx context.Context, _ *mcp.CallToolRequest, pa
 		Content: []mcp.Content{
 			&mcp.TextContent{Text: responseText},
 		},
-	}, nil, nil
+	}, WriteResponse{}, nil
 }
 
-func (a *App) DeleteIscsiService(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.IscsiService) (*mcp.CallToolResult, any, error) {
-	if !a.locks.TryLock(parameters.Cluster) {
-		return errorResult(fmt.Errorf("another write operation is in progress on cluster %s, please try again", parameters.Cluster)), nil, nil
-	}
-	defer a.locks.Unlock(parameters.Cluster)
-
+func (a *App) DeleteIscsiService(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.IscsiService) (*mcp.CallToolResult, WriteResponse, error) {
 	if err := newDeleteIscsiService(parameters); err != nil {
-		return nil, nil, err
+		return nil, WriteResponse{}, err
 	}
 
 	client, err := a.getClient(parameters.Cluster)
 	if err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), WriteResponse{}, err
 	}
 	err = client.DeleteIscsiService(ctx, parameters.SVM)
 
 	if err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), WriteResponse{}, err
 	}
 
 	responseText := "iSCSI Service deleted successfully"
@@ -95,68 +80,58 @@ func (a *App) DeleteIscsiService(ctx context.Context, _ *mcp.CallToolRequest, pa
 		Content: []mcp.Content{
 			&mcp.TextContent{Text: responseText},
 		},
-	}, nil, nil
+	}, WriteResponse{}, nil
 }
 
-func (a *App) ModifyIscsiService(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.IscsiServiceModify) (*mcp.CallToolResult, any, error) {
-	if !a.locks.TryLock(parameters.Cluster) {
-		return errorResult(fmt.Errorf("another write operation is in progress on cluster %s, please try again", parameters.Cluster)), nil, nil
-	}
-	defer a.locks.Unlock(parameters.Cluster)
-
+func (a *App) ModifyIscsiService(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.IscsiServiceModify) (*mcp.CallToolResult, WriteResponse, error) {
 	if parameters.SVM == "" {
-		return nil, nil, errors.New("SVM name is required")
+		return nil, WriteResponse{}, errors.New("SVM name is required")
 	}
 
 	client, err := a.getClient(parameters.Cluster)
 	if err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), WriteResponse{}, err
 	}
 
 	switch parameters.Operation {
 	case "update":
 		iscsiServiceUpdate, err := newUpdateIscsiService(tool.IscsiService{SVM: parameters.SVM, Enabled: parameters.IscsiServiceUpdate.Enabled})
 		if err != nil {
-			return nil, nil, err
+			return nil, WriteResponse{}, err
 		}
 
 		err = client.UpdateIscsiService(ctx, parameters.SVM, iscsiServiceUpdate)
 		if err != nil {
-			return errorResult(err), nil, err
+			return errorResult(err), WriteResponse{}, err
 		}
 
-		return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: "iSCSI Service updated successfully"}}}, nil, nil
+		return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: "iSCSI Service updated successfully"}}}, WriteResponse{}, nil
 	case "delete":
 		err = client.DeleteIscsiService(ctx, parameters.SVM)
 		if err != nil {
-			return errorResult(err), nil, err
+			return errorResult(err), WriteResponse{}, err
 		}
 
-		return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: "iSCSI Service deleted successfully"}}}, nil, nil
+		return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: "iSCSI Service deleted successfully"}}}, WriteResponse{}, nil
 	default:
-		return errorResult(fmt.Errorf("unsupported operation %q; supported values: update, delete", parameters.Operation)), nil, nil
+		return errorResult(fmt.Errorf("unsupported operation %q; supported values: update, delete", parameters.Operation)), WriteResponse{}, nil
 	}
 }
 
-func (a *App) CreateNetworkIPInterface(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.NetworkIPInterface) (*mcp.CallToolResult, any, error) {
-	if !a.locks.TryLock(parameters.Cluster) {
-		return errorResult(fmt.Errorf("another write operation is in progress on cluster %s, please try again", parameters.Cluster)), nil, nil
-	}
-	defer a.locks.Unlock(parameters.Cluster)
-
+func (a *App) CreateNetworkIPInterface(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.NetworkIPInterface) (*mcp.CallToolResult, WriteResponse, error) {
 	networkIPInterfaceCreate, err := newCreateNetworkIPInterface(parameters)
 	if err != nil {
-		return nil, nil, err
+		return nil, WriteResponse{}, err
 	}
 
 	client, err := a.getClient(parameters.Cluster)
 	if err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), WriteResponse{}, err
 	}
 	err = client.CreateNetworkIPInterface(ctx, networkIPInterfaceCreate)
 
 	if err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), WriteResponse{}, err
 	}
 
 	responseText := "Network IP interface created successfully"
@@ -165,28 +140,23 @@ func (a *App) CreateNetworkIPInterface(ctx context.Context, _ *mcp.CallToolReque
 		Content: []mcp.Content{
 			&mcp.TextContent{Text: responseText},
 		},
-	}, nil, nil
+	}, WriteResponse{}, nil
 }
 
-func (a *App) UpdateNetworkIPInterface(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.NetworkIPInterface) (*mcp.CallToolResult, any, error) {
-	if !a.locks.TryLock(parameters.Cluster) {
-		return errorResult(fmt.Errorf("another write operation is in progress on cluster %s, please try again", parameters.Cluster)), nil, nil
-	}
-	defer a.locks.Unlock(parameters.Cluster)
-
+func (a *App) UpdateNetworkIPInterface(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.NetworkIPInterface) (*mcp.CallToolResult, WriteResponse, error) {
 	networkIPInterfaceUpdate, err := newUpdateNetworkIPInterface(parameters)
 	if err != nil {
-		return nil, nil, err
+		return nil, WriteResponse{}, err
 	}
 
 	client, err := a.getClient(parameters.Cluster)
 	if err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), WriteResponse{}, err
 	}
 	err = client.UpdateNetworkIPInterface(ctx, parameters.Scope, parameters.Name, parameters.SVM, networkIPInterfaceUpdate)
 
 	if err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), WriteResponse{}, err
 	}
 
 	responseText := "Network IP interface updated successfully"
@@ -195,27 +165,22 @@ func (a *App) UpdateNetworkIPInterface(ctx context.Context, _ *mcp.CallToolReque
 		Content: []mcp.Content{
 			&mcp.TextContent{Text: responseText},
 		},
-	}, nil, nil
+	}, WriteResponse{}, nil
 }
 
-func (a *App) DeleteNetworkIPInterface(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.NetworkIPInterface) (*mcp.CallToolResult, any, error) {
-	if !a.locks.TryLock(parameters.Cluster) {
-		return errorResult(fmt.Errorf("another write operation is in progress on cluster %s, please try again", parameters.Cluster)), nil, nil
-	}
-	defer a.locks.Unlock(parameters.Cluster)
-
+func (a *App) DeleteNetworkIPInterface(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.NetworkIPInterface) (*mcp.CallToolResult, WriteResponse, error) {
 	if err := validateNwInterface(parameters.Name, parameters.Scope, parameters.SVM); err != nil {
-		return nil, nil, err
+		return nil, WriteResponse{}, err
 	}
 
 	client, err := a.getClient(parameters.Cluster)
 	if err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), WriteResponse{}, err
 	}
 	err = client.DeleteNetworkIPInterface(ctx, parameters.Scope, parameters.Name, parameters.SVM)
 
 	if err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), WriteResponse{}, err
 	}
 
 	responseText := "Network IP interface deleted successfully"
@@ -224,22 +189,17 @@ func (a *App) DeleteNetworkIPInterface(ctx context.Context, _ *mcp.CallToolReque
 		Content: []mcp.Content{
 			&mcp.TextContent{Text: responseText},
 		},
-	}, nil, nil
+	}, WriteResponse{}, nil
 }
 
-func (a *App) ModifyNetworkIPInterface(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.NetworkIPInterfaceModify) (*mcp.CallToolResult, any, error) {
-	if !a.locks.TryLock(parameters.Cluster) {
-		return errorResult(fmt.Errorf("another write operation is in progress on cluster %s, please try again", parameters.Cluster)), nil, nil
-	}
-	defer a.locks.Unlock(parameters.Cluster)
-
+func (a *App) ModifyNetworkIPInterface(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.NetworkIPInterfaceModify) (*mcp.CallToolResult, WriteResponse, error) {
 	if err := validateNwInterface(parameters.Name, parameters.Scope, parameters.SVM); err != nil {
-		return nil, nil, err
+		return nil, WriteResponse{}, err
 	}
 
 	client, err := a.getClient(parameters.Cluster)
 	if err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), WriteResponse{}, err
 	}
 
 	switch parameters.Operation {
@@ -252,24 +212,24 @@ func (a *App) ModifyNetworkIPInterface(ctx context.Context, _ *mcp.CallToolReque
 			ServicePolicy: parameters.NetworkIPInterfaceUpdate.ServicePolicy,
 		})
 		if err != nil {
-			return nil, nil, err
+			return nil, WriteResponse{}, err
 		}
 
 		err = client.UpdateNetworkIPInterface(ctx, parameters.Scope, parameters.Name, parameters.SVM, networkIPInterfaceUpdate)
 		if err != nil {
-			return errorResult(err), nil, err
+			return errorResult(err), WriteResponse{}, err
 		}
 
-		return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: "Network IP interface updated successfully"}}}, nil, nil
+		return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: "Network IP interface updated successfully"}}}, WriteResponse{}, nil
 	case "delete":
 		err = client.DeleteNetworkIPInterface(ctx, parameters.Scope, parameters.Name, parameters.SVM)
 		if err != nil {
-			return errorResult(err), nil, err
+			return errorResult(err), WriteResponse{}, err
 		}
 
-		return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: "Network IP interface deleted successfully"}}}, nil, nil
+		return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: "Network IP interface deleted successfully"}}}, WriteResponse{}, nil
 	default:
-		return errorResult(fmt.Errorf("unsupported operation %q; supported values: update, delete", parameters.Operation)), nil, nil
+		return errorResult(fmt.Errorf("unsupported operation %q; supported values: update, delete", parameters.Operation)), WriteResponse{}, nil
 	}
 }
 
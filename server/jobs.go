@@ -0,0 +1,113 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/netapp/ontap-mcp/ontap"
+	"github.com/netapp/ontap-mcp/tool"
+)
+
+const (
+	asyncArgument     = "async"
+	defaultJobsToList = 20
+	maxJobsToList     = 500
+)
+
+// asyncRequested reports whether the caller passed async: true.
+func asyncRequested(args map[string]any) bool {
+	async, _ := args[asyncArgument].(bool)
+	return async
+}
+
+// asyncResult replaces a tool's success message when ONTAP is still running
+// the change as one or more jobs.
+func asyncResult(name, cluster string, jobs []string) *mcp.CallToolResult {
+	text := fmt.Sprintf("%s was accepted by cluster %s and is still running as ONTAP job(s) %s. "+
+		"Use get_ontap_job with cluster_name %q and the job uuid to check whether it succeeded.",
+		name, cluster, strings.Join(jobs, ", "), cluster)
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: text}},
+	}
+}
+
+// jobProgressMessage is the progress notification sent for each poll of an
+// ONTAP job the tool call is waiting on.
+func jobProgressMessage(jr ontap.JobResponse) string {
+	message := strings.TrimSpace(jr.Message)
+	if message == "" {
+		message = "waiting for ONTAP job"
+	}
+	if jr.State == "" {
+		return message
+	}
+	return fmt.Sprintf("ONTAP job %s: %s", jr.State, message)
+}
+
+// JobsResponse is the structured output of list_ontap_jobs.
+type JobsResponse struct {
+	Jobs []ontap.JobResponse `json:"jobs"`
+}
+
+func (a *App) GetOntapJob(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.Job) (*mcp.CallToolResult, ontap.JobResponse, error) {
+	if parameters.UUID == "" {
+		return errorResult(errors.New("uuid is required")), ontap.JobResponse{}, nil
+	}
+
+	client, err := a.getClient(parameters.Cluster)
+	if err != nil {
+		return errorResult(err), ontap.JobResponse{}, err
+	}
+
+	job, err := client.GetJob(ctx, parameters.UUID)
+	if err != nil {
+		return errorResult(err), ontap.JobResponse{}, err
+	}
+
+	return nil, job, nil
+}
+
+func (a *App) ListOntapJobs(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.JobList) (*mcp.CallToolResult, JobsResponse, error) {
+	maxRecords := parameters.MaxRecords
+	if maxRecords <= 0 {
+		maxRecords = defaultJobsToList
+	}
+	maxRecords = min(maxRecords, maxJobsToList)
+
+	client, err := a.getClient(parameters.Cluster)
+	if err != nil {
+		return errorResult(err), JobsResponse{}, err
+	}
+
+	jobs, err := client.ListJobs(ctx, parameters.State, maxRecords)
+	if err != nil {
+		return errorResult(err), JobsResponse{}, err
+	}
+
+	return nil, JobsResponse{Jobs: jobs}, nil
+}
+
+func (a *App) CancelOntapJob(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.Job) (*mcp.CallToolResult, WriteResponse, error) {
+	if parameters.UUID == "" {
+		return errorResult(errors.New("uuid is required")), WriteResponse{}, nil
+	}
+
+	client, err := a.getClient(parameters.Cluster)
+	if err != nil {
+		return errorResult(err), WriteResponse{}, err
+	}
+
+	err = client.CancelJob(ctx, parameters.UUID)
+	if err != nil {
+		return errorResult(err), WriteResponse{}, err
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: "ONTAP job " + parameters.UUID + " cancelled successfully"},
+		},
+	}, WriteResponse{}, nil
+}
@@ -0,0 +1,116 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/netapp/ontap-mcp/server/lock"
+)
+
+// newJobTestApp answers volume deletes with job j1 and job polls with state.
+func newJobTestApp(t *testing.T, state string, polls *atomic.Int32) *App {
+	t.Helper()
+	return newONTAPTestApp(t, Options{}, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodDelete:
+			w.WriteHeader(http.StatusAccepted)
+			_, _ = w.Write([]byte(`{"job":{"uuid":"j1"}}`))
+		case r.Method == http.MethodPatch && r.URL.Path == "/api/cluster/jobs/j1":
+			_, _ = w.Write([]byte(`{}`))
+		case r.URL.Path == "/api/cluster/jobs/j1":
+			polls.Add(1)
+			_, _ = w.Write([]byte(`{"uuid":"j1","state":"` + state + `","message":"Volume vol1 deleted"}`))
+		default:
+			_, _ = w.Write([]byte(`{"num_records":1,"records":[{"uuid":"v-uuid"}]}`))
+		}
+	})
+}
+
+func TestAsyncReturnsJobHandle(t *testing.T) {
+	var polls atomic.Int32
+	app := newJobTestApp(t, "running", &polls)
+	session := newTestSession(t, app, nil)
+
+	params := deleteVolumeParams("vol1")
+	params.Arguments.(map[string]any)[asyncArgument] = true
+	res, err := session.CallTool(t.Context(), params)
+	if err != nil {
+		t.Fatalf("CallTool: %v", err)
+	}
+	text := toolText(t, res)
+	if res.IsError || !strings.Contains(text, "ONTAP job(s) j1") || !strings.Contains(text, "get_ontap_job") {
+		t.Fatalf("expected a job handle, got %s", text)
+	}
+	if got := polls.Load(); got != 0 {
+		t.Fatalf("expected the job not to be polled, got %d polls", got)
+	}
+	var out WriteResponse
+	decodeStructured(t, res, &out)
+	if !out.Running || len(out.JobUUIDs) != 1 || out.JobUUIDs[0] != "j1" {
+		t.Fatalf("expected the structured content to report running job j1, got %+v", out)
+	}
+
+	res, err = session.CallTool(t.Context(), &mcp.CallToolParams{Name: "get_ontap_job", Arguments: map[string]any{
+		"cluster_name": "dc1", "uuid": "j1",
+	}})
+	if err != nil {
+		t.Fatalf("CallTool: %v", err)
+	}
+	if text := toolText(t, res); res.IsError || !strings.Contains(text, `"state":"running"`) {
+		t.Fatalf("unexpected get_ontap_job result %s", text)
+	}
+}
+
+func TestWaitForJobSendsProgress(t *testing.T) {
+	var polls atomic.Int32
+	app := newJobTestApp(t, "success", &polls)
+	progress := make(chan *mcp.ProgressNotificationParams, 10)
+	session := newTestSession(t, app, &mcp.ClientOptions{
+		ProgressNotificationHandler: func(_ context.Context, req *mcp.ProgressNotificationClientRequest) {
+			progress <- req.Params
+		},
+	})
+
+	res, err := session.CallTool(t.Context(), deleteVolumeParams("vol1"))
+	if err != nil {
+		t.Fatalf("CallTool: %v", err)
+	}
+	if text := toolText(t, res); res.IsError || strings.Contains(text, "j1") {
+		t.Fatalf("expected the tool to wait for the job, got %s", text)
+	}
+	select {
+	case p := <-progress:
+		if p.ProgressToken != "delete-vol1" || p.Message != "ONTAP job success: Volume vol1 deleted" {
+			t.Fatalf("unexpected progress notification %+v", p)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected a progress notification from the job message")
+	}
+}
+
+func TestCancelJobIgnoresLocks(t *testing.T) {
+	var polls atomic.Int32
+	app := newJobTestApp(t, "running", &polls)
+	session := newTestSession(t, app, nil)
+
+	release, err := app.locks.Acquire(t.Context(), lock.Key{Cluster: "dc1"}, false, nil)
+	if err != nil {
+		t.Fatalf("Acquire: %v", err)
+	}
+	defer release()
+
+	res, err := session.CallTool(t.Context(), &mcp.CallToolParams{Name: "cancel_ontap_job", Arguments: map[string]any{
+		"cluster_name": "dc1", "uuid": "j1",
+	}})
+	if err != nil {
+		t.Fatalf("CallTool: %v", err)
+	}
+	if text := toolText(t, res); res.IsError || !strings.Contains(text, "cancelled") {
+		t.Fatalf("expected cancel to run while the cluster is locked, got %s", text)
+	}
+}
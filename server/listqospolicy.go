@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/netapp/ontap-mcp/server/lock"
 )
 
 type ListQoSPoliciesParams struct {
@@ -72,15 +73,18 @@ func newListQoSPolicies(p ListQoSPoliciesParams) (listQoSPoliciesArgs, error) {
 	return listQoSPoliciesArgs{cluster: p.Cluster, svmName: p.SVMName, policyType: pt}, nil
 }
 
-func (a *App) ListQoSPolicies(ctx context.Context, _ *mcp.CallToolRequest, p ListQoSPoliciesParams) (*mcp.CallToolResult, QoSPoliciesResponse, error) {
+func (a *App) ListQoSPolicies(ctx context.Context, req *mcp.CallToolRequest, p ListQoSPoliciesParams) (*mcp.CallToolResult, QoSPoliciesResponse, error) {
 	empty := QoSPoliciesResponse{}
 	args, err := newListQoSPolicies(p)
 	if err != nil {
 		return errorResult(err), empty, nil
 	}
 
-	a.locks.RLock(args.cluster)
-	defer a.locks.RUnlock(args.cluster)
+	release, err := a.acquireLock(ctx, a.newProgressReporter(ctx, req), lock.Key{Cluster: args.cluster}, true)
+	if err != nil {
+		return errorResult(err), empty, nil
+	}
+	defer release()
 
 	client, err := a.getClient(args.cluster)
 	if err != nil {
@@ -1,47 +1,183 @@
 package lock
 
 import (
+	"context"
+	"slices"
 	"strings"
 	"sync"
+
+	"github.com/netapp/ontap-mcp/metrics"
 )
 
+// Key identifies what an operation touches. An empty SVM covers the whole
+// cluster, and an SVM without Objects covers the whole SVM. Two keys on the
+// same cluster overlap unless they name different SVMs, or the same SVM and
+// disjoint Objects.
+type Key struct {
+	Cluster string
+	SVM     string
+	Objects []string
+}
+
+func (k Key) overlaps(o Key) bool {
+	if k.SVM == "" || o.SVM == "" {
+		return true
+	}
+	if !strings.EqualFold(k.SVM, o.SVM) {
+		return false
+	}
+	if len(k.Objects) == 0 || len(o.Objects) == 0 {
+		return true
+	}
+	for _, obj := range k.Objects {
+		if slices.Contains(o.Objects, obj) {
+			return true
+		}
+	}
+	return false
+}
+
+// Map queues operations per cluster. Shared holders, i.e. reads, only exclude
+// writers; writers exclude every operation whose key overlaps theirs.
+// Operations that overlap are granted in arrival order, so a stream of reads
+// cannot starve a write, while operations that do not overlap run in parallel.
 type Map struct {
-	m sync.Map
+	mu     sync.Mutex
+	queues map[string]*queue // lower-cased cluster name
 }
 
-func New() *Map {
-	return &Map{}
+type queue struct {
+	holders []*ticket
+	waiting []*ticket
+}
+
+type ticket struct {
+	key     Key
+	shared  bool
+	granted chan struct{} // closed when the ticket becomes a holder
+	moved   chan struct{} // signalled when ahead changes
+	ahead   int
+}
+
+func (t *ticket) conflicts(o *ticket) bool {
+	return !(t.shared && o.shared) && t.key.overlaps(o.key)
 }
 
-func (m *Map) getOrCreate(cluster string) *sync.RWMutex {
-	v, _ := m.m.LoadOrStore(strings.ToLower(cluster), &sync.RWMutex{})
-	return v.(*sync.RWMutex)
+func New() *Map {
+	return &Map{queues: make(map[string]*queue)}
 }
 
-func (m *Map) RLock(cluster string) {
-	if cluster == "" {
-		return
+// Acquire waits until key may be held and returns the function that releases
+// it. While waiting, onWait, if not nil, is called with the number of
+// operations ahead each time that number changes. Acquire gives up when ctx is
+// done and returns ctx.Err(). An empty cluster is never locked.
+func (m *Map) Acquire(ctx context.Context, key Key, shared bool, onWait func(ahead int)) (func(), error) {
+	if key.Cluster == "" {
+		return func() {}, nil
+	}
+	cluster := strings.ToLower(key.Cluster)
+	t := &ticket{
+		key:     key,
+		shared:  shared,
+		granted: make(chan struct{}),
+		moved:   make(chan struct{}, 1),
+	}
+
+	m.mu.Lock()
+	q := m.queues[cluster]
+	if q == nil {
+		q = &queue{}
+		m.queues[cluster] = q
+	}
+	t.ahead = q.ahead(t, len(q.waiting))
+	if t.ahead == 0 {
+		q.holders = append(q.holders, t)
+		m.mu.Unlock()
+		return m.releaser(cluster, t), nil
+	}
+	q.waiting = append(q.waiting, t)
+	ahead := t.ahead
+	m.mu.Unlock()
+
+	metrics.LockContention.WithLabelValues(cluster).Inc()
+	for {
+		if onWait != nil {
+			onWait(ahead)
+		}
+		select {
+		case <-t.granted:
+			return m.releaser(cluster, t), nil
+		case <-t.moved:
+			m.mu.Lock()
+			ahead = t.ahead
+			m.mu.Unlock()
+		case <-ctx.Done():
+			m.mu.Lock()
+			select {
+			case <-t.granted:
+				// Granted while giving up; hand it straight back.
+				q.holders = slices.DeleteFunc(q.holders, func(h *ticket) bool { return h == t })
+			default:
+				q.waiting = slices.DeleteFunc(q.waiting, func(w *ticket) bool { return w == t })
+			}
+			m.promote(cluster, q)
+			m.mu.Unlock()
+			return nil, ctx.Err()
+		}
 	}
-	m.getOrCreate(cluster).RLock()
 }
 
-func (m *Map) RUnlock(cluster string) {
-	if cluster == "" {
-		return
+func (m *Map) releaser(cluster string, t *ticket) func() {
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			m.mu.Lock()
+			defer m.mu.Unlock()
+			q := m.queues[cluster]
+			q.holders = slices.DeleteFunc(q.holders, func(h *ticket) bool { return h == t })
+			m.promote(cluster, q)
+		})
 	}
-	m.getOrCreate(cluster).RUnlock()
 }
 
-func (m *Map) TryLock(cluster string) bool {
-	if cluster == "" {
-		return true
+// ahead counts the holders and the first n waiters that conflict with t.
+func (q *queue) ahead(t *ticket, n int) int {
+	count := 0
+	for _, h := range q.holders {
+		if t.conflicts(h) {
+			count++
+		}
+	}
+	for _, w := range q.waiting[:n] {
+		if t.conflicts(w) {
+			count++
+		}
 	}
-	return m.getOrCreate(cluster).TryLock()
+	return count
 }
 
-func (m *Map) Unlock(cluster string) {
-	if cluster == "" {
-		return
+// promote grants every waiter that no longer has anything ahead of it and
+// tells the others their new position. m.mu must be held.
+func (m *Map) promote(cluster string, q *queue) {
+	for i := 0; i < len(q.waiting); {
+		w := q.waiting[i]
+		ahead := q.ahead(w, i)
+		if ahead == 0 {
+			q.waiting = slices.Delete(q.waiting, i, i+1)
+			q.holders = append(q.holders, w)
+			close(w.granted)
+			continue
+		}
+		if ahead != w.ahead {
+			w.ahead = ahead
+			select {
+			case w.moved <- struct{}{}:
+			default:
+			}
+		}
+		i++
+	}
+	if len(q.holders) == 0 && len(q.waiting) == 0 {
+		delete(m.queues, cluster)
 	}
-	m.getOrCreate(cluster).Unlock()
 }
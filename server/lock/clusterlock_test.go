@@ -0,0 +1,146 @@
+package lock
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestKeyOverlaps(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b Key
+		want bool
+	}{
+		{name: "cluster scope", a: Key{Cluster: "c1"}, b: Key{Cluster: "c1", SVM: "vs1", Objects: []string{"vol1"}}, want: true},
+		{name: "different SVMs", a: Key{Cluster: "c1", SVM: "vs1"}, b: Key{Cluster: "c1", SVM: "vs2"}},
+		{name: "SVM case", a: Key{Cluster: "c1", SVM: "VS1"}, b: Key{Cluster: "c1", SVM: "vs1", Objects: []string{"vol1"}}, want: true},
+		{name: "SVM scope", a: Key{Cluster: "c1", SVM: "vs1"}, b: Key{Cluster: "c1", SVM: "vs1", Objects: []string{"vol1"}}, want: true},
+		{name: "different objects", a: Key{Cluster: "c1", SVM: "vs1", Objects: []string{"vol1"}}, b: Key{Cluster: "c1", SVM: "vs1", Objects: []string{"vol2"}}},
+		{name: "shared object", a: Key{Cluster: "c1", SVM: "vs1", Objects: []string{"vol1", "lun1"}}, b: Key{Cluster: "c1", SVM: "vs1", Objects: []string{"vol1"}}, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.a.overlaps(tt.b); got != tt.want {
+				t.Errorf("overlaps = %v, want %v", got, tt.want)
+			}
+			if got := tt.b.overlaps(tt.a); got != tt.want {
+				t.Errorf("reverse overlaps = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func mustAcquire(t *testing.T, m *Map, key Key, shared bool) func() {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(t.Context(), time.Second)
+	defer cancel()
+	release, err := m.Acquire(ctx, key, shared, nil)
+	if err != nil {
+		t.Fatalf("Acquire(%+v): %v", key, err)
+	}
+	return release
+}
+
+// acquireAsync starts waiting for key and returns a channel that receives the
+// release function once it is granted, and one that receives queue positions.
+func acquireAsync(t *testing.T, m *Map, key Key, shared bool) (<-chan func(), <-chan int) {
+	t.Helper()
+	granted := make(chan func(), 1)
+	positions := make(chan int, 10)
+	go func() {
+		release, err := m.Acquire(t.Context(), key, shared, func(ahead int) { positions <- ahead })
+		if err == nil {
+			granted <- release
+		}
+	}()
+	return granted, positions
+}
+
+func expectPosition(t *testing.T, positions <-chan int, want int) {
+	t.Helper()
+	select {
+	case got := <-positions:
+		if got != want {
+			t.Fatalf("position = %d, want %d", got, want)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("expected position %d", want)
+	}
+}
+
+func expectGranted(t *testing.T, granted <-chan func()) func() {
+	t.Helper()
+	select {
+	case release := <-granted:
+		return release
+	case <-time.After(time.Second):
+		t.Fatal("expected the lock to be granted")
+		return nil
+	}
+}
+
+func expectWaiting(t *testing.T, granted <-chan func()) {
+	t.Helper()
+	select {
+	case <-granted:
+		t.Fatal("expected the lock to still be waiting")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestAcquire_ParallelOnDifferentObjects(t *testing.T) {
+	m := New()
+	r1 := mustAcquire(t, m, Key{Cluster: "c1", SVM: "vs1", Objects: []string{"vol1"}}, false)
+	r2 := mustAcquire(t, m, Key{Cluster: "c1", SVM: "vs1", Objects: []string{"vol2"}}, false)
+	r3 := mustAcquire(t, m, Key{Cluster: "c1", SVM: "vs2"}, false)
+	r4 := mustAcquire(t, m, Key{Cluster: "c2"}, false)
+	r1()
+	r2()
+	r3()
+	r4()
+	if len(m.queues) != 0 {
+		t.Fatalf("expected all queues to be removed, got %d", len(m.queues))
+	}
+}
+
+func TestAcquire_FIFO(t *testing.T) {
+	m := New()
+	release := mustAcquire(t, m, Key{Cluster: "c1"}, false)
+
+	writer, writerPos := acquireAsync(t, m, Key{Cluster: "c1", SVM: "vs1"}, false)
+	expectPosition(t, writerPos, 1)
+	reader, readerPos := acquireAsync(t, m, Key{Cluster: "c1"}, true)
+	expectPosition(t, readerPos, 2)
+
+	// The reader arrived after the writer it conflicts with, so it keeps waiting.
+	release()
+	releaseWriter := expectGranted(t, writer)
+	expectPosition(t, readerPos, 1)
+	expectWaiting(t, reader)
+
+	releaseWriter()
+	expectGranted(t, reader)()
+
+	// Readers share the lock.
+	r1 := mustAcquire(t, m, Key{Cluster: "c1"}, true)
+	r2 := mustAcquire(t, m, Key{Cluster: "c1"}, true)
+	r1()
+	r2()
+}
+
+func TestAcquire_GivesUpWhenContextDone(t *testing.T) {
+	m := New()
+	release := mustAcquire(t, m, Key{Cluster: "c1", SVM: "vs1"}, false)
+
+	ctx, cancel := context.WithTimeout(t.Context(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := m.Acquire(ctx, Key{Cluster: "c1", SVM: "vs1"}, false, nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected DeadlineExceeded, got %v", err)
+	}
+
+	// The abandoned request must not block those behind it.
+	release()
+	mustAcquire(t, m, Key{Cluster: "c1", SVM: "vs1"}, false)()
+}
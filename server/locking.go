@@ -0,0 +1,93 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/netapp/ontap-mcp/metrics"
+	"github.com/netapp/ontap-mcp/server/lock"
+)
+
+// DefaultLockTimeout bounds how long a tool call waits for other operations on
+// the same cluster, SVM or object when Options.LockTimeout is not set.
+const DefaultLockTimeout = 2 * time.Minute
+
+// lockObjectArguments are the tool arguments that name the object a mutating
+// tool changes. Writes on the same SVM only wait for each other when they share
+// one of these values, so two volumes can be created in parallel. Values are
+// compared without regard to the kind of object; a volume and a qtree with the
+// same name queue behind each other, which is safe.
+var lockObjectArguments = []string{
+	"name", "new_name",
+	"volume_name", "new_volume_name",
+	"lun_name", "new_lun_name",
+	"igroup_name", "policy_name", "namespace_name", "subsystem_name",
+}
+
+// unlockedTools never wait for a lock. cancel_ontap_job must be able to
+// cancel the job another tool call is waiting on while holding its lock.
+var unlockedTools = map[string]bool{"cancel_ontap_job": true}
+
+// lockKey is what a mutating tool call locks: the SVM and objects named in its
+// arguments, or the whole cluster when it names no SVM.
+func lockKey(args map[string]any) lock.Key {
+	key := lock.Key{
+		Cluster: stringArgument(args, "cluster_name"),
+		SVM:     stringArgument(args, "svm_name"),
+	}
+	if key.SVM == "" {
+		return key
+	}
+	for _, name := range lockObjectArguments {
+		if v := stringArgument(args, name); v != "" {
+			key.Objects = append(key.Objects, v)
+		}
+	}
+	return key
+}
+
+func (a *App) lockTimeout() time.Duration {
+	if a.options.LockTimeout > 0 {
+		return a.options.LockTimeout
+	}
+	return DefaultLockTimeout
+}
+
+// acquireLock waits for key and returns the function that releases it. While
+// the call is queued, its position is sent to progress.
+func (a *App) acquireLock(ctx context.Context, progress *progressReporter, key lock.Key, shared bool) (func(), error) {
+	timeout := a.lockTimeout()
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var first, last int
+	release, err := a.locks.Acquire(waitCtx, key, shared, func(ahead int) {
+		if first == 0 {
+			first = ahead
+		}
+		last = ahead
+		progress.report(float64(first-ahead), float64(first), fmt.Sprintf("Waiting for %d other operation(s) on %s to finish", ahead, lockScope(key)))
+	})
+	if err == nil {
+		return release, nil
+	}
+	if errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil {
+		metrics.LockTimeouts.WithLabelValues(strings.ToLower(key.Cluster)).Inc()
+		return nil, fmt.Errorf("timed out after %s waiting for %d other operation(s) on %s to finish. No changes were made, try again later", timeout, last, lockScope(key))
+	}
+	return nil, err
+}
+
+func lockScope(key lock.Key) string {
+	scope := "cluster " + key.Cluster
+	if key.SVM != "" {
+		scope += ", SVM " + key.SVM
+	}
+	if len(key.Objects) > 0 {
+		scope += ", " + strings.Join(key.Objects, ", ")
+	}
+	return scope
+}
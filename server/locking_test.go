@@ -0,0 +1,137 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/netapp/ontap-mcp/server/lock"
+)
+
+func TestLockKey(t *testing.T) {
+	tests := []struct {
+		name string
+		args map[string]any
+		want lock.Key
+	}{
+		{
+			name: "cluster scope without SVM",
+			args: map[string]any{"cluster_name": "dc1", "name": "policy1"},
+			want: lock.Key{Cluster: "dc1"},
+		},
+		{
+			name: "SVM scope",
+			args: map[string]any{"cluster_name": "dc1", "svm_name": "vs1"},
+			want: lock.Key{Cluster: "dc1", SVM: "vs1"},
+		},
+		{
+			name: "objects",
+			args: map[string]any{"cluster_name": "dc1", "svm_name": "vs1", "volume_name": "vol1", "new_volume_name": "vol2", "size": "10GB"},
+			want: lock.Key{Cluster: "dc1", SVM: "vs1", Objects: []string{"vol1", "vol2"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := lockKey(tt.args)
+			if got.Cluster != tt.want.Cluster || got.SVM != tt.want.SVM || !slices.Equal(got.Objects, tt.want.Objects) {
+				t.Errorf("lockKey() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func newLockTestApp(t *testing.T, opts Options) *App {
+	t.Helper()
+	return newONTAPTestApp(t, opts, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			_, _ = w.Write([]byte(`{}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"num_records":1,"records":[{"uuid":"v-uuid"}]}`))
+	})
+}
+
+func deleteVolumeParams(volume string) *mcp.CallToolParams {
+	params := &mcp.CallToolParams{Name: "delete_volume", Arguments: map[string]any{
+		"cluster_name": "dc1", "svm_name": "vs1", "volume_name": volume,
+	}}
+	params.SetProgressToken("delete-" + volume)
+	return params
+}
+
+func TestWriteQueuesBehindConflictingWrite(t *testing.T) {
+	app := newLockTestApp(t, Options{})
+	progress := make(chan *mcp.ProgressNotificationParams, 10)
+	session := newTestSession(t, app, &mcp.ClientOptions{
+		ProgressNotificationHandler: func(_ context.Context, req *mcp.ProgressNotificationClientRequest) {
+			progress <- req.Params
+		},
+	})
+
+	release, err := app.locks.Acquire(t.Context(), lock.Key{Cluster: "dc1", SVM: "vs1", Objects: []string{"vol1"}}, false, nil)
+	if err != nil {
+		t.Fatalf("Acquire: %v", err)
+	}
+
+	// A different volume on the same SVM is not blocked.
+	res, err := session.CallTool(t.Context(), deleteVolumeParams("vol2"))
+	if err != nil || res.IsError {
+		t.Fatalf("expected delete of vol2 to run in parallel, got %v %s", err, toolText(t, res))
+	}
+
+	done := make(chan *mcp.CallToolResult, 1)
+	go func() {
+		res, err := session.CallTool(t.Context(), deleteVolumeParams("vol1"))
+		if err != nil {
+			t.Errorf("CallTool: %v", err)
+		}
+		done <- res
+	}()
+
+	select {
+	case p := <-progress:
+		if p.ProgressToken != "delete-vol1" || !strings.Contains(p.Message, "Waiting for 1 other operation(s)") {
+			t.Fatalf("unexpected progress notification %+v", p)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected a queue position progress notification")
+	}
+	select {
+	case <-done:
+		t.Fatal("expected delete of vol1 to wait for the lock")
+	default:
+	}
+
+	release()
+	select {
+	case res := <-done:
+		if res == nil || res.IsError {
+			t.Fatalf("expected queued delete to succeed, got %s", toolText(t, res))
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected the queued delete to run once the lock was released")
+	}
+}
+
+func TestWriteLockTimeout(t *testing.T) {
+	app := newLockTestApp(t, Options{LockTimeout: 50 * time.Millisecond})
+	session := newTestSession(t, app, nil)
+
+	release, err := app.locks.Acquire(t.Context(), lock.Key{Cluster: "dc1"}, false, nil)
+	if err != nil {
+		t.Fatalf("Acquire: %v", err)
+	}
+	defer release()
+
+	res, err := session.CallTool(t.Context(), deleteVolumeParams("vol1"))
+	if err != nil {
+		t.Fatalf("CallTool: %v", err)
+	}
+	if text := toolText(t, res); !res.IsError || !strings.Contains(text, "timed out after 50ms waiting for 1 other operation(s) on cluster dc1") {
+		t.Fatalf("expected a lock timeout, got %s", text)
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/modelcontextprotocol/go-sdk/auth"
 	"github.com/netapp/ontap-mcp/config"
+	"github.com/netapp/ontap-mcp/metrics"
 	"io"
 	"log/slog"
 	"math/big"
@@ -293,6 +294,7 @@ func (a *App) refreshJWKS() error {
 			return nil, nil
 		}
 		if err := a.fetchAndCacheJWKS(); err != nil {
+			metrics.JWKSRefreshFailures.Inc()
 			return nil, err
 		}
 		return nil, nil
@@ -53,3 +53,24 @@ func TestResourceMetadataURLUsesHTTPSForTLSRequests(t *testing.T) {
 		t.Fatalf("unexpected metadata URL\nwant: %s\ngot:  %s", want, got)
 	}
 }
+
+func TestMetricsRequireBearerTokenWithOAuth(t *testing.T) {
+	tests := []struct {
+		name         string
+		oauthEnabled bool
+		wantCode     int
+	}{
+		{name: "oauth", oauthEnabled: true, wantCode: http.StatusUnauthorized},
+		{name: "no oauth", oauthEnabled: false, wantCode: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := &App{logger: slog.Default(), cfg: &config.ONTAP{}, oauthEnabled: tt.oauthEnabled}
+			resp := httptest.NewRecorder()
+			app.metricsHandler().ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "http://mcp.example.test/metrics", http.NoBody))
+			if resp.Code != tt.wantCode {
+				t.Fatalf("expected %d, got %d", tt.wantCode, resp.Code)
+			}
+		})
+	}
+}
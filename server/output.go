@@ -0,0 +1,45 @@
+package server
+
+import (
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/netapp/ontap-mcp/rest"
+)
+
+// WriteResponse is the structured output of the tools that change a cluster.
+// Handlers return it empty; addTool fills it in from the text of the result,
+// the ONTAP jobs the call started and the objects ONTAP created.
+type WriteResponse struct {
+	Message  string          `json:"message" jsonschema:"what the call did"`
+	Objects  []CreatedObject `json:"objects,omitzero" jsonschema:"objects ONTAP created, with their UUID and name"`
+	JobUUIDs []string        `json:"job_uuids,omitzero" jsonschema:"UUIDs of the ONTAP jobs the call started"`
+	Running  bool            `json:"running,omitzero" jsonschema:"true when async was set and the jobs are still running; follow them with get_ontap_job"`
+	DryRun   bool            `json:"dry_run,omitzero" jsonschema:"true when no changes were made because of dry_run"`
+}
+
+type CreatedObject struct {
+	UUID string `json:"uuid,omitzero" jsonschema:"UUID of the object"`
+	Name string `json:"name,omitzero" jsonschema:"name of the object"`
+}
+
+// writeOutput returns out with the WriteResponse of a successful or planned
+// call filled in. Outputs of any other type are returned unchanged.
+func writeOutput[Out any](out Out, res *mcp.CallToolResult, jobs *rest.Jobs, created *rest.Created, dryRun bool) Out {
+	w, ok := any(&out).(*WriteResponse)
+	if !ok {
+		return out
+	}
+	if w.Message == "" && res != nil {
+		w.Message = resultText(res)
+	}
+	w.DryRun = dryRun
+	if jobs != nil {
+		w.JobUUIDs = jobs.UUIDs()
+		w.Running = len(jobs.Started()) > 0
+	}
+	if created != nil {
+		for _, o := range created.Objects() {
+			w.Objects = append(w.Objects, CreatedObject(o))
+		}
+	}
+	return out
+}
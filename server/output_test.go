@@ -0,0 +1,109 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestNewOntapGetResponse(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want OntapGetResponse
+	}{
+		{
+			name: "collection",
+			raw:  `{"num_records":2,"records":[{"name":"vol1"},{"name":"vol2"}]}`,
+			want: OntapGetResponse{NumRecords: 2, Records: []map[string]any{{"name": "vol1"}, {"name": "vol2"}}},
+		},
+		{
+			name: "empty collection",
+			raw:  `{"num_records":0,"records":[]}`,
+			want: OntapGetResponse{Records: []map[string]any{}},
+		},
+		{
+			name: "single object",
+			raw:  `{"name":"cluster1","version":{"full":"9.16.1"}}`,
+			want: OntapGetResponse{NumRecords: 1, Records: []map[string]any{{"name": "cluster1", "version": map[string]any{"full": "9.16.1"}}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := newOntapGetResponse(json.RawMessage(tt.raw)); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("newOntapGetResponse() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStructuredOutput(t *testing.T) {
+	app := newONTAPTestApp(t, Options{}, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost:
+			w.WriteHeader(http.StatusAccepted)
+			_, _ = w.Write([]byte(`{"job":{"uuid":"j1"},"num_records":1,"records":[{"uuid":"s-uuid","name":"snap1"}]}`))
+		case r.URL.Path == "/api/cluster/jobs/j1":
+			_, _ = w.Write([]byte(`{"uuid":"j1","state":"success"}`))
+		default:
+			_, _ = w.Write([]byte(`{"num_records":1,"records":[{"uuid":"v-uuid","name":"vol1","_links":{"self":{}}}]}`))
+		}
+	})
+	session := newTestSession(t, app, nil)
+
+	tools, err := session.ListTools(t.Context(), nil)
+	if err != nil {
+		t.Fatalf("ListTools: %v", err)
+	}
+	for _, tool := range tools.Tools {
+		if tool.OutputSchema == nil && (tool.Name == "create_snapshot" || tool.Name == "ontap_get" || tool.Name == "list_registered_clusters") {
+			t.Errorf("expected %s to publish an output schema", tool.Name)
+		}
+	}
+
+	res, err := session.CallTool(t.Context(), &mcp.CallToolParams{Name: "create_snapshot", Arguments: map[string]any{
+		"cluster_name": "dc1", "svm_name": "vs1", "volume_name": "vol1", "name": "snap1",
+	}})
+	if err != nil {
+		t.Fatalf("CallTool: %v", err)
+	}
+	var write WriteResponse
+	decodeStructured(t, res, &write)
+	want := WriteResponse{
+		Message:  "Snapshot created successfully",
+		Objects:  []CreatedObject{{UUID: "s-uuid", Name: "snap1"}},
+		JobUUIDs: []string{"j1"},
+	}
+	if !reflect.DeepEqual(write, want) {
+		t.Fatalf("structured content = %+v, want %+v", write, want)
+	}
+
+	res, err = session.CallTool(t.Context(), &mcp.CallToolParams{Name: "ontap_get", Arguments: map[string]any{
+		"cluster_name": "dc1", "path": "/storage/volumes",
+	}})
+	if err != nil {
+		t.Fatalf("CallTool: %v", err)
+	}
+	var get OntapGetResponse
+	decodeStructured(t, res, &get)
+	if get.NumRecords != 1 || get.Records[0]["uuid"] != "v-uuid" || get.Records[0]["_links"] != nil {
+		t.Fatalf("unexpected ontap_get structured content %+v", get)
+	}
+}
+
+func decodeStructured(t *testing.T, res *mcp.CallToolResult, v any) {
+	t.Helper()
+	if res.IsError {
+		t.Fatalf("unexpected tool error: %s", toolText(t, res))
+	}
+	data, err := json.Marshal(res.StructuredContent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		t.Fatalf("failed to decode structured content %s: %v", data, err)
+	}
+}
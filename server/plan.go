@@ -0,0 +1,64 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/netapp/ontap-mcp/rest"
+)
+
+const dryRunArgument = "dry_run"
+
+// addMutatingArguments adds the dry_run and async arguments to a mutating
+// tool's input schema. The tool's own parameter struct never sees them;
+// safeHandler reads them from the raw arguments instead.
+func addMutatingArguments[In any](tt *mcp.Tool) error {
+	schema, err := jsonschema.For[In](nil)
+	if err != nil {
+		return err
+	}
+	if schema.Properties == nil {
+		schema.Properties = make(map[string]*jsonschema.Schema)
+	}
+	schema.Properties[dryRunArgument] = &jsonschema.Schema{
+		Type:        "boolean",
+		Description: "If true, resolve names and validate inputs but do not change anything. Returns the ONTAP REST calls that would be made.",
+	}
+	schema.Properties[asyncArgument] = &jsonschema.Schema{
+		Type:        "boolean",
+		Description: "If true, return as soon as ONTAP accepts the change, with the UUIDs of any ONTAP jobs still running it, instead of waiting for them to finish. Follow the jobs with get_ontap_job.",
+	}
+	tt.InputSchema = schema
+	return nil
+}
+
+// dryRunRequested reports whether the caller passed dry_run: true.
+func dryRunRequested(args map[string]any) bool {
+	dryRun, _ := args[dryRunArgument].(bool)
+	return dryRun
+}
+
+// planResult describes the captured requests instead of the tool's usual
+// success message.
+func planResult(plan *rest.Plan) (*mcp.CallToolResult, error) {
+	requests := plan.Requests()
+	if len(requests) == 0 {
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{&mcp.TextContent{Text: "Dry run: no changes were made and no ONTAP requests would be sent."}},
+		}, nil
+	}
+
+	data, err := json.MarshalIndent(requests, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Dry run: no changes were made. The following %d ONTAP REST request(s) would be sent:\n", len(requests))
+	sb.Write(data)
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: sb.String()}},
+	}, nil
+}
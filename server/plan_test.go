@@ -0,0 +1,196 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/netapp/ontap-mcp/config"
+)
+
+// newTestSession serves app over an in-memory transport and returns a
+// connected client session.
+func newTestSession(t *testing.T, app *App, opts *mcp.ClientOptions) *mcp.ClientSession {
+	t.Helper()
+	ctx, cancel := context.WithCancel(t.Context())
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- app.serve(ctx, app.createMCPServer(), serverTransport)
+	}()
+
+	client := mcp.NewClient(&mcp.Implementation{Name: "test", Version: "v0"}, opts)
+	session, err := client.Connect(ctx, clientTransport, nil)
+	if err != nil {
+		cancel()
+		t.Fatalf("client connect: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = session.Close()
+		cancel()
+		<-errCh
+	})
+	return session
+}
+
+// newONTAPTestApp returns an App with one cluster, dc1, backed by handler.
+func newONTAPTestApp(t *testing.T, opts Options, handler http.HandlerFunc) *App {
+	t.Helper()
+	ts := httptest.NewTLSServer(handler)
+	t.Cleanup(ts.Close)
+
+	cfg := &config.ONTAP{
+		Pollers: map[string]*config.Poller{
+			"dc1": {Name: "dc1", Addr: strings.TrimPrefix(ts.URL, "https://"), Username: "admin", Password: "secret"},
+		},
+		PollersOrdered: []string{"dc1"},
+	}
+	opts.TestHTTPClient = ts.Client()
+	if opts.ToolMode == "" {
+		opts.ToolMode = "legacy"
+	}
+	app, err := NewApp(cfg, opts, slog.Default())
+	if err != nil {
+		t.Fatalf("NewApp: %v", err)
+	}
+	return app
+}
+
+func toolText(t *testing.T, res *mcp.CallToolResult) string {
+	t.Helper()
+	var sb strings.Builder
+	for _, c := range res.Content {
+		if tc, ok := c.(*mcp.TextContent); ok {
+			sb.WriteString(tc.Text)
+		}
+	}
+	return sb.String()
+}
+
+func TestDryRunCapturesMutatingRequests(t *testing.T) {
+	tests := []struct {
+		name       string
+		plan       bool
+		args       map[string]any
+		wantSent   bool
+		wantInText string
+	}{
+		{
+			name:       "dry_run argument",
+			args:       map[string]any{"cluster_name": "dc1", "svm_name": "vs1", "volume_name": "vol1", "dry_run": true},
+			wantInText: `"method": "DELETE"`,
+		},
+		{
+			name:       "server wide plan mode",
+			plan:       true,
+			args:       map[string]any{"cluster_name": "dc1", "svm_name": "vs1", "volume_name": "vol1"},
+			wantInText: "/api/storage/volumes/v-uuid",
+		},
+		{
+			name:       "dry_run false executes",
+			args:       map[string]any{"cluster_name": "dc1", "svm_name": "vs1", "volume_name": "vol1", "dry_run": false},
+			wantSent:   true,
+			wantInText: "Volume deleted successfully",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				mu   sync.Mutex
+				sent []string
+			)
+			app := newONTAPTestApp(t, Options{Plan: tt.plan}, func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodGet {
+					mu.Lock()
+					sent = append(sent, r.Method+" "+r.URL.Path)
+					mu.Unlock()
+					w.WriteHeader(http.StatusOK)
+					_, _ = w.Write([]byte(`{}`))
+					return
+				}
+				_, _ = w.Write([]byte(`{"num_records":1,"records":[{"uuid":"v-uuid"}]}`))
+			})
+			session := newTestSession(t, app, nil)
+
+			res, err := session.CallTool(t.Context(), &mcp.CallToolParams{Name: "delete_volume", Arguments: tt.args})
+			if err != nil {
+				t.Fatalf("CallTool: %v", err)
+			}
+			text := toolText(t, res)
+			if res.IsError {
+				t.Fatalf("unexpected tool error: %s", text)
+			}
+			if !strings.Contains(text, tt.wantInText) {
+				t.Fatalf("expected result to contain %q, got %s", tt.wantInText, text)
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			if tt.wantSent != (len(sent) > 0) {
+				t.Fatalf("wantSent=%v but ONTAP received %v", tt.wantSent, sent)
+			}
+		})
+	}
+}
+
+func TestDryRunArgumentOnlyOnMutatingTools(t *testing.T) {
+	app := newONTAPTestApp(t, Options{}, func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	session := newTestSession(t, app, nil)
+
+	tools, err := session.ListTools(t.Context(), nil)
+	if err != nil {
+		t.Fatalf("ListTools: %v", err)
+	}
+	for _, tool := range tools.Tools {
+		schema, ok := tool.InputSchema.(map[string]any)
+		if !ok {
+			t.Fatalf("tool %s has unexpected input schema type %T", tool.Name, tool.InputSchema)
+		}
+		props, _ := schema["properties"].(map[string]any)
+		_, hasDryRun := props[dryRunArgument]
+		if tool.Annotations.ReadOnlyHint == hasDryRun {
+			t.Errorf("tool %s: readOnly=%v but has dry_run=%v", tool.Name, tool.Annotations.ReadOnlyHint, hasDryRun)
+		}
+		_, hasAsync := props[asyncArgument]
+		if tool.Annotations.ReadOnlyHint == hasAsync {
+			t.Errorf("tool %s: readOnly=%v but has async=%v", tool.Name, tool.Annotations.ReadOnlyHint, hasAsync)
+		}
+	}
+}
+
+func TestDryRunMasksSecrets(t *testing.T) {
+	app := newONTAPTestApp(t, Options{Plan: true}, func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"num_records":1,"records":[{"uuid":"svm-uuid"}]}`))
+	})
+	session := newTestSession(t, app, nil)
+
+	res, err := session.CallTool(t.Context(), &mcp.CallToolParams{Name: "create_cifs_service", Arguments: map[string]any{
+		"cluster_name":     "dc1",
+		"svm_name":         "vs1",
+		"cifs_server_name": "CIFS1",
+		"ad_domain":        "example.com",
+		"ad_user":          "admin",
+		"ad_password":      "hunter2",
+	}})
+	if err != nil {
+		t.Fatalf("CallTool: %v", err)
+	}
+	text := toolText(t, res)
+	if res.IsError {
+		t.Fatalf("unexpected tool error: %s", text)
+	}
+	if !strings.Contains(text, `"method": "POST"`) {
+		t.Fatalf("expected a captured POST, got %s", text)
+	}
+	if strings.Contains(text, "hunter2") {
+		t.Fatalf("dry run result leaks the password: %s", text)
+	}
+}
@@ -0,0 +1,57 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// progressReporter sends MCP progress notifications for a tool call. It is nil,
+// and reporting is a no-op, when the client did not send a progress token.
+type progressReporter struct {
+	ctx     context.Context
+	session *mcp.ServerSession
+	token   any
+	logger  *slog.Logger
+	last    float64
+}
+
+func (a *App) newProgressReporter(ctx context.Context, req *mcp.CallToolRequest) *progressReporter {
+	if req == nil || req.Session == nil || req.Params == nil {
+		return nil
+	}
+	token := req.Params.GetProgressToken()
+	if token == nil {
+		return nil
+	}
+	return &progressReporter{ctx: ctx, session: req.Session, token: token, logger: a.logger}
+}
+
+// report sends progress out of total, which may be 0 when unknown. Progress
+// never goes backwards, as the MCP specification requires.
+func (p *progressReporter) report(progress, total float64, message string) {
+	if p == nil {
+		return
+	}
+	progress = max(progress, p.last)
+	p.last = progress
+	err := p.session.NotifyProgress(p.ctx, &mcp.ProgressNotificationParams{
+		ProgressToken: p.token,
+		Progress:      progress,
+		Total:         total,
+		Message:       message,
+	})
+	if err != nil {
+		p.logger.Debug("failed to send progress notification", slog.Any("error", err))
+	}
+}
+
+// step advances progress by one with an unknown total, for work whose length
+// is not known up front, such as waiting for an ONTAP job.
+func (p *progressReporter) step(message string) {
+	if p == nil {
+		return
+	}
+	p.report(p.last+1, 0, message)
+}
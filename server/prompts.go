@@ -0,0 +1,206 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/goccy/go-yaml"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/netapp/ontap-mcp/config"
+	"github.com/netapp/ontap-mcp/descriptions"
+)
+
+// promptDefinition is a runbook prompt. Operator templates use the same
+// layout in YAML.
+type promptDefinition struct {
+	Name        string           `yaml:"name"`
+	Title       string           `yaml:"title,omitempty"`
+	Description string           `yaml:"description,omitempty"`
+	Arguments   []promptArgument `yaml:"arguments,omitempty"`
+	Template    string           `yaml:"template"`
+}
+
+type promptArgument struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description,omitempty"`
+	Required    bool   `yaml:"required,omitempty"`
+}
+
+var (
+	clusterPromptArgument = promptArgument{Name: "cluster_name", Description: "cluster name, from list_registered_clusters", Required: true}
+	svmPromptArgument     = promptArgument{Name: "svm_name", Description: "SVM name", Required: true}
+	volumePromptArgument  = promptArgument{Name: "volume_name", Description: "volume name", Required: true}
+)
+
+// builtinPrompts are the runbooks every server offers.
+var builtinPrompts = []promptDefinition{
+	{
+		Name:        "provision_nfs_share",
+		Title:       "Provision an NFS share",
+		Description: "Create a volume with an export policy and junction path, and return the mount command.",
+		Arguments: []promptArgument{
+			clusterPromptArgument, svmPromptArgument, volumePromptArgument,
+			{Name: "size", Description: "volume size, e.g. 100GB"},
+			{Name: "clients", Description: "clients allowed to mount the share, e.g. 10.0.0.0/24"},
+		},
+		Template: descriptions.PromptProvisionNFSShare,
+	},
+	{
+		Name:        "map_lun_to_esxi_host",
+		Title:       "Map a LUN to a new ESXi host",
+		Description: "Add an ESXi host's initiator to an igroup and map an existing LUN to it.",
+		Arguments: []promptArgument{
+			clusterPromptArgument, svmPromptArgument,
+			{Name: "lun_name", Description: "LUN path, e.g. /vol/vol1/lun1", Required: true},
+			{Name: "initiator_name", Description: "iSCSI IQN or FC WWPN of the ESXi host", Required: true},
+			{Name: "igroup_name", Description: "igroup to add the host to"},
+		},
+		Template: descriptions.PromptMapLUNToESXiHost,
+	},
+	{
+		Name:        "setup_snapmirror_dr",
+		Title:       "Set up SnapMirror DR for a volume",
+		Description: "Create a data protection volume on a peered cluster, and create and initialize a SnapMirror relationship to it.",
+		Arguments: []promptArgument{
+			clusterPromptArgument, svmPromptArgument, volumePromptArgument,
+			{Name: "destination_cluster_name", Description: "cluster to replicate to"},
+			{Name: "destination_svm_name", Description: "SVM to replicate to"},
+			{Name: "policy_name", Description: "SnapMirror policy, MirrorAllSnapshots by default"},
+		},
+		Template: descriptions.PromptSetupSnapMirrorDR,
+	},
+	{
+		Name:        "recover_file_from_snapshot",
+		Title:       "Recover a file from a snapshot",
+		Description: "Find the right snapshot of a volume and copy a file back from its .snapshot directory.",
+		Arguments: []promptArgument{
+			clusterPromptArgument, svmPromptArgument, volumePromptArgument,
+			{Name: "file_path", Description: "path of the file, relative to the volume root"},
+			{Name: "before", Description: "when the file was lost or damaged, e.g. yesterday 14:00"},
+		},
+		Template: descriptions.PromptRecoverFileFromSnapshot,
+	},
+}
+
+// runbookPrompt is a parsed promptDefinition.
+type runbookPrompt struct {
+	prompt   *mcp.Prompt
+	template *template.Template
+}
+
+func newRunbookPrompt(def promptDefinition) (*runbookPrompt, error) {
+	if strings.TrimSpace(def.Name) == "" {
+		return nil, errors.New("name is required")
+	}
+	if strings.TrimSpace(def.Template) == "" {
+		return nil, errors.New("template is required")
+	}
+	prompt := &mcp.Prompt{Name: def.Name, Title: def.Title, Description: def.Description}
+	seen := make(map[string]bool, len(def.Arguments))
+	for _, arg := range def.Arguments {
+		if arg.Name == "" {
+			return nil, errors.New("every argument requires a name")
+		}
+		if seen[arg.Name] {
+			return nil, fmt.Errorf("argument %s is defined twice", arg.Name)
+		}
+		seen[arg.Name] = true
+		prompt.Arguments = append(prompt.Arguments, &mcp.PromptArgument{Name: arg.Name, Description: arg.Description, Required: arg.Required})
+	}
+	tmpl, err := template.New(def.Name).Option("missingkey=zero").Parse(def.Template)
+	if err != nil {
+		return nil, err
+	}
+	return &runbookPrompt{prompt: prompt, template: tmpl}, nil
+}
+
+// loadPrompts returns the built-in prompts followed by the templates in
+// cfg.Dir. An operator template with the name of a built-in prompt replaces it.
+func loadPrompts(cfg *config.Prompts) ([]*runbookPrompt, error) {
+	prompts := make([]*runbookPrompt, 0, len(builtinPrompts))
+	index := make(map[string]int)
+	for _, def := range builtinPrompts {
+		p, err := newRunbookPrompt(def)
+		if err != nil {
+			return nil, fmt.Errorf("built-in prompt %s: %w", def.Name, err)
+		}
+		index[def.Name] = len(prompts)
+		prompts = append(prompts, p)
+	}
+	if cfg == nil {
+		return prompts, nil
+	}
+	dir := strings.TrimSpace(cfg.Dir)
+	if dir == "" {
+		return nil, errors.New("section `Prompts` requires dir")
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read prompt directory: %w", err)
+	}
+	fromFile := make(map[string]string)
+	for _, e := range entries {
+		ext := filepath.Ext(e.Name())
+		if e.IsDir() || (ext != ".yaml" && ext != ".yml") {
+			continue
+		}
+		path := filepath.Join(dir, e.Name())
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read prompt template: %w", err)
+		}
+		var def promptDefinition
+		if err := yaml.Unmarshal(contents, &def); err != nil {
+			return nil, fmt.Errorf("prompt template %s: %w", path, err)
+		}
+		p, err := newRunbookPrompt(def)
+		if err != nil {
+			return nil, fmt.Errorf("prompt template %s: %w", path, err)
+		}
+		if other, ok := fromFile[def.Name]; ok {
+			return nil, fmt.Errorf("prompt template %s: prompt %s is already defined in %s", path, def.Name, other)
+		}
+		fromFile[def.Name] = path
+		if i, ok := index[def.Name]; ok {
+			prompts[i] = p
+			continue
+		}
+		index[def.Name] = len(prompts)
+		prompts = append(prompts, p)
+	}
+	return prompts, nil
+}
+
+func addPrompts(a *App, server *mcp.Server) {
+	for _, p := range a.prompts {
+		server.AddPrompt(p.prompt, p.get)
+	}
+}
+
+// get renders the prompt as one user message.
+func (p *runbookPrompt) get(_ context.Context, req *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	args := make(map[string]string, len(req.Params.Arguments))
+	for k, v := range req.Params.Arguments {
+		args[k] = strings.TrimSpace(v)
+	}
+	for _, arg := range p.prompt.Arguments {
+		if arg.Required && args[arg.Name] == "" {
+			return nil, fmt.Errorf("prompt %s requires argument %s", p.prompt.Name, arg.Name)
+		}
+	}
+
+	var sb strings.Builder
+	if err := p.template.Execute(&sb, args); err != nil {
+		return nil, fmt.Errorf("failed to render prompt %s: %w", p.prompt.Name, err)
+	}
+	return &mcp.GetPromptResult{
+		Description: p.prompt.Description,
+		Messages:    []*mcp.PromptMessage{{Role: "user", Content: &mcp.TextContent{Text: sb.String()}}},
+	}, nil
+}
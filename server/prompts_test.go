@@ -0,0 +1,131 @@
+package server
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/netapp/ontap-mcp/config"
+)
+
+const expandVolumePrompt = `name: expand_volume
+title: Expand a volume
+arguments:
+  - name: cluster_name
+    required: true
+  - name: volume_name
+    required: true
+template: Grow {{.volume_name}} on {{.cluster_name}} by {{or .increment "10%"}}.
+`
+
+func TestLoadPrompts(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   map[string]string
+		want    []string
+		wantErr string
+	}{
+		{
+			name:  "adds operator templates",
+			files: map[string]string{"expand.yaml": expandVolumePrompt, "notes.txt": "ignored"},
+			want:  []string{"provision_nfs_share", "map_lun_to_esxi_host", "setup_snapmirror_dr", "recover_file_from_snapshot", "expand_volume"},
+		},
+		{
+			name:  "replaces a built-in prompt",
+			files: map[string]string{"nfs.yml": "name: provision_nfs_share\ntemplate: Ask the storage team.\n"},
+			want:  []string{"provision_nfs_share", "map_lun_to_esxi_host", "setup_snapmirror_dr", "recover_file_from_snapshot"},
+		},
+		{
+			name:    "missing template",
+			files:   map[string]string{"empty.yaml": "name: empty\n"},
+			wantErr: "template is required",
+		},
+		{
+			name:    "invalid template",
+			files:   map[string]string{"bad.yaml": "name: bad\ntemplate: \"{{.cluster_name\"\n"},
+			wantErr: "bad.yaml",
+		},
+		{
+			name:    "duplicate names",
+			files:   map[string]string{"a.yaml": expandVolumePrompt, "b.yaml": expandVolumePrompt},
+			wantErr: "prompt expand_volume is already defined",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, contents := range tt.files {
+				if err := os.WriteFile(filepath.Join(dir, name), []byte(contents), 0o600); err != nil {
+					t.Fatal(err)
+				}
+			}
+			prompts, err := loadPrompts(&config.Prompts{Dir: dir})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("loadPrompts: %v", err)
+			}
+			names := make([]string, 0, len(prompts))
+			for _, p := range prompts {
+				names = append(names, p.prompt.Name)
+			}
+			if strings.Join(names, ",") != strings.Join(tt.want, ",") {
+				t.Fatalf("prompts = %v, want %v", names, tt.want)
+			}
+		})
+	}
+
+	if _, err := loadPrompts(&config.Prompts{Dir: filepath.Join(t.TempDir(), "missing")}); err == nil {
+		t.Fatal("expected a missing prompt directory to fail")
+	}
+}
+
+func TestGetPrompt(t *testing.T) {
+	app := newONTAPTestApp(t, Options{}, func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	session := newTestSession(t, app, nil)
+
+	list, err := session.ListPrompts(t.Context(), nil)
+	if err != nil {
+		t.Fatalf("ListPrompts: %v", err)
+	}
+	if len(list.Prompts) != len(builtinPrompts) {
+		t.Fatalf("expected %d prompts, got %d", len(builtinPrompts), len(list.Prompts))
+	}
+
+	res, err := session.GetPrompt(t.Context(), &mcp.GetPromptParams{
+		Name:      "provision_nfs_share",
+		Arguments: map[string]string{"cluster_name": "dc1", "svm_name": "vs1", "volume_name": "projects", "size": "100GB"},
+	})
+	if err != nil {
+		t.Fatalf("GetPrompt: %v", err)
+	}
+	if len(res.Messages) != 1 {
+		t.Fatalf("expected one message, got %d", len(res.Messages))
+	}
+	text := res.Messages[0].Content.(*mcp.TextContent).Text
+	for _, want := range []string{"volume projects on SVM vs1 of cluster dc1, size 100GB.", "size 100GB,", "set to the clients I name (ask me)"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("expected the prompt to contain %q, got:\n%s", want, text)
+		}
+	}
+	if strings.Contains(text, "<no value>") {
+		t.Errorf("expected missing optional arguments to render empty, got:\n%s", text)
+	}
+
+	_, err = session.GetPrompt(t.Context(), &mcp.GetPromptParams{
+		Name:      "provision_nfs_share",
+		Arguments: map[string]string{"cluster_name": "dc1", "svm_name": "vs1"},
+	})
+	if err == nil || !strings.Contains(err.Error(), "requires argument volume_name") {
+		t.Fatalf("expected a missing volume_name to fail, got %v", err)
+	}
+}
@@ -9,25 +9,20 @@ import (
 	"github.com/netapp/ontap-mcp/tool"
 )
 
-func (a *App) CreateQtree(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.QtreeCreate) (*mcp.CallToolResult, any, error) {
-	if !a.locks.TryLock(parameters.Cluster) {
-		return errorResult(fmt.Errorf("another write operation is in progress on cluster %s, please try again", parameters.Cluster)), nil, nil
-	}
-	defer a.locks.Unlock(parameters.Cluster)
-
+func (a *App) CreateQtree(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.QtreeCreate) (*mcp.CallToolResult, WriteResponse, error) {
 	qtreeCreate, err := newCreateQtree(parameters)
 	if err != nil {
-		return nil, nil, err
+		return nil, WriteResponse{}, err
 	}
 
 	client, err := a.getClient(parameters.Cluster)
 	if err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), WriteResponse{}, err
 	}
 	err = client.CreateQtree(ctx, qtreeCreate)
 
 	if err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), WriteResponse{}, err
 	}
 
 	responseText := "Qtree created successfully"
@@ -36,28 +31,23 @@ func (a *App) CreateQtree(ctx context.Context, _ *mcp.CallToolRequest, parameter
 		Content: []mcp.Content{
 			&mcp.TextContent{Text: responseText},
 		},
-	}, nil, nil
+	}, WriteResponse{}, nil
 }
 
-func (a *App) UpdateQtree(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.Qtree) (*mcp.CallToolResult, any, error) {
-	if !a.locks.TryLock(parameters.Cluster) {
-		return errorResult(fmt.Errorf("another write operation is in progress on cluster %s, please try again", parameters.Cluster)), nil, nil
-	}
-	defer a.locks.Unlock(parameters.Cluster)
-
+func (a *App) UpdateQtree(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.Qtree) (*mcp.CallToolResult, WriteResponse, error) {
 	qtreeUpdate, err := newUpdateQtree(parameters)
 	if err != nil {
-		return nil, nil, err
+		return nil, WriteResponse{}, err
 	}
 
 	client, err := a.getClient(parameters.Cluster)
 	if err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), WriteResponse{}, err
 	}
 	err = client.UpdateQtree(ctx, parameters.SVM, parameters.Volume, parameters.Name, qtreeUpdate)
 
 	if err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), WriteResponse{}, err
 	}
 
 	responseText := "Qtree updated successfully"
@@ -66,28 +56,23 @@ func (a *App) UpdateQtree(ctx context.Context, _ *mcp.CallToolRequest, parameter
 		Content: []mcp.Content{
 			&mcp.TextContent{Text: responseText},
 		},
-	}, nil, nil
+	}, WriteResponse{}, nil
 }
 
-func (a *App) DeleteQtree(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.Qtree) (*mcp.CallToolResult, any, error) {
-	if !a.locks.TryLock(parameters.Cluster) {
-		return errorResult(fmt.Errorf("another write operation is in progress on cluster %s, please try again", parameters.Cluster)), nil, nil
-	}
-	defer a.locks.Unlock(parameters.Cluster)
-
+func (a *App) DeleteQtree(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.Qtree) (*mcp.CallToolResult, WriteResponse, error) {
 	qtreeDelete, err := newDeleteQtree(parameters)
 	if err != nil {
-		return nil, nil, err
+		return nil, WriteResponse{}, err
 	}
 
 	client, err := a.getClient(parameters.Cluster)
 	if err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), WriteResponse{}, err
 	}
 	err = client.DeleteQtree(ctx, qtreeDelete)
 
 	if err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), WriteResponse{}, err
 	}
 
 	responseText := "Qtree deleted successfully"
@@ -96,22 +81,17 @@ func (a *App) DeleteQtree(ctx context.Context, _ *mcp.CallToolRequest, parameter
 		Content: []mcp.Content{
 			&mcp.TextContent{Text: responseText},
 		},
-	}, nil, nil
+	}, WriteResponse{}, nil
 }
 
-func (a *App) ModifyQtree(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.QtreeModify) (*mcp.CallToolResult, any, error) {
-	if !a.locks.TryLock(parameters.Cluster) {
-		return errorResult(fmt.Errorf("another write operation is in progress on cluster %s, please try again", parameters.Cluster)), nil, nil
-	}
-	defer a.locks.Unlock(parameters.Cluster)
-
+func (a *App) ModifyQtree(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.QtreeModify) (*mcp.CallToolResult, WriteResponse, error) {
 	if err := validateQtree(parameters.SVM, parameters.Volume, parameters.Name); err != nil {
-		return nil, nil, err
+		return nil, WriteResponse{}, err
 	}
 
 	client, err := a.getClient(parameters.Cluster)
 	if err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), WriteResponse{}, err
 	}
 
 	switch parameters.Operation {
@@ -123,15 +103,15 @@ func (a *App) ModifyQtree(ctx context.Context, _ *mcp.CallToolRequest, parameter
 			NewName: parameters.QtreeUpdate.NewName,
 		})
 		if err != nil {
-			return nil, nil, err
+			return nil, WriteResponse{}, err
 		}
 
 		err = client.UpdateQtree(ctx, parameters.SVM, parameters.Volume, parameters.Name, qtreeUpdate)
 		if err != nil {
-			return errorResult(err), nil, err
+			return errorResult(err), WriteResponse{}, err
 		}
 
-		return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: "Qtree updated successfully"}}}, nil, nil
+		return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: "Qtree updated successfully"}}}, WriteResponse{}, nil
 	case "delete":
 		qtreeDelete, err := newDeleteQtree(tool.Qtree{
 			SVM:    parameters.SVM,
@@ -139,17 +119,17 @@ func (a *App) ModifyQtree(ctx context.Context, _ *mcp.CallToolRequest, parameter
 			Name:   parameters.Name,
 		})
 		if err != nil {
-			return nil, nil, err
+			return nil, WriteResponse{}, err
 		}
 
 		err = client.DeleteQtree(ctx, qtreeDelete)
 		if err != nil {
-			return errorResult(err), nil, err
+			return errorResult(err), WriteResponse{}, err
 		}
 
-		return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: "Qtree deleted successfully"}}}, nil, nil
+		return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: "Qtree deleted successfully"}}}, WriteResponse{}, nil
 	default:
-		return errorResult(fmt.Errorf("unsupported operation %q; supported values: update, delete", parameters.Operation)), nil, nil
+		return errorResult(fmt.Errorf("unsupported operation %q; supported values: update, delete", parameters.Operation)), WriteResponse{}, nil
 	}
 }
 
@@ -0,0 +1,421 @@
+package server
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/netapp/ontap-mcp/tool"
+)
+
+// recordQuery is the select, sort_by, group_by, aggregate and top_n of an
+// ontap_get call. It is applied to the records ONTAP returned, so the client
+// only receives the fields, rows and totals it asked for.
+type recordQuery struct {
+	selected   fieldTree
+	sortKeys   []sortKey
+	groupBy    []string
+	aggregates []aggregate
+	topN       int
+}
+
+// fieldTree holds dot-notation fields as a tree, e.g. "space.used" and
+// "space.size" share the "space" node. A leaf keeps the whole value.
+type fieldTree map[string]fieldTree
+
+type sortKey struct {
+	field string
+	desc  bool
+}
+
+type aggregate struct {
+	fn    string
+	field string
+}
+
+// name is the key of the aggregate in a RecordGroup, e.g. "sum(space.used)".
+func (g aggregate) name() string {
+	return g.fn + "(" + g.field + ")"
+}
+
+// RecordGroup is one group of an ontap_get call with group_by.
+type RecordGroup struct {
+	Key        map[string]any     `json:"key" jsonschema:"the group_by fields and their value for this group"`
+	Count      int                `json:"count" jsonschema:"number of records in the group"`
+	Aggregates map[string]float64 `json:"aggregates,omitzero" jsonschema:"the requested aggregates, e.g. sum(space.used), over the numeric values in the group"`
+	Records    []map[string]any   `json:"records,omitzero" jsonschema:"the top_n records of the group, when top_n is set"`
+}
+
+func parseRecordQuery(p tool.OntapGetParams) (*recordQuery, error) {
+	if p.TopN < 0 {
+		return nil, errors.New("top_n must not be negative")
+	}
+	q := &recordQuery{topN: p.TopN, groupBy: splitFields(p.GroupBy)}
+
+	for _, field := range splitFields(p.Select) {
+		q.selected.add(field)
+	}
+	for _, s := range splitFields(p.SortBy) {
+		parts := strings.Fields(s)
+		key := sortKey{field: parts[0]}
+		switch {
+		case len(parts) == 1:
+		case len(parts) == 2 && strings.EqualFold(parts[1], "asc"):
+		case len(parts) == 2 && strings.EqualFold(parts[1], "desc"):
+			key.desc = true
+		default:
+			return nil, fmt.Errorf("invalid sort_by %q: use a field, optionally followed by asc or desc", s)
+		}
+		q.sortKeys = append(q.sortKeys, key)
+	}
+	for _, s := range splitFields(p.Aggregate) {
+		fn, field, ok := strings.Cut(strings.TrimSuffix(s, ")"), "(")
+		fn = strings.ToLower(strings.TrimSpace(fn))
+		field = strings.TrimSpace(field)
+		if !ok || !strings.HasSuffix(s, ")") || field == "" || !slices.Contains([]string{"sum", "avg", "min", "max"}, fn) {
+			return nil, fmt.Errorf("invalid aggregate %q: use sum, avg, min or max of a field, e.g. sum(space.used)", s)
+		}
+		q.aggregates = append(q.aggregates, aggregate{fn: fn, field: field})
+	}
+	return q, nil
+}
+
+// empty reports whether q leaves the records unchanged.
+func (q *recordQuery) empty() bool {
+	return len(q.selected) == 0 && len(q.sortKeys) == 0 && len(q.groupBy) == 0 && len(q.aggregates) == 0 && q.topN == 0
+}
+
+// fields returns fields with every field q selects, sorts, groups or
+// aggregates by added, so ONTAP returns them. A field already covered by one in
+// fields, such as space.used by space or space.*, is not added again.
+func (q *recordQuery) fields(fields string) string {
+	requested := splitFields(fields)
+	var needed []string
+	q.selected.walk("", func(field string) { needed = append(needed, field) })
+	for _, key := range q.sortKeys {
+		needed = append(needed, key.field)
+	}
+	needed = append(needed, q.groupBy...)
+	for _, agg := range q.aggregates {
+		needed = append(needed, agg.field)
+	}
+
+	out := requested
+	for _, field := range needed {
+		if !slices.ContainsFunc(out, func(f string) bool { return coversField(f, field) }) {
+			out = append(out, field)
+		}
+	}
+	return strings.Join(out, ",")
+}
+
+// coversField reports whether requesting the ONTAP field f returns field.
+func coversField(f, field string) bool {
+	if f == "*" || f == "**" || f == field {
+		return true
+	}
+	parent := strings.TrimSuffix(strings.TrimSuffix(f, "*"), ".")
+	return strings.HasPrefix(field, parent+".")
+}
+
+// apply sorts, groups, cuts and projects the records of resp. Without
+// group_by, aggregates summarize every record in a single group.
+func (q *recordQuery) apply(resp OntapGetResponse) OntapGetResponse {
+	records := resp.Records
+	q.sort(records)
+	out := OntapGetResponse{TotalRecords: len(records)}
+
+	if len(q.groupBy) == 0 && len(q.aggregates) == 0 {
+		out.Records = q.project(q.top(records))
+		out.NumRecords = len(out.Records)
+		return out
+	}
+
+	var (
+		order  []string
+		groups = make(map[string][]map[string]any)
+		keys   = make(map[string]map[string]any)
+	)
+	for _, record := range records {
+		key := make(map[string]any, len(q.groupBy))
+		for _, field := range q.groupBy {
+			key[field], _ = lookupField(record, field)
+		}
+		id := groupID(key)
+		if _, ok := groups[id]; !ok {
+			order = append(order, id)
+			keys[id] = key
+		}
+		groups[id] = append(groups[id], record)
+	}
+	slices.SortStableFunc(order, func(a, b string) int {
+		for _, field := range q.groupBy {
+			if c := compareValues(keys[a][field], keys[b][field]); c != 0 {
+				return c
+			}
+		}
+		return 0
+	})
+
+	for _, id := range order {
+		group := RecordGroup{Key: keys[id], Count: len(groups[id]), Aggregates: q.aggregate(groups[id])}
+		if q.topN > 0 {
+			group.Records = q.project(q.top(groups[id]))
+		}
+		out.Groups = append(out.Groups, group)
+		out.NumRecords += group.Count
+	}
+	return out
+}
+
+func (q *recordQuery) sort(records []map[string]any) {
+	if len(q.sortKeys) == 0 {
+		return
+	}
+	slices.SortStableFunc(records, func(a, b map[string]any) int {
+		for _, key := range q.sortKeys {
+			va, okA := lookupField(a, key.field)
+			vb, okB := lookupField(b, key.field)
+			// Records without the field go last in either direction.
+			switch {
+			case !okA && !okB:
+				continue
+			case !okA:
+				return 1
+			case !okB:
+				return -1
+			}
+			c := compareValues(va, vb)
+			if key.desc {
+				c = -c
+			}
+			if c != 0 {
+				return c
+			}
+		}
+		return 0
+	})
+}
+
+func (q *recordQuery) top(records []map[string]any) []map[string]any {
+	if q.topN > 0 && len(records) > q.topN {
+		return records[:q.topN]
+	}
+	return records
+}
+
+func (q *recordQuery) project(records []map[string]any) []map[string]any {
+	if len(q.selected) == 0 {
+		return records
+	}
+	out := make([]map[string]any, 0, len(records))
+	for _, record := range records {
+		out = append(out, q.selected.project(record).(map[string]any))
+	}
+	return out
+}
+
+// aggregate returns the aggregates of records. An aggregate whose field has no
+// numeric value in any record is left out.
+func (q *recordQuery) aggregate(records []map[string]any) map[string]float64 {
+	if len(q.aggregates) == 0 {
+		return nil
+	}
+	out := make(map[string]float64, len(q.aggregates))
+	for _, agg := range q.aggregates {
+		var values []float64
+		for _, record := range records {
+			v, _ := lookupField(record, agg.field)
+			values = append(values, numbers(v)...)
+		}
+		if len(values) == 0 {
+			continue
+		}
+		var result float64
+		switch agg.fn {
+		case "sum", "avg":
+			for _, v := range values {
+				result += v
+			}
+			if agg.fn == "avg" {
+				result /= float64(len(values))
+			}
+		case "min":
+			result = slices.Min(values)
+		case "max":
+			result = slices.Max(values)
+		}
+		out[agg.name()] = result
+	}
+	return out
+}
+
+func (t *fieldTree) add(field string) {
+	if *t == nil {
+		*t = fieldTree{}
+	}
+	node := *t
+	parts := strings.Split(field, ".")
+	for _, part := range parts[:len(parts)-1] {
+		child, ok := node[part]
+		if ok && child == nil {
+			// A parent field is selected and keeps the whole value.
+			return
+		}
+		if !ok {
+			child = fieldTree{}
+			node[part] = child
+		}
+		node = child
+	}
+	node[parts[len(parts)-1]] = nil
+}
+
+// walk calls fn with the dot-notation field of every leaf of t.
+func (t fieldTree) walk(prefix string, fn func(field string)) {
+	for _, key := range slices.Sorted(maps.Keys(t)) {
+		field := prefix + key
+		if t[key] == nil {
+			fn(field)
+			continue
+		}
+		t[key].walk(field+".", fn)
+	}
+}
+
+// project returns the parts of v selected by t. Arrays are projected element by
+// element, so "aggregates.name" keeps the name of every aggregate.
+func (t fieldTree) project(v any) any {
+	switch val := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(t))
+		for key, child := range t {
+			value, ok := val[key]
+			if !ok {
+				continue
+			}
+			if child == nil {
+				out[key] = value
+				continue
+			}
+			out[key] = child.project(value)
+		}
+		return out
+	case []any:
+		out := make([]any, len(val))
+		for i, item := range val {
+			out[i] = t.project(item)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+// lookupField returns the value of a dot-notation field of record. A field
+// inside an array, e.g. "aggregates.name", returns the value of each element,
+// or the value itself when the array has one element.
+func lookupField(record map[string]any, field string) (any, bool) {
+	return lookupValue(record, strings.Split(field, "."))
+}
+
+func lookupValue(v any, parts []string) (any, bool) {
+	if len(parts) == 0 {
+		return v, true
+	}
+	switch val := v.(type) {
+	case map[string]any:
+		child, ok := val[parts[0]]
+		if !ok {
+			return nil, false
+		}
+		return lookupValue(child, parts[1:])
+	case []any:
+		var values []any
+		for _, item := range val {
+			if value, ok := lookupValue(item, parts); ok {
+				values = append(values, value)
+			}
+		}
+		switch len(values) {
+		case 0:
+			return nil, false
+		case 1:
+			return values[0], true
+		default:
+			return values, true
+		}
+	default:
+		return nil, false
+	}
+}
+
+// compareValues orders numbers numerically and everything else by its JSON
+// encoding, with nil first.
+func compareValues(a, b any) int {
+	fa, okA := a.(float64)
+	fb, okB := b.(float64)
+	if okA && okB {
+		return cmp.Compare(fa, fb)
+	}
+	if a == nil || b == nil {
+		return cmp.Compare(boolInt(a != nil), boolInt(b != nil))
+	}
+	sa, okA := a.(string)
+	sb, okB := b.(string)
+	if okA && okB {
+		return strings.Compare(sa, sb)
+	}
+	return strings.Compare(groupID(a), groupID(b))
+}
+
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// numbers returns the numeric values of v, which may be an array from
+// lookupField.
+func numbers(v any) []float64 {
+	switch val := v.(type) {
+	case float64:
+		if math.IsNaN(val) {
+			return nil
+		}
+		return []float64{val}
+	case []any:
+		var out []float64
+		for _, item := range val {
+			out = append(out, numbers(item)...)
+		}
+		return out
+	default:
+		return nil
+	}
+}
+
+func groupID(v any) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
+
+// splitFields splits a comma-separated list, dropping empty entries.
+func splitFields(s string) []string {
+	var out []string
+	for part := range strings.SplitSeq(s, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
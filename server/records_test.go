@@ -0,0 +1,181 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/netapp/ontap-mcp/tool"
+)
+
+const volumeRecords = `{"num_records":4,"records":[
+	{"name":"vol1","svm":{"name":"vs1"},"aggregates":[{"name":"aggr1"}],"space":{"used":100,"size":1000}},
+	{"name":"vol2","svm":{"name":"vs1"},"aggregates":[{"name":"aggr2"}],"space":{"used":300,"size":1000}},
+	{"name":"vol3","svm":{"name":"vs2"},"aggregates":[{"name":"aggr1"}],"space":{"used":200,"size":500}},
+	{"name":"vol4","svm":{"name":"vs2"},"aggregates":[{"name":"aggr1"}]}
+]}`
+
+func TestRecordQuery(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  tool.OntapGetParams
+		want    string
+		wantErr string
+	}{
+		{
+			name:   "select",
+			params: tool.OntapGetParams{Select: "name,space.used", TopN: 2},
+			want:   `{"num_records":2,"records":[{"name":"vol1","space":{"used":100}},{"name":"vol2","space":{"used":300}}],"total_records":4}`,
+		},
+		{
+			name:   "select inside arrays",
+			params: tool.OntapGetParams{Select: "aggregates.name,name,aggregates", TopN: 1},
+			want:   `{"num_records":1,"records":[{"aggregates":[{"name":"aggr1"}],"name":"vol1"}],"total_records":4}`,
+		},
+		{
+			name:   "sort descending with missing values last",
+			params: tool.OntapGetParams{Select: "name", SortBy: "space.used desc"},
+			want:   `{"num_records":4,"records":[{"name":"vol2"},{"name":"vol3"},{"name":"vol1"},{"name":"vol4"}],"total_records":4}`,
+		},
+		{
+			name:   "sort by several fields",
+			params: tool.OntapGetParams{Select: "name", SortBy: "svm.name desc, space.size asc", TopN: 3},
+			want:   `{"num_records":3,"records":[{"name":"vol3"},{"name":"vol4"},{"name":"vol1"}],"total_records":4}`,
+		},
+		{
+			name:   "group with aggregates",
+			params: tool.OntapGetParams{GroupBy: "aggregates.name", Aggregate: "sum(space.used),avg(space.used),min(space.size),max(space.size)"},
+			want: `{"num_records":4,"groups":[` +
+				`{"key":{"aggregates.name":"aggr1"},"count":3,"aggregates":{"avg(space.used)":150,"max(space.size)":1000,"min(space.size)":500,"sum(space.used)":300}},` +
+				`{"key":{"aggregates.name":"aggr2"},"count":1,"aggregates":{"avg(space.used)":300,"max(space.size)":1000,"min(space.size)":1000,"sum(space.used)":300}}],"total_records":4}`,
+		},
+		{
+			name:   "top records per group",
+			params: tool.OntapGetParams{GroupBy: "aggregates.name", SortBy: "space.used desc", TopN: 1, Select: "name"},
+			want: `{"num_records":4,"groups":[{"key":{"aggregates.name":"aggr1"},"count":3,"records":[{"name":"vol3"}]},` +
+				`{"key":{"aggregates.name":"aggr2"},"count":1,"records":[{"name":"vol2"}]}],"total_records":4}`,
+		},
+		{
+			name:   "aggregate without group_by",
+			params: tool.OntapGetParams{Aggregate: "sum(space.size)"},
+			want:   `{"num_records":4,"groups":[{"key":{},"count":4,"aggregates":{"sum(space.size)":2500}}],"total_records":4}`,
+		},
+		{
+			name:    "invalid sort_by",
+			params:  tool.OntapGetParams{SortBy: "name up"},
+			wantErr: "invalid sort_by",
+		},
+		{
+			name:    "invalid aggregate",
+			params:  tool.OntapGetParams{Aggregate: "median(space.used)"},
+			wantErr: "invalid aggregate",
+		},
+		{
+			name:    "negative top_n",
+			params:  tool.OntapGetParams{TopN: -1},
+			wantErr: "top_n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := parseRecordQuery(tt.params)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseRecordQuery: %v", err)
+			}
+			got, err := json.Marshal(q.apply(newOntapGetResponse(json.RawMessage(volumeRecords))))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Fatalf("apply() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecordQueryFields(t *testing.T) {
+	tests := []struct {
+		name   string
+		params tool.OntapGetParams
+		want   string
+	}{
+		{
+			name:   "no fields requested",
+			params: tool.OntapGetParams{Select: "name", SortBy: "space.used desc", GroupBy: "svm.name", Aggregate: "sum(space.size)"},
+			want:   "name,space.used,svm.name,space.size",
+		},
+		{
+			name:   "adds to the requested fields",
+			params: tool.OntapGetParams{Fields: "name,state", SortBy: "space.used desc"},
+			want:   "name,state,space.used",
+		},
+		{
+			name:   "covered by a parent field",
+			params: tool.OntapGetParams{Fields: "name,space", Aggregate: "sum(space.used)", GroupBy: "aggregates.name"},
+			want:   "name,space,aggregates.name",
+		},
+		{
+			name:   "covered by a wildcard",
+			params: tool.OntapGetParams{Fields: "space.*", SortBy: "space.used"},
+			want:   "space.*",
+		},
+		{
+			name:   "covered by all fields",
+			params: tool.OntapGetParams{Fields: "**", Select: "name,svm.name"},
+			want:   "**",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := parseRecordQuery(tt.params)
+			if err != nil {
+				t.Fatalf("parseRecordQuery: %v", err)
+			}
+			if got := q.fields(tt.params.Fields); got != tt.want {
+				t.Fatalf("fields() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOntapGetShapesRecords(t *testing.T) {
+	app := newONTAPTestApp(t, Options{}, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Has("top_n") || r.URL.Query().Has("group_by") {
+			t.Errorf("expected shaping parameters to stay local, got %s", r.URL.RawQuery)
+		}
+		if r.URL.Path == "/api/storage/volumes" && r.URL.Query().Get("fields") != "name,svm.name,space.used" {
+			t.Errorf("expected the sort_by and select fields to be requested, got fields=%q", r.URL.Query().Get("fields"))
+		}
+		_, _ = w.Write([]byte(volumeRecords))
+	})
+	session := newTestSession(t, app, nil)
+
+	res, err := session.CallTool(t.Context(), &mcp.CallToolParams{Name: "ontap_get", Arguments: map[string]any{
+		"cluster_name": "dc1", "path": "/storage/volumes", "fields": "name",
+		"sort_by": "space.used desc", "top_n": 2, "select": "name,svm.name",
+	}})
+	if err != nil {
+		t.Fatalf("CallTool: %v", err)
+	}
+	var out OntapGetResponse
+	decodeStructured(t, res, &out)
+	want := OntapGetResponse{NumRecords: 2, TotalRecords: 4, Records: []map[string]any{
+		{"name": "vol2", "svm": map[string]any{"name": "vs1"}},
+		{"name": "vol3", "svm": map[string]any{"name": "vs2"}},
+	}}
+	if !reflect.DeepEqual(out, want) {
+		t.Fatalf("structured content = %+v, want %+v", out, want)
+	}
+	if text := toolText(t, res); strings.Contains(text, "vol1") || strings.Contains(text, "space") {
+		t.Fatalf("expected the text content to hold only the shaped records, got %s", text)
+	}
+}
@@ -0,0 +1,319 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/netapp/ontap-mcp/server/lock"
+)
+
+// DefaultResourcePollInterval is how often subscribed resources are fetched
+// again to detect changes when Options.ResourcePollInterval is not set.
+const DefaultResourcePollInterval = 30 * time.Second
+
+const resourceScheme = "ontap"
+
+// ontapResource is an ONTAP object exposed as an MCP resource template.
+type ontapResource struct {
+	template mcp.ResourceTemplate
+	segments []string // path after the cluster; {name} segments are variables
+	path     string   // ONTAP REST collection
+	fields   string
+	filters  map[string]string // ONTAP query parameter → variable
+	single   bool              // the filters select exactly one record
+	noun     string
+}
+
+var ontapResources = []ontapResource{
+	{
+		template: mcp.ResourceTemplate{
+			Name:        "cluster",
+			Title:       "ONTAP cluster",
+			URITemplate: "ontap://{cluster}/cluster",
+			Description: "Name, UUID, ONTAP version, location and contact of a cluster.",
+			MIMEType:    "application/json",
+		},
+		segments: []string{"cluster"},
+		path:     "/cluster",
+		fields:   "name,uuid,version,location,contact",
+		noun:     "cluster",
+	},
+	{
+		template: mcp.ResourceTemplate{
+			Name:        "svms",
+			Title:       "ONTAP SVMs",
+			URITemplate: "ontap://{cluster}/svms",
+			Description: "Name, UUID, state and subtype of every SVM on a cluster.",
+			MIMEType:    "application/json",
+		},
+		segments: []string{"svms"},
+		path:     "/svm/svms",
+		fields:   "name,uuid,state,subtype,comment",
+		noun:     "SVMs",
+	},
+	{
+		template: mcp.ResourceTemplate{
+			Name:        "volume",
+			Title:       "ONTAP volume",
+			URITemplate: "ontap://{cluster}/svms/{svm}/volumes/{volume}",
+			Description: "State, size, space usage, junction path and policies of one volume.",
+			MIMEType:    "application/json",
+		},
+		segments: []string{"svms", "{svm}", "volumes", "{volume}"},
+		path:     "/storage/volumes",
+		fields: "name,uuid,svm.name,state,type,style,size,space.used,space.available," +
+			"nas.path,nas.export_policy.name,snapshot_policy.name,qos.policy.name,aggregates.name",
+		filters: map[string]string{"svm.name": "svm", "name": "volume"},
+		single:  true,
+		noun:    "volume",
+	},
+}
+
+// resourceRef is a parsed ontap:// URI.
+type resourceRef struct {
+	resource *ontapResource
+	cluster  string
+	vars     map[string]string
+}
+
+// parseResourceURI matches uri against the resource templates.
+func parseResourceURI(uri string) (resourceRef, error) {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != resourceScheme || u.Host == "" {
+		return resourceRef{}, mcp.ResourceNotFoundError(uri)
+	}
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	for i := range ontapResources {
+		r := &ontapResources[i]
+		if len(parts) != len(r.segments) {
+			continue
+		}
+		vars := make(map[string]string)
+		matched := true
+		for j, seg := range r.segments {
+			if name, ok := strings.CutPrefix(seg, "{"); ok {
+				if parts[j] == "" {
+					matched = false
+					break
+				}
+				vars[strings.TrimSuffix(name, "}")] = parts[j]
+			} else if parts[j] != seg {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return resourceRef{resource: r, cluster: u.Host, vars: vars}, nil
+		}
+	}
+	return resourceRef{}, mcp.ResourceNotFoundError(uri)
+}
+
+func addResources(a *App, server *mcp.Server) {
+	for i := range ontapResources {
+		template := ontapResources[i].template
+		server.AddResourceTemplate(&template, a.ReadResource)
+	}
+}
+
+func (a *App) ReadResource(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	uri := req.Params.URI
+	ref, err := parseResourceURI(uri)
+	if err != nil {
+		return nil, err
+	}
+	if !a.permissionsFor(req.Extra).allows(accessRead, ref.cluster) {
+		return nil, fmt.Errorf("not authorized to read %s: requires read access on cluster %s", uri, ref.cluster)
+	}
+	text, err := a.fetchResource(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	return &mcp.ReadResourceResult{
+		Contents: []*mcp.ResourceContents{{URI: uri, MIMEType: "application/json", Text: text}},
+	}, nil
+}
+
+// fetchResource returns the JSON of the object ref names, without _links.
+func (a *App) fetchResource(ctx context.Context, ref resourceRef) (string, error) {
+	client, err := a.getClient(ref.cluster)
+	if err != nil {
+		return "", err
+	}
+	release, err := a.acquireLock(ctx, nil, lock.Key{Cluster: ref.cluster}, true)
+	if err != nil {
+		return "", err
+	}
+	defer release()
+
+	r := ref.resource
+	params := url.Values{}
+	params.Set("fields", r.fields)
+	for param, name := range r.filters {
+		params.Set(param, ref.vars[name])
+	}
+	raw, err := client.GenericGet(ctx, r.path, params, 0)
+	if err != nil {
+		return "", err
+	}
+
+	var data any
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return "", fmt.Errorf("failed to decode ONTAP response for %s: %w", r.noun, err)
+	}
+	if r.single {
+		records, _ := data.(map[string]any)["records"].([]any)
+		if len(records) != 1 {
+			return "", fmt.Errorf("%s %s not found on cluster %s", r.noun, ref.vars[r.filters["name"]], ref.cluster)
+		}
+		data = records[0]
+	}
+	out, err := json.Marshal(stripLinksValue(data))
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// resourceWatcher polls the resources clients of one MCP server subscribed
+// to, and notifies them when a resource's content changes. It only runs while
+// there are subscriptions.
+type resourceWatcher struct {
+	app      *App
+	server   *mcp.Server
+	interval time.Duration
+
+	mu      sync.Mutex
+	subs    map[string]*subscription // by URI
+	running bool
+}
+
+type subscription struct {
+	ref      resourceRef
+	sessions map[*mcp.ServerSession]bool
+	last     string
+}
+
+func newResourceWatcher(a *App) *resourceWatcher {
+	interval := a.options.ResourcePollInterval
+	if interval <= 0 {
+		interval = DefaultResourcePollInterval
+	}
+	return &resourceWatcher{app: a, interval: interval, subs: make(map[string]*subscription)}
+}
+
+func (w *resourceWatcher) subscribe(ctx context.Context, req *mcp.SubscribeRequest) error {
+	uri := req.Params.URI
+	ref, err := parseResourceURI(uri)
+	if err != nil {
+		return err
+	}
+	if !w.app.permissionsFor(req.Extra).allows(accessRead, ref.cluster) {
+		return fmt.Errorf("not authorized to subscribe to %s: requires read access on cluster %s", uri, ref.cluster)
+	}
+	// The first read is the baseline later polls are compared against.
+	last, err := w.app.fetchResource(ctx, ref)
+	if err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	sub := w.subs[uri]
+	if sub == nil {
+		sub = &subscription{ref: ref, sessions: make(map[*mcp.ServerSession]bool), last: last}
+		w.subs[uri] = sub
+	}
+	sub.sessions[req.Session] = true
+	if !w.running {
+		w.running = true
+		go w.run()
+	}
+	return nil
+}
+
+func (w *resourceWatcher) unsubscribe(_ context.Context, req *mcp.UnsubscribeRequest) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if sub := w.subs[req.Params.URI]; sub != nil {
+		delete(sub.sessions, req.Session)
+		if len(sub.sessions) == 0 {
+			delete(w.subs, req.Params.URI)
+		}
+	}
+	return nil
+}
+
+func (w *resourceWatcher) run() {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		if !w.poll() {
+			return
+		}
+	}
+}
+
+// poll fetches every subscribed resource once and reports whether any
+// subscriptions are left. Subscriptions of closed sessions are dropped.
+func (w *resourceWatcher) poll() bool {
+	open := make(map[*mcp.ServerSession]bool)
+	for s := range w.server.Sessions() {
+		open[s] = true
+	}
+
+	w.mu.Lock()
+	due := make(map[string]resourceRef, len(w.subs))
+	for uri, sub := range w.subs {
+		for s := range sub.sessions {
+			if !open[s] {
+				delete(sub.sessions, s)
+			}
+		}
+		if len(sub.sessions) == 0 {
+			delete(w.subs, uri)
+			continue
+		}
+		due[uri] = sub.ref
+	}
+	if len(w.subs) == 0 {
+		w.running = false
+		w.mu.Unlock()
+		return false
+	}
+	w.mu.Unlock()
+
+	for uri, ref := range due {
+		ctx, cancel := context.WithTimeout(context.Background(), w.interval)
+		text, err := w.app.fetchResource(ctx, ref)
+		cancel()
+		if err != nil {
+			w.app.logger.Debug("failed to poll subscribed resource", slog.String("uri", uri), slog.Any("error", err))
+			continue
+		}
+
+		w.mu.Lock()
+		sub := w.subs[uri]
+		changed := sub != nil && sub.last != text
+		if changed {
+			sub.last = text
+		}
+		w.mu.Unlock()
+
+		if changed {
+			err := w.server.ResourceUpdated(context.Background(), &mcp.ResourceUpdatedNotificationParams{URI: uri})
+			if err != nil && !errors.Is(err, context.Canceled) {
+				w.app.logger.Debug("failed to send resource updated notification", slog.String("uri", uri), slog.Any("error", err))
+			}
+		}
+	}
+	return true
+}
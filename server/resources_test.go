@@ -0,0 +1,131 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestParseResourceURI(t *testing.T) {
+	tests := []struct {
+		uri     string
+		want    string
+		cluster string
+		vars    map[string]string
+		wantErr bool
+	}{
+		{uri: "ontap://dc1/cluster", want: "cluster", cluster: "dc1"},
+		{uri: "ontap://dc1/svms", want: "svms", cluster: "dc1"},
+		{uri: "ontap://dc1/svms/vs1/volumes/vol%201", want: "volume", cluster: "dc1", vars: map[string]string{"svm": "vs1", "volume": "vol 1"}},
+		{uri: "ontap://dc1/svms/vs1/volumes", wantErr: true},
+		{uri: "ontap://dc1/svms//volumes/vol1", wantErr: true},
+		{uri: "file://dc1/cluster", wantErr: true},
+		{uri: "ontap:///cluster", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.uri, func(t *testing.T) {
+			ref, err := parseResourceURI(tt.uri)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected %s not to match a template", tt.uri)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseResourceURI: %v", err)
+			}
+			if ref.resource.template.Name != tt.want || ref.cluster != tt.cluster {
+				t.Fatalf("got template %s on cluster %s, want %s on %s", ref.resource.template.Name, ref.cluster, tt.want, tt.cluster)
+			}
+			for k, v := range tt.vars {
+				if ref.vars[k] != v {
+					t.Errorf("vars[%s] = %q, want %q", k, ref.vars[k], v)
+				}
+			}
+		})
+	}
+}
+
+func TestReadVolumeResource(t *testing.T) {
+	app := newONTAPTestApp(t, Options{}, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if r.URL.Path != "/api/storage/volumes" || q.Get("svm.name") != "vs1" || q.Get("name") != "vol1" {
+			_, _ = w.Write([]byte(`{"num_records":0,"records":[]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"num_records":1,"records":[{"name":"vol1","state":"online","svm":{"name":"vs1","_links":{"self":{}}},"_links":{"self":{}}}],"_links":{"self":{}}}`))
+	})
+	session := newTestSession(t, app, nil)
+
+	templates, err := session.ListResourceTemplates(t.Context(), nil)
+	if err != nil {
+		t.Fatalf("ListResourceTemplates: %v", err)
+	}
+	if len(templates.ResourceTemplates) != len(ontapResources) {
+		t.Fatalf("expected %d resource templates, got %d", len(ontapResources), len(templates.ResourceTemplates))
+	}
+
+	res, err := session.ReadResource(t.Context(), &mcp.ReadResourceParams{URI: "ontap://dc1/svms/vs1/volumes/vol1"})
+	if err != nil {
+		t.Fatalf("ReadResource: %v", err)
+	}
+	if len(res.Contents) != 1 {
+		t.Fatalf("expected one content, got %d", len(res.Contents))
+	}
+	if got, want := res.Contents[0].Text, `{"name":"vol1","state":"online","svm":{"name":"vs1"}}`; got != want {
+		t.Fatalf("content = %s, want %s", got, want)
+	}
+
+	_, err = session.ReadResource(t.Context(), &mcp.ReadResourceParams{URI: "ontap://dc1/svms/vs1/volumes/missing"})
+	if err == nil || !strings.Contains(err.Error(), "volume missing not found on cluster dc1") {
+		t.Fatalf("expected reading a missing volume to fail, got %v", err)
+	}
+	_, err = session.ReadResource(t.Context(), &mcp.ReadResourceParams{URI: "ontap://dc1/qtrees"})
+	if err == nil {
+		t.Fatal("expected reading an unknown URI to fail")
+	}
+}
+
+func TestResourceSubscription(t *testing.T) {
+	var state atomic.Value
+	state.Store("online")
+	app := newONTAPTestApp(t, Options{ResourcePollInterval: 20 * time.Millisecond}, func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"num_records":1,"records":[{"name":"vol1","state":"` + state.Load().(string) + `"}]}`))
+	})
+	updated := make(chan string, 10)
+	session := newTestSession(t, app, &mcp.ClientOptions{
+		ResourceUpdatedHandler: func(_ context.Context, req *mcp.ResourceUpdatedNotificationRequest) {
+			updated <- req.Params.URI
+		},
+	})
+
+	const uri = "ontap://dc1/svms/vs1/volumes/vol1"
+	if err := session.Subscribe(t.Context(), &mcp.SubscribeParams{URI: uri}); err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+
+	select {
+	case got := <-updated:
+		t.Fatalf("unexpected update for %s before the volume changed", got)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	state.Store("offline")
+	select {
+	case got := <-updated:
+		if got != uri {
+			t.Fatalf("update for %s, want %s", got, uri)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected an update after the volume changed")
+	}
+
+	if err := session.Unsubscribe(t.Context(), &mcp.UnsubscribeParams{URI: uri}); err != nil {
+		t.Fatalf("Unsubscribe: %v", err)
+	}
+}
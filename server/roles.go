@@ -0,0 +1,253 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/modelcontextprotocol/go-sdk/auth"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/netapp/ontap-mcp/config"
+)
+
+// Kinds of tool access a role can grant. Every tool needs exactly one.
+const (
+	accessRead   = "read"
+	accessCreate = "create"
+	accessUpdate = "update"
+	accessDelete = "delete"
+)
+
+var accessKinds = []string{accessRead, accessCreate, accessUpdate, accessDelete}
+
+// role is a validated McpAuth.roles entry.
+type role struct {
+	claim    string
+	value    string
+	access   map[string]bool
+	clusters map[string]bool // lower-cased cluster names; nil allows every cluster
+}
+
+func newRoles(cfg []config.Role) ([]role, error) {
+	roles := make([]role, 0, len(cfg))
+	for i, r := range cfg {
+		claim := strings.TrimSpace(r.Claim)
+		value := strings.TrimSpace(r.Value)
+		if claim == "" || value == "" {
+			return nil, fmt.Errorf("McpAuth.roles[%d]: claim and value are required", i)
+		}
+		if len(r.Allow) == 0 {
+			return nil, fmt.Errorf("McpAuth.roles[%d]: allow must list at least one of %s", i, strings.Join(accessKinds, ", "))
+		}
+
+		ro := role{claim: claim, value: value, access: make(map[string]bool, len(r.Allow))}
+		for _, kind := range r.Allow {
+			kind = strings.ToLower(strings.TrimSpace(kind))
+			if !slices.Contains(accessKinds, kind) {
+				return nil, fmt.Errorf("McpAuth.roles[%d]: invalid allow value %q; supported values: %s", i, kind, strings.Join(accessKinds, ", "))
+			}
+			ro.access[kind] = true
+		}
+		for _, c := range r.Clusters {
+			c = strings.ToLower(strings.TrimSpace(c))
+			if c == "*" {
+				ro.clusters = nil
+				break
+			}
+			if ro.clusters == nil {
+				ro.clusters = make(map[string]bool)
+			}
+			ro.clusters[c] = true
+		}
+		roles = append(roles, ro)
+	}
+	return roles, nil
+}
+
+func (r role) allowsCluster(cluster string) bool {
+	return r.clusters == nil || r.clusters[strings.ToLower(cluster)]
+}
+
+// permissions are the roles that match a caller's token.
+type permissions struct {
+	roles []role
+}
+
+// permissionsFor returns what the caller behind extra may do. It returns nil,
+// meaning everything is allowed, when no roles are configured or the request
+// carries no bearer token, e.g. over stdio.
+func (a *App) permissionsFor(extra *mcp.RequestExtra) *permissions {
+	if len(a.roles) == 0 || extra == nil || extra.TokenInfo == nil {
+		return nil
+	}
+	claims := tokenClaims(extra.TokenInfo)
+	p := &permissions{}
+	for _, r := range a.roles {
+		if claimHasValue(claims, r.claim, r.value) {
+			p.roles = append(p.roles, r)
+		}
+	}
+	return p
+}
+
+func callExtra(req *mcp.CallToolRequest) *mcp.RequestExtra {
+	if req == nil {
+		return nil
+	}
+	return req.Extra
+}
+
+// allowsAccess reports whether any matching role grants access on some cluster.
+func (p *permissions) allowsAccess(access string) bool {
+	if p == nil {
+		return true
+	}
+	for _, r := range p.roles {
+		if r.access[access] {
+			return true
+		}
+	}
+	return false
+}
+
+// allows reports whether a single role grants access on cluster. An empty
+// cluster is used by tools that do not target a cluster.
+func (p *permissions) allows(access, cluster string) bool {
+	if p == nil {
+		return true
+	}
+	if cluster == "" {
+		return p.allowsAccess(access)
+	}
+	for _, r := range p.roles {
+		if r.access[access] && r.allowsCluster(cluster) {
+			return true
+		}
+	}
+	return false
+}
+
+// allowsCluster reports whether any matching role covers cluster.
+func (p *permissions) allowsCluster(cluster string) bool {
+	if p == nil {
+		return true
+	}
+	for _, r := range p.roles {
+		if r.allowsCluster(cluster) {
+			return true
+		}
+	}
+	return false
+}
+
+// updateOrDelete is the access of the operations of most modify_* tools.
+var updateOrDelete = map[string]string{"update": accessUpdate, "delete": accessDelete}
+
+// operationAccess is the access each operation of a multiplexed modify_* tool
+// requires. Operations that delete something need delete access, like the
+// delete_* and remove_* tools they stand in for.
+var operationAccess = map[string]map[string]string{
+	"modify_volume":                      updateOrDelete,
+	"modify_snapshot_policy":             updateOrDelete,
+	"modify_schedule_in_snapshot_policy": {"update": accessUpdate, "remove": accessDelete},
+	"modify_qos_policy":                  updateOrDelete,
+	"modify_nfs_export_policies":         updateOrDelete,
+	"modify_nfs_export_policies_rules":   updateOrDelete,
+	"modify_svm":                         updateOrDelete,
+	"modify_cifs_share":                  updateOrDelete,
+	"modify_nfs_service":                 updateOrDelete,
+	"modify_cifs_service":                updateOrDelete,
+	"modify_qtree":                       updateOrDelete,
+	"modify_nvme_service":                updateOrDelete,
+	"modify_iscsi_service":               updateOrDelete,
+	"modify_lun":                         updateOrDelete,
+	"modify_network_ip_interface":        updateOrDelete,
+	"modify_nvme_subsystem":              updateOrDelete,
+	"modify_nvme_namespace":              updateOrDelete,
+	"modify_fcp_service":                 updateOrDelete,
+	"modify_fc_interface":                updateOrDelete,
+	"modify_igroup":                      updateOrDelete,
+	"modify_snapmirror":                  updateOrDelete,
+	"modify_snapshot":                    {"restore": accessUpdate, "delete": accessDelete},
+}
+
+// callAccess is the access a single call requires: the access of the
+// operation for multiplexed tools, otherwise the access the tool was
+// registered with.
+func callAccess(name string, annotations toolAnnotation, args map[string]any) string {
+	if access, ok := operationAccess[name][stringArgument(args, "operation")]; ok {
+		return access
+	}
+	return annotations.access
+}
+
+// authorizeToolsList hides the tools a caller may not use from tools/list.
+func (a *App) authorizeToolsList(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		res, err := next(ctx, method, req)
+		if err != nil || method != "tools/list" {
+			return res, err
+		}
+		perms := a.permissionsFor(req.GetExtra())
+		list, ok := res.(*mcp.ListToolsResult)
+		if perms == nil || !ok {
+			return res, nil
+		}
+		list.Tools = slices.DeleteFunc(list.Tools, func(t *mcp.Tool) bool {
+			access, ok := a.toolAccess.Load(t.Name)
+			return !ok || !perms.allowsAccess(access.(string))
+		})
+		return list, nil
+	}
+}
+
+func tokenClaims(info *auth.TokenInfo) jwt.MapClaims {
+	if info == nil || info.Extra == nil {
+		return nil
+	}
+	claims, _ := info.Extra["claims"].(jwt.MapClaims)
+	return claims
+}
+
+// claimHasValue reports whether the claim contains value. The claim may be a
+// string, a list of strings, or a dotted path into nested objects such as
+// realm_access.roles. The scope and scp claims are split on spaces.
+func claimHasValue(claims jwt.MapClaims, claim, value string) bool {
+	v, ok := lookupClaim(claims, claim)
+	if !ok {
+		return false
+	}
+	switch c := v.(type) {
+	case string:
+		if claim == "scope" || claim == "scp" {
+			return slices.Contains(strings.Fields(c), value)
+		}
+		return c == value
+	case []any:
+		for _, item := range c {
+			if s, ok := item.(string); ok && s == value {
+				return true
+			}
+		}
+	case []string:
+		return slices.Contains(c, value)
+	}
+	return false
+}
+
+func lookupClaim(claims map[string]any, path string) (any, bool) {
+	if v, ok := claims[path]; ok {
+		return v, true
+	}
+	head, rest, found := strings.Cut(path, ".")
+	if !found {
+		return nil, false
+	}
+	nested, ok := claims[head].(map[string]any)
+	if !ok {
+		return nil, false
+	}
+	return lookupClaim(nested, rest)
+}
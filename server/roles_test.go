@@ -0,0 +1,225 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/modelcontextprotocol/go-sdk/auth"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/netapp/ontap-mcp/config"
+)
+
+func TestNewRoles(t *testing.T) {
+	tests := []struct {
+		name        string
+		roles       []config.Role
+		errContains string
+	}{
+		{name: "valid", roles: []config.Role{{Claim: "groups", Value: "admins", Allow: config.StringSlice{"read", "Delete"}, Clusters: config.StringSlice{"*"}}}},
+		{name: "missing claim", roles: []config.Role{{Value: "admins", Allow: config.StringSlice{"read"}}}, errContains: "claim and value are required"},
+		{name: "missing allow", roles: []config.Role{{Claim: "groups", Value: "admins"}}, errContains: "allow must list"},
+		{name: "invalid allow", roles: []config.Role{{Claim: "groups", Value: "admins", Allow: config.StringSlice{"write"}}}, errContains: `invalid allow value "write"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newRoles(tt.roles)
+			if tt.errContains == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errContains) {
+				t.Fatalf("expected error containing %q, got %v", tt.errContains, err)
+			}
+		})
+	}
+}
+
+func TestClaimHasValue(t *testing.T) {
+	claims := jwt.MapClaims{
+		"groups":       []any{"storage-admins", "viewers"},
+		"role":         "operator",
+		"scope":        "openid ontap.read ontap.write",
+		"realm_access": map[string]any{"roles": []any{"dba"}},
+	}
+	tests := []struct {
+		claim string
+		value string
+		want  bool
+	}{
+		{claim: "groups", value: "viewers", want: true},
+		{claim: "groups", value: "admins"},
+		{claim: "role", value: "operator", want: true},
+		{claim: "scope", value: "ontap.write", want: true},
+		{claim: "scope", value: "ontap", want: false},
+		{claim: "realm_access.roles", value: "dba", want: true},
+		{claim: "missing", value: "x"},
+	}
+	for _, tt := range tests {
+		if got := claimHasValue(claims, tt.claim, tt.value); got != tt.want {
+			t.Errorf("claimHasValue(%s, %s) = %v, want %v", tt.claim, tt.value, got, tt.want)
+		}
+	}
+}
+
+// newRolesTestServer serves app over streamable HTTP. The bearer token is
+// the caller's group, so each client can act as a different user.
+func newRolesTestServer(t *testing.T, app *App) *httptest.Server {
+	t.Helper()
+	server := app.createMCPServer()
+	handler := mcp.NewStreamableHTTPHandler(func(*http.Request) *mcp.Server { return server }, nil)
+	verify := func(_ context.Context, token string, _ *http.Request) (*auth.TokenInfo, error) {
+		return &auth.TokenInfo{
+			Expiration: time.Now().Add(time.Hour),
+			Extra:      map[string]any{"claims": jwt.MapClaims{"groups": []any{token}}},
+		}, nil
+	}
+	ts := httptest.NewServer(auth.RequireBearerToken(verify, nil)(handler))
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+type bearerTransport struct {
+	token string
+}
+
+func (b bearerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+b.token)
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func connectAs(t *testing.T, url, group string) *mcp.ClientSession {
+	t.Helper()
+	client := mcp.NewClient(&mcp.Implementation{Name: "test", Version: "v0"}, nil)
+	session, err := client.Connect(t.Context(), &mcp.StreamableClientTransport{
+		Endpoint:             url,
+		HTTPClient:           &http.Client{Transport: bearerTransport{token: group}},
+		DisableStandaloneSSE: true,
+	}, nil)
+	if err != nil {
+		t.Fatalf("connect as %s: %v", group, err)
+	}
+	t.Cleanup(func() { _ = session.Close() })
+	return session
+}
+
+func TestRolesAuthorizeTools(t *testing.T) {
+	app := newONTAPTestApp(t, Options{}, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			_, _ = w.Write([]byte(`{}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"num_records":1,"records":[{"uuid":"v-uuid"}]}`))
+	})
+	app.roles, _ = newRoles([]config.Role{
+		{Claim: "groups", Value: "viewers", Allow: config.StringSlice{"read"}},
+		{Claim: "groups", Value: "dc1-admins", Allow: config.StringSlice{"read", "create", "update", "delete"}, Clusters: config.StringSlice{"DC1"}},
+		{Claim: "groups", Value: "dc2-admins", Allow: config.StringSlice{"read", "delete"}, Clusters: config.StringSlice{"dc2"}},
+	})
+	ts := newRolesTestServer(t, app)
+
+	toolNames := func(session *mcp.ClientSession) []string {
+		t.Helper()
+		res, err := session.ListTools(t.Context(), nil)
+		if err != nil {
+			t.Fatalf("ListTools: %v", err)
+		}
+		names := make([]string, 0, len(res.Tools))
+		for _, tool := range res.Tools {
+			names = append(names, tool.Name)
+		}
+		return names
+	}
+	deleteVolume := &mcp.CallToolParams{Name: "delete_volume", Arguments: map[string]any{"cluster_name": "dc1", "svm_name": "vs1", "volume_name": "vol1"}}
+
+	viewer := connectAs(t, ts.URL, "viewers")
+	names := toolNames(viewer)
+	if !slices.Contains(names, "list_registered_clusters") || slices.Contains(names, "delete_volume") || slices.Contains(names, "create_volume") {
+		t.Fatalf("viewer sees unexpected tools: %v", names)
+	}
+	res, err := viewer.CallTool(t.Context(), deleteVolume)
+	if err != nil {
+		t.Fatalf("CallTool: %v", err)
+	}
+	if !res.IsError || !strings.Contains(toolText(t, res), "not authorized") {
+		t.Fatalf("expected viewer delete to be rejected, got %s", toolText(t, res))
+	}
+
+	admin := connectAs(t, ts.URL, "dc1-admins")
+	if !slices.Contains(toolNames(admin), "delete_volume") {
+		t.Fatal("expected dc1 admin to see delete_volume")
+	}
+	res, err = admin.CallTool(t.Context(), deleteVolume)
+	if err != nil {
+		t.Fatalf("CallTool: %v", err)
+	}
+	if res.IsError {
+		t.Fatalf("expected dc1 admin delete to succeed, got %s", toolText(t, res))
+	}
+
+	// Delete access on another cluster does not extend to dc1.
+	other := connectAs(t, ts.URL, "dc2-admins")
+	res, err = other.CallTool(t.Context(), deleteVolume)
+	if err != nil {
+		t.Fatalf("CallTool: %v", err)
+	}
+	if !res.IsError || !strings.Contains(toolText(t, res), "not authorized") {
+		t.Fatalf("expected dc2 admin delete on dc1 to be rejected, got %s", toolText(t, res))
+	}
+	res, err = other.CallTool(t.Context(), &mcp.CallToolParams{Name: "list_registered_clusters", Arguments: map[string]any{}})
+	if err != nil {
+		t.Fatalf("CallTool: %v", err)
+	}
+	if strings.Contains(toolText(t, res), "dc1") {
+		t.Fatalf("expected dc1 to be hidden from dc2 admins, got %s", toolText(t, res))
+	}
+
+	nobody := connectAs(t, ts.URL, "contractors")
+	if names := toolNames(nobody); len(names) != 0 {
+		t.Fatalf("expected a caller without roles to see no tools, got %v", names)
+	}
+}
+
+func TestRolesMultiplexedOperations(t *testing.T) {
+	app := newONTAPTestApp(t, Options{ToolMode: "both"}, func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"num_records":1,"records":[{"uuid":"p-uuid","name":"sp1","copies":[{"schedule":{"name":"hourly"},"count":6}]}]}`))
+	})
+	app.roles, _ = newRoles([]config.Role{
+		{Claim: "groups", Value: "operators", Allow: config.StringSlice{"read", "update"}},
+	})
+	ts := newRolesTestServer(t, app)
+
+	// Every multiplexed tool must say which of its operations delete.
+	app.toolAccess.Range(func(name, _ any) bool {
+		if strings.HasPrefix(name.(string), "modify_") && operationAccess[name.(string)] == nil {
+			t.Errorf("%s has no entry in operationAccess", name)
+		}
+		return true
+	})
+
+	operator := connectAs(t, ts.URL, "operators")
+	res, err := operator.CallTool(t.Context(), &mcp.CallToolParams{
+		Name: "modify_schedule_in_snapshot_policy",
+		Arguments: map[string]any{
+			"cluster_name":  "dc1",
+			"svm_name":      "vs1",
+			"policy_name":   "sp1",
+			"schedule_name": "hourly",
+			"operation":     "remove",
+		},
+	})
+	if err != nil {
+		t.Fatalf("CallTool: %v", err)
+	}
+	if !res.IsError || !strings.Contains(toolText(t, res), "requires delete access") {
+		t.Fatalf("expected an update-only role to be denied removing a schedule, got %s", toolText(t, res))
+	}
+}
@@ -14,10 +14,9 @@ import (
 	"net"
 	"net/http"
 	"net/url"
-	"os"
 	"reflect"
+	"regexp"
 	"runtime/debug"
-	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -28,6 +27,8 @@ import (
 	"github.com/netapp/ontap-mcp/catalog"
 	"github.com/netapp/ontap-mcp/config"
 	"github.com/netapp/ontap-mcp/descriptions"
+	"github.com/netapp/ontap-mcp/metrics"
+	"github.com/netapp/ontap-mcp/ontap"
 	"github.com/netapp/ontap-mcp/rest"
 	"github.com/netapp/ontap-mcp/server/lock"
 	"github.com/netapp/ontap-mcp/tool"
@@ -36,15 +37,21 @@ import (
 )
 
 type Options struct {
-	Host           string
-	InspectTraffic bool
-	IsTest         bool
-	Port           int
-	ReadOnly       bool
-	Stateless      bool
-	JSONResponse   bool
-	ToolMode       string
-	TestHTTPClient *http.Client // Optional HTTP client for testing
+	Host                 string
+	InspectTraffic       bool
+	IsTest               bool
+	Port                 int
+	ReadOnly             bool
+	Plan                 bool          // capture mutating ONTAP requests instead of sending them
+	ConfirmDestructive   string        // one of ConfirmOff, ConfirmDeny, ConfirmAllow
+	LockTimeout          time.Duration // how long a write waits for other operations; DefaultLockTimeout when 0
+	ResourcePollInterval time.Duration // how often subscribed resources are checked for changes; DefaultResourcePollInterval when 0
+	Stateless            bool
+	JSONResponse         bool
+	ToolMode             string
+	Transport            string
+	APICatalog           string       // path of an API catalog to use instead of ApiCatalog in the config or the embedded one
+	TestHTTPClient       *http.Client // Optional HTTP client for testing
 }
 
 type App struct {
@@ -66,20 +73,37 @@ type App struct {
 	jwksFetched  time.Time
 	jwksRefresh  singleflight.Group
 	httpClient   *http.Client
-	versionCache sync.Map
+	cfgMu        sync.RWMutex
 	clusterIndex map[string]string // lowercase name → canonical config name
+	clients      *clientRegistry
 	certFile     string
 	keyFile      string
+	roles        []role
+	toolAccess   sync.Map // tool name → access kind required to call it
+	confirmKey   []byte   // signs the RequestState of delete confirmations
+	audit        *auditLog
+	completions  nameCache
+	prompts      []*runbookPrompt
 }
 
-type cachedVersion struct {
-	version string
-	fetched time.Time
-}
+const (
+	TransportHTTP  = "http"
+	TransportStdio = "stdio"
+)
 
-const versionCacheTTL = 24 * time.Hour
 const jwksCacheTTL = 5 * time.Minute
 
+// httpShutdownTimeout is how long the HTTP server waits for requests in flight
+// when it is stopped.
+const httpShutdownTimeout = 10 * time.Second
+
+// defaultSearchLimit and defaultListLimit are how many endpoints
+// search_ontap_endpoints and list_ontap_endpoints return when limit is not set.
+const (
+	defaultSearchLimit = 10
+	defaultListLimit   = 50
+)
+
 type jwksResponse struct {
 	Keys []jwkKey `json:"keys"`
 }
@@ -102,14 +126,9 @@ type jwkKey struct {
 }
 
 func NewApp(cfg *config.ONTAP, o Options, logger *slog.Logger) (*App, error) {
-	var err error
-	index := make(map[string]string, len(cfg.Pollers))
-	for name := range cfg.Pollers {
-		key := strings.ToLower(name)
-		if existing, collision := index[key]; collision {
-			return nil, fmt.Errorf("poller names %q and %q differ only by case; rename one to avoid ambiguity", existing, name)
-		}
-		index[key] = name
+	index, err := buildClusterIndex(cfg)
+	if err != nil {
+		return nil, err
 	}
 
 	var certFile, keyFile string
@@ -131,7 +150,10 @@ func NewApp(cfg *config.ONTAP, o Options, logger *slog.Logger) (*App, error) {
 	scope := ""
 	audience := ""
 	oauthEnabled := false
-	var algOverride []string
+	var (
+		algOverride []string
+		roles       []role
+	)
 
 	if cfg.McpAuth != nil {
 		issuer = strings.TrimSpace(cfg.McpAuth.Issuer)
@@ -148,11 +170,25 @@ func NewApp(cfg *config.ONTAP, o Options, logger *slog.Logger) (*App, error) {
 		if cfg.McpAuth.Scope != "" {
 			scope = strings.TrimSpace(cfg.McpAuth.Scope)
 		}
+		roles, err = newRoles(cfg.McpAuth.Roles)
+		if err != nil {
+			return nil, err
+		}
 		oauthEnabled = true
 	} else {
 		logger.Error("OAuth bearer auth disabled as McpAuth is not configured. Falling back to non-oauth workflow")
 	}
 
+	audit, err := newAuditLog(cfg.Audit)
+	if err != nil {
+		return nil, err
+	}
+
+	prompts, err := loadPrompts(cfg.Prompts)
+	if err != nil {
+		return nil, err
+	}
+
 	app := &App{
 		cfg:          cfg,
 		logger:       logger,
@@ -169,8 +205,13 @@ func NewApp(cfg *config.ONTAP, o Options, logger *slog.Logger) (*App, error) {
 		keyAlg:       make(map[string]string),
 		httpClient:   httpClient,
 		clusterIndex: index,
+		clients:      newClientRegistry(),
 		certFile:     certFile,
 		keyFile:      keyFile,
+		roles:        roles,
+		audit:        audit,
+		confirmKey:   newConfirmKey(),
+		prompts:      prompts,
 	}
 
 	// When OAuth is enabled, pre-warm the JWKS so a misconfigured issuer fails
@@ -182,18 +223,20 @@ func NewApp(cfg *config.ONTAP, o Options, logger *slog.Logger) (*App, error) {
 		}
 	}
 
-	const catalogPath = "conf/ontap_api_catalog.json"
-	if cat, err := catalog.Load(catalogPath); err == nil {
-		app.catalog = cat
-		logger.Info("loaded API catalog", slog.Int("endpoints", len(cat)), slog.String("path", catalogPath))
-	} else {
-		logger.Warn("API catalog not found — catalog tools disabled", slog.String("path", catalogPath))
+	cat, source, err := loadCatalog(o.APICatalog, cfg.APICatalog)
+	if err != nil {
+		return nil, err
 	}
+	app.catalog = cat
+	logger.Info("loaded API catalog", slog.Int("endpoints", len(cat)), slog.String("catalog", source))
 
 	return app, nil
 }
 
-func (a *App) StartServer() {
+// StartServer serves MCP until ctx is canceled, e.g. on SIGTERM, or the
+// stdio client disconnects. The HTTP server finishes the requests in flight
+// before it returns.
+func (a *App) StartServer(ctx context.Context) error {
 	a.logger.Info(version.String())
 	if a.options.ReadOnly {
 		a.logger.Info("MCP server is running in read-only mode; mutating operations are disabled")
@@ -204,26 +247,69 @@ func (a *App) StartServer() {
 	if a.options.JSONResponse {
 		a.logger.Info("MCP server is responding with application/json instead of text/event-stream")
 	}
+
+	server := a.createMCPServer()
+
+	if a.options.Transport == TransportStdio {
+		if a.oauthEnabled {
+			a.logger.Warn("McpAuth is ignored with the stdio transport; the MCP client owns the server process")
+		}
+		return a.runStdioServer(ctx, server)
+	}
+
 	if a.oauthEnabled {
 		a.logger.Info("OAuth bearer auth enabled using McpAuth")
 	} else {
 		a.logger.Error("OAuth bearer auth disabled. Falling back to non-oauth workflow")
 	}
+	return a.runHTTPServer(ctx, server)
+}
+
+// runStdioServer serves the MCP server over stdin/stdout until the client
+// closes the stream. Stdout carries the MCP protocol, so all logging must go
+// to stderr.
+func (a *App) runStdioServer(ctx context.Context, server *mcp.Server) error {
+	a.logger.Info("starting MCP server over", slog.String("transport", "stdio"))
+
+	if err := a.serve(ctx, server, &mcp.StdioTransport{}); err != nil {
+		return fmt.Errorf("stdio server failed: %w", err)
+	}
 
-	server := a.createMCPServer()
-	a.runHTTPServer(server)
+	a.logger.Info("mcp server shutdown gracefully")
+	return nil
+}
+
+// serve runs the MCP server on the given transport until the peer disconnects.
+// A clean EOF from the client is not treated as an error.
+func (a *App) serve(ctx context.Context, server *mcp.Server, transport mcp.Transport) error {
+	err := server.Run(ctx, transport)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, context.Canceled) {
+		return err
+	}
+	return nil
 }
 
 func (a *App) createMCPServer() *mcp.Server {
 	instructions := "IMPORTANT:" + descriptions.Instructions
 
+	watcher := newResourceWatcher(a)
 	server := mcp.NewServer(&mcp.Implementation{Name: config.AppName, Version: version.Info()}, &mcp.ServerOptions{
-		Instructions: instructions,
-		Logger:       a.logger,
+		Instructions:       instructions,
+		Logger:             a.logger,
+		SubscribeHandler:   watcher.subscribe,
+		UnsubscribeHandler: watcher.unsubscribe,
+		CompletionHandler:  a.Complete,
 	})
+	watcher.server = server
+	server.AddReceivingMiddleware(a.authorizeToolsList)
+	addResources(a, server)
+	addPrompts(a, server)
 
 	addTool(a, server, "list_registered_clusters", descriptions.ListClusters, readOnlyAnnotation, a.ListClusters)
 	addTool(a, server, "list_qos_policies", descriptions.ListQoSPolicies, readOnlyAnnotation, a.ListQoSPolicies)
+	addTool(a, server, "list_ontap_jobs", descriptions.ListOntapJobs, readOnlyAnnotation, a.ListOntapJobs)
+	addTool(a, server, "get_ontap_job", descriptions.GetOntapJob, readOnlyAnnotation, a.GetOntapJob)
+	addTool(a, server, "cancel_ontap_job", descriptions.CancelOntapJob, updateAnnotation, a.CancelOntapJob)
 
 	// operation on Volume object
 	addTool(a, server, "create_volume", descriptions.CreateVolume, createAnnotation, a.CreateVolume)
@@ -348,11 +434,12 @@ func (a *App) createMCPServer() *mcp.Server {
 		addTool(a, server, "describe_ontap_endpoint", descriptions.DescribeOntapEndpoint, readOnlyAnnotation, a.DescribeOntapEndpoint)
 	}
 	addTool(a, server, "ontap_get", descriptions.OntapGet, readOnlyAnnotation, a.OntapGet)
+	addTool(a, server, "ontap_get_multi", descriptions.OntapGetMulti, readOnlyAnnotation, a.OntapGetMulti)
 
 	return server
 }
 
-func (a *App) runHTTPServer(server *mcp.Server) {
+func (a *App) runHTTPServer(ctx context.Context, server *mcp.Server) error {
 	var handler http.Handler
 
 	var urlPath, transportMethod string
@@ -413,6 +500,9 @@ func (a *App) runHTTPServer(server *mcp.Server) {
 		_, _ = w.Write([]byte("OK"))
 	})
 
+	// Prometheus metrics endpoint
+	mux.Handle("/metrics", a.metricsHandler())
+
 	if a.oauthEnabled {
 		var scopesSupported []string
 		if a.scope != "" {
@@ -454,27 +544,45 @@ func (a *App) runHTTPServer(server *mcp.Server) {
 		IdleTimeout:       60 * time.Second,
 	}
 
-	if tlsEnabled {
-		httpServer.TLSConfig = &tls.Config{
-			MinVersion: tls.VersionTLS13,
-		}
-		if err := httpServer.ListenAndServeTLS(a.certFile, a.keyFile); err != nil && !errors.Is(err, http.ErrServerClosed) {
-			a.logger.Error("http server failed to start",
-				slog.Any("error", err),
-				slog.String("url", urlPath),
-				slog.String("cert_file", a.certFile),
-				slog.String("key_file", a.keyFile))
-			os.Exit(1)
+	errCh := make(chan error, 1)
+	go func() {
+		if tlsEnabled {
+			httpServer.TLSConfig = &tls.Config{
+				MinVersion: tls.VersionTLS13,
+			}
+			errCh <- httpServer.ListenAndServeTLS(a.certFile, a.keyFile)
+			return
 		}
-	} else {
-		if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
-			a.logger.Error("http server failed to start",
-				slog.Any("error", err))
-			os.Exit(1)
+		errCh <- httpServer.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errCh:
+		if tlsEnabled {
+			return fmt.Errorf("http server failed to start on %s with cert_file %s and key_file %s: %w", urlPath, a.certFile, a.keyFile, err)
 		}
+		return fmt.Errorf("http server failed to start on %s: %w", urlPath, err)
+	case <-ctx.Done():
+	}
+
+	a.logger.Info("shutting down MCP server")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), httpShutdownTimeout)
+	defer cancel()
+	if err := httpServer.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("http server shutdown: %w", err)
 	}
 
 	a.logger.Info("mcp server shutdown gracefully")
+	return nil
+}
+
+// metricsHandler serves /metrics. With McpAuth it requires the same bearer
+// token as the MCP endpoint, since the metrics name clusters and tools.
+func (a *App) metricsHandler() http.Handler {
+	if a.oauthEnabled {
+		return a.OAuthMiddleware(metrics.Handler())
+	}
+	return metrics.Handler()
 }
 
 type clusterInfo struct {
@@ -482,40 +590,45 @@ type clusterInfo struct {
 	ONTAPVersion string `json:"ontap_version"`
 }
 
-func (a *App) resolveCluster(input string) (string, bool) {
-	canonical, ok := a.clusterIndex[strings.ToLower(input)]
-	return canonical, ok
+// ClustersResponse is the structured output of list_registered_clusters.
+type ClustersResponse struct {
+	Clusters []clusterInfo `json:"clusters"`
 }
 
-func (a *App) getClusterVersion(ctx context.Context, cluster string) (string, error) {
-	canonical, ok := a.resolveCluster(cluster)
-	if !ok {
-		return "", fmt.Errorf("cluster %s not found", cluster)
+// resolveCluster maps a case-insensitive cluster name to its canonical name
+// and poller. Disabled pollers are rejected so tools never reach a cluster
+// that is down for maintenance.
+func (a *App) resolveCluster(input string) (string, *config.Poller, error) {
+	a.cfgMu.RLock()
+	defer a.cfgMu.RUnlock()
+	canonical, ok := a.clusterIndex[strings.ToLower(input)]
+	poller := a.cfg.Pollers[canonical]
+	if !ok || poller == nil {
+		return "", nil, fmt.Errorf("cluster %s not found", input)
 	}
-
-	if cached, ok := a.versionCache.Load(canonical); ok {
-		cv := cached.(cachedVersion)
-		if time.Since(cv.fetched) < versionCacheTTL {
-			return cv.version, nil
-		}
+	if poller.IsDisabled {
+		return "", nil, fmt.Errorf("cluster %s is disabled", canonical)
 	}
+	return canonical, poller, nil
+}
 
+// getClusterVersion returns the cluster's ONTAP version as "generation.major".
+// The pooled client caches the cluster Remote, so this only reaches ONTAP on
+// the first call or after the cache expires.
+func (a *App) getClusterVersion(ctx context.Context, cluster string) (string, error) {
 	client, err := a.getClient(cluster)
 	if err != nil {
 		return "", err
 	}
-	remote, err := client.GetClusterInfo(ctx)
+	remote, err := client.Remote(ctx)
 	if err != nil {
 		return "", err
 	}
-	ver := fmt.Sprintf("%d.%d", remote.Version.Generation, remote.Version.Major)
-	a.versionCache.Store(canonical, cachedVersion{version: ver, fetched: time.Now()})
-	return ver, nil
+	return fmt.Sprintf("%d.%d", remote.Version.Generation, remote.Version.Major), nil
 }
 
-func (a *App) ListClusters(ctx context.Context, _ *mcp.CallToolRequest, _ tool.ListClusterParams) (*mcp.CallToolResult, any, error) {
-	clusters := slices.Clone(a.cfg.PollersOrdered)
-	slices.Sort(clusters)
+func (a *App) ListClusters(ctx context.Context, req *mcp.CallToolRequest, _ tool.ListClusterParams) (*mcp.CallToolResult, ClustersResponse, error) {
+	clusters := a.clusterNames(a.permissionsFor(callExtra(req)))
 
 	infos := make([]clusterInfo, 0, len(clusters))
 	for _, name := range clusters {
@@ -530,30 +643,50 @@ func (a *App) ListClusters(ctx context.Context, _ *mcp.CallToolRequest, _ tool.L
 
 	data, err := json.Marshal(infos)
 	if err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), ClustersResponse{}, err
 	}
 	return &mcp.CallToolResult{
 		Content: []mcp.Content{
 			&mcp.TextContent{Text: string(data)},
 		},
-	}, nil, nil
+	}, ClustersResponse{Clusters: infos}, nil
 }
 
 func (a *App) ListOntapEndpoints(_ context.Context, _ *mcp.CallToolRequest, p tool.ListEndpointsParams) (*mcp.CallToolResult, any, error) {
-	var results []catalog.SearchResult
+	if p.Offset < 0 || p.Limit < 0 {
+		return errorResult(errors.New("offset and limit must not be negative")), nil, nil
+	}
+	var re *regexp.Regexp
 	if p.Match != "" {
-		results = a.catalog.Search(p.Match)
-	} else {
-		results = a.catalog.ListAll()
+		var err error
+		if re, err = catalog.CompilePattern(p.Match); err != nil {
+			return errorResult(err), nil, nil
+		}
 	}
+	results := a.catalog.Filter(re, p.Tag)
 	if len(results) == 0 {
 		return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: "No endpoints found"}}}, nil, nil
 	}
+	if p.Offset >= len(results) {
+		return errorResult(fmt.Errorf("offset %d is past the last of %d endpoints", p.Offset, len(results))), nil, nil
+	}
 
+	limit := p.Limit
+	if limit == 0 {
+		limit = defaultListLimit
+	}
+	end := min(p.Offset+limit, len(results))
 	var sb strings.Builder
-	fmt.Fprintf(&sb, "Found %d endpoints:\n", len(results))
-	for _, r := range results {
-		fmt.Fprintf(&sb, "%s — %s\n", r.Path, r.Endpoint.Summary)
+	fmt.Fprintf(&sb, "Found %d endpoints, showing %d-%d:\n", len(results), p.Offset+1, end)
+	for _, r := range results[p.Offset:end] {
+		if p.Compact {
+			fmt.Fprintln(&sb, r.Path)
+		} else {
+			fmt.Fprintf(&sb, "%s — %s\n", r.Path, r.Endpoint.Summary)
+		}
+	}
+	if end < len(results) {
+		fmt.Fprintf(&sb, "\n%d more; call again with offset %d.\n", len(results)-end, end)
 	}
 	return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: sb.String()}}}, nil, nil
 }
@@ -562,7 +695,14 @@ func (a *App) SearchOntapEndpoints(_ context.Context, _ *mcp.CallToolRequest, p
 	if p.Query == "" {
 		return errorResult(errors.New("query parameter is required")), nil, nil
 	}
-	results := a.catalog.Search(p.Query)
+	if p.Limit < 0 {
+		return errorResult(errors.New("limit must not be negative")), nil, nil
+	}
+	limit := p.Limit
+	if limit == 0 {
+		limit = defaultSearchLimit
+	}
+	results := a.catalog.Search(p.Query, 0)
 	if len(results) == 0 {
 		return &mcp.CallToolResult{
 			Content: []mcp.Content{&mcp.TextContent{Text: "No endpoints found matching: " + p.Query}},
@@ -570,9 +710,15 @@ func (a *App) SearchOntapEndpoints(_ context.Context, _ *mcp.CallToolRequest, p
 	}
 
 	var sb strings.Builder
-	fmt.Fprintf(&sb, "Found %d endpoints matching %q:\n", len(results), p.Query)
+	if len(results) > limit {
+		fmt.Fprintf(&sb, "Found %d endpoints matching %q, best %d first:\n", len(results), p.Query, limit)
+		results = results[:limit]
+	} else {
+		fmt.Fprintf(&sb, "Found %d endpoints matching %q, best first:\n", len(results), p.Query)
+	}
 	for _, r := range results {
 		fmt.Fprintf(&sb, "%s — %s\n", r.Path, r.Endpoint.Summary)
+		fmt.Fprintf(&sb, "  why: %s\n", strings.Join(r.Reasons, "; "))
 	}
 	sb.WriteString("\nCall describe_ontap_endpoint for filters and fields before calling ontap_get.")
 	return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: sb.String()}}}, nil, nil
@@ -591,14 +737,24 @@ func (a *App) DescribeOntapEndpoint(ctx context.Context, _ *mcp.CallToolRequest,
 
 	var versionNote string
 	if p.Cluster != "" {
-		if ontapVer, err := a.getClusterVersion(ctx, p.Cluster); err == nil {
+		if ontapVer, err := a.getClusterVersion(ctx, p.Cluster); err == nil && knownVersion(ontapVer) {
+			if !ep.AvailableIn(ontapVer) {
+				return &mcp.CallToolResult{
+					Content: []mcp.Content{&mcp.TextContent{Text: fmt.Sprintf("Endpoint %s is not available on cluster %s, which runs ONTAP %s. It is available %s.",
+						p.Path, p.Cluster, ontapVer, availability(ep.Introduced, ep.Until))}},
+				}, nil, nil
+			}
 			ep = ep.FilterByVersion(ontapVer)
 			versionNote = ", filtered for ONTAP " + ontapVer
 		}
 	}
 
 	var sb strings.Builder
-	fmt.Fprintf(&sb, "%s (since %s%s)\n", p.Path, ep.Introduced, versionNote)
+	introduced := "since " + ep.Introduced
+	if ep.Until != "" {
+		introduced += ", removed in " + ep.Until
+	}
+	fmt.Fprintf(&sb, "%s (%s%s)\n", p.Path, introduced, versionNote)
 
 	if len(ep.PathParams) > 0 {
 		names := make([]string, 0, len(ep.PathParams))
@@ -626,24 +782,9 @@ func (a *App) DescribeOntapEndpoint(ctx context.Context, _ *mcp.CallToolRequest,
 		sb.WriteString("Filters:\n")
 		for _, name := range names {
 			f := ep.Filters[name]
-			typ := f.Type
-			if typ == "" {
-				typ = "string"
-			}
-			var ann string
-			switch {
-			case typ != "string" && f.Since != "":
-				ann = "(" + typ + "," + f.Since + ")"
-			case typ != "string":
-				ann = "(" + typ + ")"
-			case f.Since != "":
-				ann = "(" + f.Since + ")"
-			}
-			if f.Desc != "" {
-				fmt.Fprintf(&sb, "  %s%s — %s\n", name, ann, f.Desc)
-			} else {
-				fmt.Fprintf(&sb, "  %s%s\n", name, ann)
-			}
+			notes := typeNotes(f.Type, f.Items, f.Format, f.Unit, false)
+			notes = append(notes, versionNotes(f.Since, f.Until)...)
+			writeCatalogEntry(&sb, name, notes, f.Desc, f.Enum)
 		}
 	}
 
@@ -656,52 +797,91 @@ func (a *App) DescribeOntapEndpoint(ctx context.Context, _ *mcp.CallToolRequest,
 		sb.WriteString("Fields:\n")
 		for _, name := range names {
 			f := ep.Fields[name]
-			if f.Since != "" {
-				fmt.Fprintf(&sb, "  %s(%s) — %s\n", name, f.Since, f.Desc)
-			} else {
-				fmt.Fprintf(&sb, "  %s — %s\n", name, f.Desc)
-			}
+			notes := typeNotes(f.Type, f.Items, f.Format, f.Unit, f.ReadOnly)
+			notes = append(notes, versionNotes(f.Since, f.Until)...)
+			writeCatalogEntry(&sb, name, notes, f.Desc, f.Enum)
 		}
 	}
 
 	return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: sb.String()}}}, nil, nil
 }
 
-func (a *App) OntapGet(ctx context.Context, _ *mcp.CallToolRequest, p tool.OntapGetParams) (*mcp.CallToolResult, any, error) {
+func (a *App) OntapGet(ctx context.Context, req *mcp.CallToolRequest, p tool.OntapGetParams) (*mcp.CallToolResult, OntapGetResponse, error) {
 	if p.Cluster == "" {
-		return errorResult(errors.New("cluster_name is required")), nil, nil
+		return errorResult(errors.New("cluster_name is required")), OntapGetResponse{}, nil
 	}
-	if p.Path == "" {
-		return errorResult(errors.New("path is required")), nil, nil
+	query, err := parseRecordQuery(p)
+	if err != nil {
+		return errorResult(err), OntapGetResponse{}, nil
+	}
+	path, err := resolveGetPath(p.Path, p.PathParams)
+	if err != nil {
+		return errorResult(err), OntapGetResponse{}, nil
+	}
+
+	release, err := a.acquireLock(ctx, a.newProgressReporter(ctx, req), lock.Key{Cluster: p.Cluster}, true)
+	if err != nil {
+		return errorResult(err), OntapGetResponse{}, nil
+	}
+	defer release()
+
+	if !query.empty() {
+		p.Fields = query.fields(p.Fields)
+	}
+	raw, err := a.ontapGet(ctx, p, path)
+	if err != nil {
+		return errorResult(err), OntapGetResponse{}, err
+	}
+
+	if query.empty() {
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{&mcp.TextContent{Text: string(raw)}},
+		}, newOntapGetResponse(raw), nil
 	}
-	if !strings.HasPrefix(p.Path, "/") {
-		return errorResult(fmt.Errorf("path must start with /, got %q", p.Path)), nil, nil
+
+	// The text content is the shaped response, so the model only reads the
+	// records and groups it asked for.
+	out := query.apply(newOntapGetResponse(raw))
+	text, err := json.Marshal(out)
+	if err != nil {
+		return errorResult(err), OntapGetResponse{}, err
 	}
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: string(text)}},
+	}, out, nil
+}
 
-	if p.PathParams == nil {
-		p.PathParams = make(map[string]string)
+// resolveGetPath validates path and fills in its placeholders, e.g.
+// {volume.uuid}, from pathParams.
+func resolveGetPath(path string, pathParams map[string]string) (string, error) {
+	if path == "" {
+		return "", errors.New("path is required")
 	}
-	if p.Filters == nil {
-		p.Filters = make(map[string]string)
+	if !strings.HasPrefix(path, "/") {
+		return "", fmt.Errorf("path must start with /, got %q", path)
 	}
 
-	// Resolve any path-parameter placeholders (e.g. {volume.uuid}) before making the request.
-	resolvedPath := p.Path
-	for k, v := range p.PathParams {
+	resolvedPath := path
+	for k, v := range pathParams {
 		escaped := url.PathEscape(v)
 		resolvedPath = strings.ReplaceAll(resolvedPath, "{"+k+"}", escaped)
 	}
 	if strings.Contains(resolvedPath, "{") {
-		return errorResult(fmt.Errorf("path %q has unresolved placeholders. Provide their values via path_params (e.g. {\"volume.uuid\": \"<value>\"})", resolvedPath)), nil, nil
+		return "", fmt.Errorf("path %q has unresolved placeholders. Provide their values via path_params (e.g. {\"volume.uuid\": \"<value>\"})", resolvedPath)
 	}
-	p.Path = resolvedPath
-
-	a.locks.RLock(p.Cluster)
-	defer a.locks.RUnlock(p.Cluster)
+	return resolvedPath, nil
+}
 
+// ontapGet runs the query of p against path, which is p.Path with its
+// placeholders filled in, on p.Cluster and returns the response without _links.
+func (a *App) ontapGet(ctx context.Context, p tool.OntapGetParams, path string) (json.RawMessage, error) {
 	client, err := a.getClient(p.Cluster)
 	if err != nil {
-		return errorResult(err), nil, err
+		return nil, err
+	}
+	ver, verErr := a.getClusterVersion(ctx, p.Cluster)
+	if err := a.checkCatalog(p, ver); err != nil {
+		return nil, err
 	}
 
 	params := url.Values{}
@@ -715,18 +895,38 @@ func (a *App) OntapGet(ctx context.Context, _ *mcp.CallToolRequest, p tool.Ontap
 		params.Set("max_records", strconv.Itoa(p.MaxRecords))
 	}
 	// ignore_unknown_fields was introduced in ONTAP 9.11. skip for older clusters.
-	if ver, err := a.getClusterVersion(ctx, p.Cluster); err != nil || catalog.CompareVersions(ver, "9.11") >= 0 {
+	if verErr != nil || catalog.CompareVersions(ver, "9.11") >= 0 {
 		params.Set("ignore_unknown_fields", "true")
 	}
 
-	raw, err := client.GenericGet(ctx, p.Path, params, p.MaxRecords)
+	raw, err := client.GenericGet(ctx, path, params, p.MaxRecords)
 	if err != nil {
-		return errorResult(err), nil, err
+		return nil, err
 	}
+	return stripLinks(raw), nil
+}
 
-	return &mcp.CallToolResult{
-		Content: []mcp.Content{&mcp.TextContent{Text: string(stripLinks(raw))}},
-	}, nil, nil
+// OntapGetResponse is the structured output of ontap_get. A single object,
+// such as the response for /storage/volumes/{uuid}, is its only record.
+type OntapGetResponse struct {
+	NumRecords   int              `json:"num_records" jsonschema:"number of records returned, or with group_by or aggregate the number of records in the groups"`
+	Records      []map[string]any `json:"records,omitzero" jsonschema:"the records ONTAP returned, with the requested fields. Omitted when group_by or aggregate is set"`
+	Groups       []RecordGroup    `json:"groups,omitzero" jsonschema:"the groups, when group_by or aggregate is set"`
+	TotalRecords int              `json:"total_records,omitzero" jsonschema:"number of records ONTAP returned before select, sort_by, group_by and top_n were applied"`
+}
+
+func newOntapGetResponse(raw json.RawMessage) OntapGetResponse {
+	var collection struct {
+		Records []map[string]any `json:"records"`
+	}
+	if err := json.Unmarshal(raw, &collection); err == nil && collection.Records != nil {
+		return OntapGetResponse{NumRecords: len(collection.Records), Records: collection.Records}
+	}
+	var record map[string]any
+	if err := json.Unmarshal(raw, &record); err != nil || record == nil {
+		return OntapGetResponse{}
+	}
+	return OntapGetResponse{NumRecords: 1, Records: []map[string]any{record}}
 }
 
 func errorResult(err error) *mcp.CallToolResult {
@@ -773,44 +973,52 @@ func stripLinksValue(v any) any {
 	}
 }
 
-func (a *App) getClient(cluster string) (*rest.Client, error) {
-	canonical, ok := a.resolveCluster(cluster)
-	if !ok {
-		return nil, fmt.Errorf("cluster %s not found", cluster)
-	}
-	poller := a.cfg.Pollers[canonical]
-	if a.options.TestHTTPClient != nil {
-		return rest.NewWithClient(poller, a.options.TestHTTPClient), nil
-	}
-	return rest.New(poller), nil
+// toolAnnotation is the MCP annotations of a kind of tool and the role
+// access it requires.
+type toolAnnotation struct {
+	mcp.ToolAnnotations
+	access string
 }
 
 var (
-	readOnlyAnnotation = mcp.ToolAnnotations{
-		ReadOnlyHint: true,
+	readOnlyAnnotation = toolAnnotation{
+		ToolAnnotations: mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+		access: accessRead,
 	}
-	createAnnotation = mcp.ToolAnnotations{
-		DestructiveHint: new(false),
+	createAnnotation = toolAnnotation{
+		ToolAnnotations: mcp.ToolAnnotations{
+			DestructiveHint: new(false),
+		},
+		access: accessCreate,
 	}
-	updateAnnotation = mcp.ToolAnnotations{
-		DestructiveHint: new(true),
-		IdempotentHint:  true,
+	updateAnnotation = toolAnnotation{
+		ToolAnnotations: mcp.ToolAnnotations{
+			DestructiveHint: new(true),
+			IdempotentHint:  true,
+		},
+		access: accessUpdate,
 	}
-	deleteAnnotation = mcp.ToolAnnotations{
-		DestructiveHint: new(true),
-		IdempotentHint:  true,
+	deleteAnnotation = toolAnnotation{
+		ToolAnnotations: mcp.ToolAnnotations{
+			DestructiveHint: new(true),
+			IdempotentHint:  true,
+		},
+		access: accessDelete,
 	}
 )
 
-func addTool[In, Out any](a *App, server *mcp.Server, name string, description string, annotations mcp.ToolAnnotations, handler mcp.ToolHandlerFor[In, Out]) {
+func addTool[In, Out any](a *App, server *mcp.Server, name string, description string, annotations toolAnnotation, handler mcp.ToolHandlerFor[In, Out]) {
 	if a.options.ReadOnly && !annotations.ReadOnlyHint {
 		a.logger.Warn("skipping registration of destructive tool in read-only mode", slog.String("tool", name))
 		return
 	}
+	toolAnnotations := annotations.ToolAnnotations
 	tt := &mcp.Tool{
 		Name:        name,
 		Description: description,
-		Annotations: &annotations,
+		Annotations: &toolAnnotations,
 	}
 
 	// Check if the tool handlers in param has any fields. If it doesn't, create a schema with an empty properties
@@ -825,12 +1033,63 @@ func addTool[In, Out any](a *App, server *mcp.Server, name string, description s
 		tt.InputSchema = json.RawMessage(`{"type":"object","properties":{}}`)
 	}
 
-	safeHandler := func(ctx context.Context, req *mcp.CallToolRequest, params In) (*mcp.CallToolResult, Out, error) {
+	a.toolAccess.Store(name, annotations.access)
+
+	mutating := !annotations.ReadOnlyHint
+	if mutating && tt.InputSchema == nil {
+		if err := addMutatingArguments[In](tt); err != nil {
+			a.logger.Error("failed to add dry_run and async arguments", slog.String("tool", name), slog.Any("error", err))
+		}
+	}
+
+	// run guards the tool handler with role checks, dry runs, delete
+	// confirmation, write locking, async jobs and panic recovery. It also
+	// reports the audit outcome when the call did not simply succeed or fail.
+	run := func(ctx context.Context, req *mcp.CallToolRequest, params In, args map[string]any) (*mcp.CallToolResult, Out, string, error) {
 		var (
-			res *mcp.CallToolResult
-			out Out
-			err error
+			res     *mcp.CallToolResult
+			out     Out
+			err     error
+			plan    *rest.Plan
+			jobs    *rest.Jobs
+			created *rest.Created
+			outcome string
 		)
+		if perms := a.permissionsFor(callExtra(req)); perms != nil {
+			access := callAccess(name, annotations, args)
+			cluster := stringArgument(args, "cluster_name")
+			if !perms.allows(access, cluster) {
+				a.logger.Warn("tool call denied by McpAuth roles",
+					slog.String("tool", name),
+					slog.String("access", access),
+					slog.String("cluster", cluster))
+				return errorResult(fmt.Errorf("not authorized to call %s on cluster %s: requires %s access", name, cluster, access)), out, auditDenied, nil
+			}
+		}
+		if mutating {
+			if a.options.Plan || dryRunRequested(args) {
+				ctx, plan = rest.WithPlan(ctx)
+			} else if a.confirmationEnabled() && needsConfirmation(name, annotations, args) {
+				var pending *mcp.CallToolResult
+				if pending, outcome = a.confirmDestructive(ctx, name, req, args); pending != nil {
+					return pending, out, outcome, nil
+				}
+			}
+			if plan == nil {
+				progress := a.newProgressReporter(ctx, req)
+				if !unlockedTools[name] {
+					release, lockErr := a.acquireLock(ctx, progress, lockKey(args), false)
+					if lockErr != nil {
+						return errorResult(lockErr), out, "", nil
+					}
+					defer release()
+				}
+				ctx, jobs = rest.WithJobs(ctx, asyncRequested(args), func(jr ontap.JobResponse) {
+					progress.step(jobProgressMessage(jr))
+				})
+				ctx, created = rest.WithCreated(ctx)
+			}
+		}
 		func() {
 			defer func() {
 				if rec := recover(); rec != nil {
@@ -843,6 +1102,32 @@ func addTool[In, Out any](a *App, server *mcp.Server, name string, description s
 			}()
 			res, out, err = handler(ctx, req, params)
 		}()
+		if plan != nil && err == nil && (res == nil || !res.IsError) {
+			var zero Out
+			res, err = planResult(plan)
+			return res, writeOutput(zero, res, nil, nil, true), auditDryRun, err
+		}
+		if jobs != nil && err == nil && (res == nil || !res.IsError) {
+			if started := jobs.Started(); len(started) > 0 {
+				var zero Out
+				res = asyncResult(name, stringArgument(args, "cluster_name"), started)
+				return res, writeOutput(zero, res, jobs, created, false), outcome, nil
+			}
+			out = writeOutput(out, res, jobs, created, false)
+		}
+		return res, out, outcome, err
+	}
+
+	safeHandler := func(ctx context.Context, req *mcp.CallToolRequest, params In) (*mcp.CallToolResult, Out, error) {
+		start := time.Now()
+		args := toolArguments(req)
+		ctx, span := startToolSpan(ctx, req, name, args)
+		res, out, outcome, err := run(ctx, req, params, args)
+		elapsed := time.Since(start)
+		outcome, errText := callOutcome(outcome, res, err)
+		endToolSpan(span, outcome, errText)
+		metrics.ObserveToolCall(name, errText != "", elapsed)
+		a.auditToolCall(req, name, args, out, outcome, errText, elapsed)
 		return res, out, err
 	}
 
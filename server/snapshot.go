@@ -9,111 +9,91 @@ import (
 	"github.com/netapp/ontap-mcp/tool"
 )
 
-func (a *App) CreateSnapshot(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.Snapshot) (*mcp.CallToolResult, any, error) {
-	if !a.locks.TryLock(parameters.Cluster) {
-		return errorResult(fmt.Errorf("another write operation is in progress on cluster %s, please try again", parameters.Cluster)), nil, nil
-	}
-	defer a.locks.Unlock(parameters.Cluster)
-
+func (a *App) CreateSnapshot(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.Snapshot) (*mcp.CallToolResult, WriteResponse, error) {
 	snapshotCreate, err := newCreateSnapshot(parameters)
 	if err != nil {
-		return nil, nil, err
+		return nil, WriteResponse{}, err
 	}
 
 	client, err := a.getClient(parameters.Cluster)
 	if err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), WriteResponse{}, err
 	}
 
 	if err := client.CreateSnapshot(ctx, snapshotCreate, parameters.Volume, parameters.SVM); err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), WriteResponse{}, err
 	}
 
 	return &mcp.CallToolResult{
 		Content: []mcp.Content{
 			&mcp.TextContent{Text: "Snapshot created successfully"},
 		},
-	}, nil, nil
+	}, WriteResponse{}, nil
 }
 
-func (a *App) DeleteSnapshot(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.Snapshot) (*mcp.CallToolResult, any, error) {
-	if !a.locks.TryLock(parameters.Cluster) {
-		return errorResult(fmt.Errorf("another write operation is in progress on cluster %s, please try again", parameters.Cluster)), nil, nil
-	}
-	defer a.locks.Unlock(parameters.Cluster)
-
+func (a *App) DeleteSnapshot(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.Snapshot) (*mcp.CallToolResult, WriteResponse, error) {
 	if parameters.SVM == "" {
-		return nil, nil, errors.New("SVM name is required")
+		return nil, WriteResponse{}, errors.New("SVM name is required")
 	}
 	if parameters.Volume == "" {
-		return nil, nil, errors.New("volume name is required")
+		return nil, WriteResponse{}, errors.New("volume name is required")
 	}
 	if parameters.Name == "" {
-		return nil, nil, errors.New("snapshot name is required")
+		return nil, WriteResponse{}, errors.New("snapshot name is required")
 	}
 
 	client, err := a.getClient(parameters.Cluster)
 	if err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), WriteResponse{}, err
 	}
 
 	if err := client.DeleteSnapshot(ctx, parameters.Volume, parameters.SVM, parameters.Name); err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), WriteResponse{}, err
 	}
 
 	return &mcp.CallToolResult{
 		Content: []mcp.Content{
 			&mcp.TextContent{Text: "Snapshot deleted successfully"},
 		},
-	}, nil, nil
+	}, WriteResponse{}, nil
 }
 
-func (a *App) RestoreSnapshot(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.Snapshot) (*mcp.CallToolResult, any, error) {
-	if !a.locks.TryLock(parameters.Cluster) {
-		return errorResult(fmt.Errorf("another write operation is in progress on cluster %s, please try again", parameters.Cluster)), nil, nil
-	}
-	defer a.locks.Unlock(parameters.Cluster)
-
+func (a *App) RestoreSnapshot(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.Snapshot) (*mcp.CallToolResult, WriteResponse, error) {
 	snapshotRestore, err := newRestoreSnapshot(parameters)
 	if err != nil {
-		return nil, nil, err
+		return nil, WriteResponse{}, err
 	}
 
 	client, err := a.getClient(parameters.Cluster)
 	if err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), WriteResponse{}, err
 	}
 
 	if err := client.RestoreSnapshot(ctx, parameters.Volume, parameters.SVM, snapshotRestore); err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), WriteResponse{}, err
 	}
 
 	return &mcp.CallToolResult{
 		Content: []mcp.Content{
 			&mcp.TextContent{Text: "Snapshot restored successfully"},
 		},
-	}, nil, nil
+	}, WriteResponse{}, nil
 }
 
-func (a *App) ModifySnapshot(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.SnapshotModify) (*mcp.CallToolResult, any, error) {
-	if !a.locks.TryLock(parameters.Cluster) {
-		return errorResult(fmt.Errorf("another write operation is in progress on cluster %s, please try again", parameters.Cluster)), nil, nil
-	}
-	defer a.locks.Unlock(parameters.Cluster)
-
+func (a *App) ModifySnapshot(ctx context.Context, _ *mcp.CallToolRequest, parameters tool.SnapshotModify) (*mcp.CallToolResult, WriteResponse, error) {
 	if parameters.SVM == "" {
-		return nil, nil, errors.New("SVM name is required")
+		return nil, WriteResponse{}, errors.New("SVM name is required")
 	}
 	if parameters.Volume == "" {
-		return nil, nil, errors.New("volume name is required")
+		return nil, WriteResponse{}, errors.New("volume name is required")
 	}
 	if parameters.Name == "" {
-		return nil, nil, errors.New("snapshot name is required")
+		return nil, WriteResponse{}, errors.New("snapshot name is required")
 	}
 
 	client, err := a.getClient(parameters.Cluster)
 	if err != nil {
-		return errorResult(err), nil, err
+		return errorResult(err), WriteResponse{}, err
 	}
 
 	snapshot := tool.Snapshot{
@@ -127,30 +107,30 @@ func (a *App) ModifySnapshot(ctx context.Context, _ *mcp.CallToolRequest, parame
 	case "restore":
 		snapshotRestore, err := newRestoreSnapshot(snapshot)
 		if err != nil {
-			return nil, nil, err
+			return nil, WriteResponse{}, err
 		}
 
 		if err := client.RestoreSnapshot(ctx, parameters.Volume, parameters.SVM, snapshotRestore); err != nil {
-			return errorResult(err), nil, err
+			return errorResult(err), WriteResponse{}, err
 		}
 
 		return &mcp.CallToolResult{
 			Content: []mcp.Content{
 				&mcp.TextContent{Text: "Snapshot restored successfully"},
 			},
-		}, nil, nil
+		}, WriteResponse{}, nil
 	case "delete":
 		if err := client.DeleteSnapshot(ctx, parameters.Volume, parameters.SVM, parameters.Name); err != nil {
-			return errorResult(err), nil, err
+			return errorResult(err), WriteResponse{}, err
 		}
 
 		return &mcp.CallToolResult{
 			Content: []mcp.Content{
 				&mcp.TextContent{Text: "Snapshot deleted successfully"},
 			},
-		}, nil, nil
+		}, WriteResponse{}, nil
 	default:
-		return errorResult(fmt.Errorf("unsupported operation %q; supported values: restore, delete", parameters.Operation)), nil, nil
+		return errorResult(fmt.Errorf("unsupported operation %q; supported values: restore, delete", parameters.Operation)), WriteResponse{}, nil
 	}
 }
 
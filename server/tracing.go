@@ -0,0 +1,42 @@
+package server
+
+import (
+	"context"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/netapp/ontap-mcp/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = tracing.Tracer("github.com/netapp/ontap-mcp/server")
+
+// startToolSpan starts the span of a tool call. It continues the W3C trace
+// context of the MCP HTTP request when there is one and is a root span
+// otherwise.
+func startToolSpan(ctx context.Context, req *mcp.CallToolRequest, name string, args map[string]any) (context.Context, trace.Span) {
+	if extra := callExtra(req); extra != nil {
+		ctx = tracing.Extract(ctx, extra.Header)
+	}
+	attrs := []attribute.KeyValue{
+		attribute.String("mcp.method.name", "tools/call"),
+		attribute.String("gen_ai.tool.name", name),
+	}
+	if cluster := stringArgument(args, "cluster_name"); cluster != "" {
+		attrs = append(attrs, attribute.String("ontap.cluster", cluster))
+	}
+	if req != nil && req.Session != nil && req.Session.ID() != "" {
+		attrs = append(attrs, attribute.String("mcp.session.id", req.Session.ID()))
+	}
+	return tracer.Start(ctx, "tools/call "+name, trace.WithSpanKind(trace.SpanKindServer), trace.WithAttributes(attrs...))
+}
+
+// endToolSpan records the outcome of a tool call on span and ends it.
+func endToolSpan(span trace.Span, outcome, errText string) {
+	span.SetAttributes(attribute.String("ontap_mcp.outcome", outcome))
+	if errText != "" {
+		span.SetStatus(codes.Error, errText)
+	}
+	span.End()
+}
@@ -0,0 +1,91 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+const testTraceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+
+type traceparentTransport struct{}
+
+func (traceparentTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("traceparent", "00-"+testTraceID+"-00f067aa0ba902b7-01")
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestToolCallTracing(t *testing.T) {
+	// The global tracer provider can only be replaced once, so it is not
+	// restored after the test.
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	app := newONTAPTestApp(t, Options{}, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			_, _ = w.Write([]byte(`{}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"num_records":1,"records":[{"uuid":"v-uuid"}]}`))
+	})
+	server := app.createMCPServer()
+	ts := httptest.NewServer(mcp.NewStreamableHTTPHandler(func(*http.Request) *mcp.Server { return server }, nil))
+	t.Cleanup(ts.Close)
+
+	client := mcp.NewClient(&mcp.Implementation{Name: "test", Version: "v0"}, nil)
+	session, err := client.Connect(t.Context(), &mcp.StreamableClientTransport{
+		Endpoint:             ts.URL,
+		HTTPClient:           &http.Client{Transport: traceparentTransport{}},
+		DisableStandaloneSSE: true,
+	}, nil)
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	t.Cleanup(func() { _ = session.Close() })
+
+	res, err := session.CallTool(t.Context(), &mcp.CallToolParams{Name: "delete_volume", Arguments: map[string]any{
+		"cluster_name": "dc1", "svm_name": "vs1", "volume_name": "vol1",
+	}})
+	if err != nil {
+		t.Fatalf("CallTool: %v", err)
+	}
+	if res.IsError {
+		t.Fatalf("unexpected tool error: %s", toolText(t, res))
+	}
+
+	var toolSpan sdktrace.ReadOnlySpan
+	for _, s := range recorder.Ended() {
+		if s.Name() == "tools/call delete_volume" {
+			toolSpan = s
+		}
+	}
+	if toolSpan == nil {
+		t.Fatal("expected a span for the tool call")
+	}
+	if got := toolSpan.SpanContext().TraceID().String(); got != testTraceID {
+		t.Fatalf("tool span trace ID = %s, want the incoming %s", got, testTraceID)
+	}
+	if !toolSpan.Parent().IsRemote() {
+		t.Fatal("expected the tool span to continue the incoming trace context")
+	}
+
+	var children []string
+	for _, s := range recorder.Ended() {
+		if s.Parent().SpanID() == toolSpan.SpanContext().SpanID() {
+			children = append(children, s.Name())
+		}
+	}
+	joined := strings.Join(children, ", ")
+	if !strings.Contains(joined, "GET /api/storage/volumes") || !strings.Contains(joined, "DELETE /api/storage/volumes/v-uuid") {
+		t.Fatalf("expected ONTAP request spans under the tool span, got %s", joined)
+	}
+}
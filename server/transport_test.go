@@ -0,0 +1,154 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/netapp/ontap-mcp/config"
+)
+
+func TestServeListsSameToolsAsHTTP(t *testing.T) {
+	tests := []struct {
+		name       string
+		readOnly   bool
+		wantTool   string
+		wantNoTool string
+	}{
+		{name: "read write", readOnly: false, wantTool: "create_volume"},
+		{name: "read only", readOnly: true, wantTool: "list_registered_clusters", wantNoTool: "create_volume"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.ONTAP{Pollers: map[string]*config.Poller{"dc1": {Name: "dc1"}}}
+			app, err := NewApp(cfg, Options{ReadOnly: tt.readOnly, ToolMode: "legacy", Transport: TransportStdio}, slog.Default())
+			if err != nil {
+				t.Fatalf("NewApp: %v", err)
+			}
+
+			ctx, cancel := context.WithCancel(t.Context())
+			defer cancel()
+
+			serverTransport, clientTransport := mcp.NewInMemoryTransports()
+			errCh := make(chan error, 1)
+			go func() {
+				errCh <- app.serve(ctx, app.createMCPServer(), serverTransport)
+			}()
+
+			client := mcp.NewClient(&mcp.Implementation{Name: "test", Version: "v0"}, nil)
+			session, err := client.Connect(ctx, clientTransport, nil)
+			if err != nil {
+				t.Fatalf("client connect: %v", err)
+			}
+
+			tools, err := session.ListTools(ctx, nil)
+			if err != nil {
+				t.Fatalf("ListTools: %v", err)
+			}
+			names := make(map[string]bool, len(tools.Tools))
+			for _, tool := range tools.Tools {
+				names[tool.Name] = true
+				if tt.readOnly && !tool.Annotations.ReadOnlyHint {
+					t.Errorf("tool %s is not read-only but server is in read-only mode", tool.Name)
+				}
+			}
+			if !names[tt.wantTool] {
+				t.Errorf("expected tool %s to be registered", tt.wantTool)
+			}
+			if tt.wantNoTool != "" && names[tt.wantNoTool] {
+				t.Errorf("expected tool %s not to be registered", tt.wantNoTool)
+			}
+
+			_ = session.Close()
+			if err := <-errCh; err != nil {
+				t.Fatalf("serve returned error: %v", err)
+			}
+		})
+	}
+}
+
+// TestStdioKeepsStdoutForJSONRPC runs a stdio session over the process's real
+// stdin and stdout. Anything else written to stdout would corrupt the
+// JSON-RPC stream the client reads.
+func TestStdioKeepsStdoutForJSONRPC(t *testing.T) {
+	app := newONTAPTestApp(t, Options{Transport: TransportStdio}, func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	})
+
+	stdinR, stdinW, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdoutR, stdoutW, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	oldStdin, oldStdout := os.Stdin, os.Stdout
+	os.Stdin, os.Stdout = stdinR, stdoutW
+	t.Cleanup(func() { os.Stdin, os.Stdout = oldStdin, oldStdout })
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- app.serve(ctx, app.createMCPServer(), &mcp.StdioTransport{})
+	}()
+
+	client := mcp.NewClient(&mcp.Implementation{Name: "test", Version: "v0"}, nil)
+	session, err := client.Connect(ctx, &mcp.IOTransport{Reader: stdoutR, Writer: stdinW}, nil)
+	if err != nil {
+		t.Fatalf("client connect: %v", err)
+	}
+
+	res, err := session.CallTool(ctx, &mcp.CallToolParams{Name: "create_schedule", Arguments: map[string]any{
+		"cluster_name":    "dc1",
+		"name":            "every-minute",
+		"cron_expression": "0 * * * * *",
+	}})
+	if err != nil {
+		t.Fatalf("CallTool: %v", err)
+	}
+	if !res.IsError || !strings.Contains(toolText(t, res), "use at most five") {
+		t.Fatalf("expected the six-field cron to be rejected, got %s", toolText(t, res))
+	}
+	// The stream must still be intact for the next request.
+	if _, err := session.ListTools(ctx, nil); err != nil {
+		t.Fatalf("ListTools after the schedule call: %v", err)
+	}
+
+	_ = session.Close()
+	_ = stdinW.Close()
+	if err := <-errCh; err != nil {
+		t.Fatalf("serve returned error: %v", err)
+	}
+}
+
+func TestHTTPServerStopsWhenContextIsCanceled(t *testing.T) {
+	cfg := &config.ONTAP{Pollers: map[string]*config.Poller{"dc1": {Name: "dc1"}}}
+	app, err := NewApp(cfg, Options{Host: "127.0.0.1", Port: 0, ToolMode: "legacy"}, slog.Default())
+	if err != nil {
+		t.Fatalf("NewApp: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(t.Context())
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- app.StartServer(ctx)
+	}()
+	cancel()
+
+	select {
+	case err := <-errCh:
+		if err != nil {
+			t.Fatalf("StartServer returned error: %v", err)
+		}
+	case <-time.After(httpShutdownTimeout + time.Second):
+		t.Fatal("StartServer did not return after the context was canceled")
+	}
+}
@@ -625,14 +625,34 @@ type OntapGetParams struct {
 	PathParams map[string]string `json:"path_params,omitzero" jsonschema:"values for path placeholders when the path contains {param} segments, e.g. {\"volume.uuid\":\"abc-123\"}. Get the UUID first from the collection endpoint."`
 	Filters    map[string]string `json:"filters,omitzero" jsonschema:"filter key-value pairs using ONTAP query syntax as JSON object, e.g. {\"svm.name\":\"vs1\",\"state\":\"online\"}"`
 	MaxRecords int               `json:"max_records,omitzero" jsonschema:"limit results. omit to return all records"`
+	Select     string            `json:"select,omitzero" jsonschema:"comma-separated dot-notation fields to keep in each returned record, e.g. \"name,space.used\". Applied after the records are fetched"`
+	SortBy     string            `json:"sort_by,omitzero" jsonschema:"comma-separated fields to sort the records by, each optionally followed by asc or desc, e.g. \"space.used desc,name\""`
+	GroupBy    string            `json:"group_by,omitzero" jsonschema:"comma-separated fields to group the records by, e.g. \"aggregates.name\". Each group reports its count and the requested aggregates"`
+	Aggregate  string            `json:"aggregate,omitzero" jsonschema:"comma-separated sum, avg, min or max of numeric fields, e.g. \"sum(space.used),max(space.used)\". Without group_by they summarize all records"`
+	TopN       int               `json:"top_n,omitzero" jsonschema:"keep the first N records after sort_by; with group_by, the first N records of each group"`
+}
+
+type OntapGetMultiParams struct {
+	Clusters   []string          `json:"clusters,omitzero" jsonschema:"cluster names from list_registered_clusters, or [\"all\"] for every cluster. Omit when datacenter is set"`
+	Datacenter string            `json:"datacenter,omitzero" jsonschema:"query every cluster in this datacenter, as configured in ontap.yaml. Omit when clusters is set"`
+	Fields     string            `json:"fields,omitzero" jsonschema:"comma-separated dot-notation fields to return, e.g. \"name,svm.name,space.size\""`
+	Path       string            `json:"path" jsonschema:"ONTAP REST API path without /api prefix, e.g. /storage/volumes"`
+	PathParams map[string]string `json:"path_params,omitzero" jsonschema:"values for path placeholders when the path contains {param} segments. The same values are used on every cluster"`
+	Filters    map[string]string `json:"filters,omitzero" jsonschema:"filter key-value pairs using ONTAP query syntax as JSON object, e.g. {\"space.percent_used\":\">90\"}"`
+	MaxRecords int               `json:"max_records,omitzero" jsonschema:"limit results per cluster. omit to return all records"`
 }
 
 type ListEndpointsParams struct {
-	Match string `json:"match,omitzero" jsonschema:"optional substring or regex to filter endpoint paths and summaries; omit to return all"`
+	Match   string `json:"match,omitzero" jsonschema:"optional case-insensitive regular expression matched against endpoint paths, summaries and tags, e.g. \"snapshot\" or \"nfs.*export\"; omit to return all"`
+	Tag     string `json:"tag,omitzero" jsonschema:"only return endpoints with this tag, e.g. \"storage\" or \"networking\""`
+	Offset  int    `json:"offset,omitzero" jsonschema:"number of endpoints to skip, for the next page"`
+	Limit   int    `json:"limit,omitzero" jsonschema:"maximum number of endpoints to return. Defaults to 50"`
+	Compact bool   `json:"compact,omitzero" jsonschema:"return only the paths, without summaries"`
 }
 
 type SearchEndpointsParams struct {
-	Query string `json:"query" jsonschema:"keyword to search across endpoint paths, summaries and tags"`
+	Query string `json:"query" jsonschema:"words to search for across endpoint paths, tags, summaries, and field and filter names and descriptions, e.g. \"snapshot restore\" or \"lif\""`
+	Limit int    `json:"limit,omitzero" jsonschema:"maximum number of endpoints to return, best first. Defaults to 10"`
 }
 
 type DescribeEndpointParams struct {
@@ -740,3 +760,14 @@ type SVMPeer struct {
 	Cluster string `json:"cluster_name" jsonschema:"cluster name"`
 	SVM     string `json:"svm_name" jsonschema:"SVM name"`
 }
+
+type Job struct {
+	Cluster string `json:"cluster_name" jsonschema:"cluster name"`
+	UUID    string `json:"uuid" jsonschema:"ONTAP job UUID, e.g. as returned by a mutating tool called with async: true"`
+}
+
+type JobList struct {
+	Cluster    string `json:"cluster_name" jsonschema:"cluster name"`
+	State      string `json:"state,omitzero" jsonschema:"only return jobs in this state (e.g., queued, running, paused, success, failure)"`
+	MaxRecords int    `json:"max_records,omitzero" jsonschema:"maximum number of jobs to return, most recently started first. Defaults to 20"`
+}
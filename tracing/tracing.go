@@ -0,0 +1,86 @@
+// Package tracing exports OpenTelemetry traces of MCP tool calls and the
+// ONTAP REST requests they make.
+//
+// Other packages create spans with the global tracer provider. Until Start is
+// called it is a no-op, so tracing costs nothing when it is not configured.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.43.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/netapp/ontap-mcp/config"
+	"github.com/netapp/ontap-mcp/version"
+)
+
+// tracesPath is appended to an OTLP endpoint given without a path, as the
+// OTEL_EXPORTER_OTLP_ENDPOINT specification requires.
+const tracesPath = "/v1/traces"
+
+// Tracer returns the tracer for the named instrumentation scope, typically a
+// package import path.
+func Tracer(name string) trace.Tracer {
+	return otel.Tracer(name)
+}
+
+// Start exports traces over OTLP/HTTP to endpoint, e.g.
+// http://otel-collector:4318, and accepts W3C trace context from callers. The
+// returned function flushes buffered spans and must be called before exit.
+func Start(ctx context.Context, endpoint string) (func(context.Context) error, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("invalid OTLP endpoint %q: must be a URL like http://localhost:4318", endpoint)
+	}
+	if u.Path == "" || u.Path == "/" {
+		u.Path = tracesPath
+	}
+
+	exporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(u.String()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP exporter: %w", err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(
+		semconv.ServiceName(config.AppName),
+		semconv.ServiceVersion(version.VERSION),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create trace resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	return provider.Shutdown, nil
+}
+
+// Extract returns ctx with the trace context carried by header, if any.
+func Extract(ctx context.Context, header http.Header) context.Context {
+	if header == nil {
+		return ctx
+	}
+	return otel.GetTextMapPropagator().Extract(ctx, propagation.HeaderCarrier(header))
+}
+
+// End records err on span, if any, and ends it.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}